@@ -6,13 +6,17 @@
 //   - Applying migrations from ./sql/*.sql (idempotent, recorded in _migrations).
 //   - Convenience helpers for the Daily Challenge (insert/check results, leaderboard).
 //
-// Note: This file assumes SQLite but can be adapted for other backends.
+// Note: The backend is chosen by DSN scheme (internal/dialect). A postgres://
+// DSN is opened with pgx behind dialect.OpenDB, which rewrites every query
+// (here and in internal/) from the SQLite form they are written in, and
+// migrates from ./sql/postgres instead of ./sql.
 
 package main
 
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"io/fs"
 	"os"
@@ -21,8 +25,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5/stdlib"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/rs/zerolog/log"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/dialect"
 )
 
 /**
@@ -31,11 +38,22 @@ import (
  * - Ensures parent directory exists for relative DSNs (e.g. ./data/app.db).
  * - Configures busy timeout and WAL journaling mode.
  * - Enforces foreign keys.
+ * - Postgres DSNs are opened with pgx, wrapped by dialect.OpenDB so the
+ *   SQLite-style queries used throughout are rewritten on the way through.
  *
  * @param dsn Database path or DSN string.
+ * @param d   Dialect selected from dsn (dialect.FromDSN).
  * @returns *sql.DB ready for queries/migrations.
  */
-func openDB(dsn string) (*sql.DB, error) {
+func openDB(dsn string, d dialect.Dialect) (*sql.DB, error) {
+	if d == dialect.Postgres {
+		c, err := stdlib.GetDefaultDriver().(driver.DriverContext).OpenConnector(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", d, err)
+		}
+		return dialect.OpenDB(d, c), nil
+	}
+
 	// Ensure directory exists for ./data/app.db, etc.
 	dir := filepath.Dir(dsn)
 	if dir != "." && dir != "" {
@@ -58,30 +76,37 @@ func openDB(dsn string) (*sql.DB, error) {
 }
 
 /**
- * migrate applies SQL migrations from ./sql directory.
+ * migrate applies SQL migrations from ./sql (SQLite) or ./sql/postgres.
  *
  * - Uses a _migrations table to track applied files.
- * - Executes each *.sql file in lexical order.
+ * - Executes each *.sql file in lexical order; subdirectories belong to
+ *   other dialects and are skipped.
  * - Skips if already applied.
  * - Detects "self-managed" scripts (with BEGIN TRANSACTION or PRAGMA FOREIGN_KEYS=OFF)
  *   and runs them outside of an outer transaction.
  */
-func migrate(db *sql.DB) error {
+func migrate(db *sql.DB, d dialect.Dialect) error {
 	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS _migrations (name TEXT PRIMARY KEY);`); err != nil {
 		return fmt.Errorf("create _migrations: %w", err)
 	}
 
-	// Collect ./sql/*.sql
+	// Collect ./sql/*.sql (or ./sql/postgres/*.sql)
 	root := "sql"
+	if d == dialect.Postgres {
+		root = filepath.Join("sql", "postgres")
+	}
 	var files []string
-	if err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+	if err := filepath.WalkDir(root, func(path string, e fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		if d.IsDir() {
+		if e.IsDir() {
+			if path != root {
+				return filepath.SkipDir
+			}
 			return nil
 		}
-		if strings.HasSuffix(strings.ToLower(d.Name()), ".sql") {
+		if strings.HasSuffix(strings.ToLower(e.Name()), ".sql") {
 			files = append(files, path)
 		}
 		return nil
@@ -189,7 +214,8 @@ func DailyAlreadyPlayed(ctx context.Context, db *sql.DB, userID, date string) (b
  * InsertDailyResult inserts a new daily result row.
  *
  * - Respects UNIQUE(user_id, date).
- * - If a row already exists, the insert is ignored (no error).
+ * - If a row already exists, the insert is ignored (no error); on Postgres
+ *   via ON CONFLICT DO NOTHING.
  */
 func InsertDailyResult(ctx context.Context, db *sql.DB, r DailyResult) error {
 	_, err := db.ExecContext(ctx, `
@@ -13,6 +13,8 @@
 //       - Lightweight HTTP router used for defining API routes.
 //   • github.com/golang-jwt/jwt/v5 v5.2.1
 //       - JWT implementation for authentication (sign/verify tokens).
+//   • github.com/jackc/pgx/v5 v5.6.0
+//       - Postgres driver (database/sql "pgx" stdlib) for postgres:// DSNs.
 //   • github.com/joho/godotenv v1.5.1
 //       - Loads environment variables from `.env` files in development.
 //   • github.com/mattn/go-sqlite3 v1.14.22
@@ -21,6 +23,8 @@
 //       - Structured, leveled logging with JSON output.
 //   • golang.org/x/crypto v0.26.0
 //       - Crypto utilities (bcrypt, HMAC, etc.), used in auth & daily mode.
+//   • golang.org/x/text v0.17.0
+//       - Unicode normalization (NFC/NFKC) for username keys.
 //
 // Indirect dependencies (transitive):
 //   • github.com/mattn/go-colorable v0.1.13
@@ -29,6 +33,9 @@
 //       - Detects if output is a terminal (isatty check).
 //   • golang.org/x/sys v0.23.0
 //       - Low-level system call utilities, pulled by crypto/logging deps.
+//   • github.com/jackc/pgpassfile, github.com/jackc/pgservicefile,
+//     github.com/jackc/puddle/v2, golang.org/x/sync
+//       - Pulled in by pgx (.pgpass/service files, connection pooling).
 
 module github.com/robalobadob/wordle/apps/go-server
 
@@ -37,14 +44,20 @@ go 1.22
 require (
 	github.com/go-chi/chi/v5 v5.0.12
 	github.com/golang-jwt/jwt/v5 v5.2.1
+	github.com/jackc/pgx/v5 v5.6.0
 	github.com/joho/godotenv v1.5.1
 	github.com/mattn/go-sqlite3 v1.14.22
 	github.com/rs/zerolog v1.33.0
 	golang.org/x/crypto v0.26.0
+	golang.org/x/text v0.17.0
 )
 
 require (
-	github.com/mattn/go-colorable v0.1.13 // indirect
-	github.com/mattn/go-isatty v0.0.19    // indirect
-	golang.org/x/sys v0.23.0              // indirect
+	github.com/jackc/pgpassfile v1.0.0                                // indirect
+	github.com/jackc/pgservicefile v0.0.0-20221227161230-091c0ba34f0a // indirect
+	github.com/jackc/puddle/v2 v2.2.1                                 // indirect
+	github.com/mattn/go-colorable v0.1.13                             // indirect
+	github.com/mattn/go-isatty v0.0.19                                // indirect
+	golang.org/x/sync v0.8.0                                          // indirect
+	golang.org/x/sys v0.23.0                                          // indirect
 )
@@ -0,0 +1,106 @@
+// apps/go-server/internal/analysis/difficulty.go
+//
+// Heuristic difficulty scoring for answer words.
+//
+// A score in [0, 100] combines three signals, each normalized to [0, 1]:
+//   - rarity     (45%) – how uncommon the word's letters are across the pool
+//   - repeats    (20%) – repeated letters are harder to find
+//   - neighbours (35%) – words differing in exactly one position ("_IGHT"
+//                        traps); 10+ neighbours counts as maximally hard
+//
+// Scores are only comparable for the same pool; callers pass words.Answers()
+// (or a per-length pool) so results are deterministic.
+
+package analysis
+
+import "math"
+
+// neighbourCap is the neighbour count treated as maximally ambiguous.
+const neighbourCap = 10
+
+// Scorer caches pool statistics for repeated Difficulty calls.
+type Scorer struct {
+	pool    []string
+	freq    [26]int // words in pool containing each letter
+	maxFreq int
+}
+
+// NewScorer precomputes letter frequencies over pool (lowercase a–z words).
+func NewScorer(pool []string) *Scorer {
+	s := &Scorer{pool: pool}
+	for _, w := range pool {
+		var seen [26]bool
+		for i := 0; i < len(w); i++ {
+			c := w[i] - 'a'
+			if c < 26 && !seen[c] {
+				seen[c] = true
+				s.freq[c]++
+			}
+		}
+	}
+	for _, f := range s.freq {
+		s.maxFreq = max(s.maxFreq, f)
+	}
+	return s
+}
+
+// Difficulty scores answer against the scorer's pool (higher = harder),
+// rounded to one decimal place. Empty answers score 0.
+func (s *Scorer) Difficulty(answer string) float64 {
+	if answer == "" {
+		return 0
+	}
+
+	// Rarity: mean over distinct letters of 1 - freq/maxFreq.
+	var seen [26]bool
+	distinct, rarity := 0, 0.0
+	for i := 0; i < len(answer); i++ {
+		c := answer[i] - 'a'
+		if c >= 26 || seen[c] {
+			continue
+		}
+		seen[c] = true
+		distinct++
+		if s.maxFreq > 0 {
+			rarity += 1 - float64(s.freq[c])/float64(s.maxFreq)
+		} else {
+			rarity++
+		}
+	}
+	if distinct > 0 {
+		rarity /= float64(distinct)
+	}
+
+	// Repeats: share of letters that duplicate another; 40% (e.g. "mamma") is max.
+	repeats := math.Min(1, float64(len(answer)-distinct)/float64(len(answer))/0.4)
+
+	// Neighbours: same-length pool words one substitution away.
+	n := 0
+	for _, w := range s.pool {
+		if len(w) == len(answer) && oneApart(w, answer) {
+			n++
+		}
+	}
+	neighbours := math.Min(1, float64(n)/neighbourCap)
+
+	score := 100 * (0.45*rarity + 0.20*repeats + 0.35*neighbours)
+	return math.Round(score*10) / 10
+}
+
+// Difficulty is a one-off NewScorer(pool).Difficulty(answer).
+func Difficulty(answer string, pool []string) float64 {
+	return NewScorer(pool).Difficulty(answer)
+}
+
+// oneApart reports whether equal-length a and b differ in exactly one position.
+func oneApart(a, b string) bool {
+	diff := 0
+	for i := 0; i < len(a); i++ {
+		if a[i] != b[i] {
+			if diff++; diff > 1 {
+				return false
+			}
+		}
+	}
+	return diff == 1
+}
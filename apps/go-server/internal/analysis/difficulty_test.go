@@ -0,0 +1,30 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+func TestDifficultyRanksHardWordsHigher(t *testing.T) {
+	if _, err := words.Init(); err != nil {
+		t.Fatal(err)
+	}
+	s := NewScorer(words.Answers())
+	for _, tc := range []struct{ hard, easy string }{
+		{"jazzy", "crane"},
+		{"fuzzy", "slate"},
+		{"mamma", "arise"},
+	} {
+		h, e := s.Difficulty(tc.hard), s.Difficulty(tc.easy)
+		if h <= e {
+			t.Errorf("Difficulty(%s) = %.1f, not above Difficulty(%s) = %.1f", tc.hard, h, tc.easy, e)
+		}
+		if h > 100 || e < 0 {
+			t.Errorf("scores out of range: %s=%.1f %s=%.1f", tc.hard, h, tc.easy, e)
+		}
+	}
+	if got := s.Difficulty(""); got != 0 {
+		t.Errorf("Difficulty(\"\") = %v, want 0", got)
+	}
+}
@@ -0,0 +1,80 @@
+// apps/go-server/internal/analysis/hint.go
+//
+// Next-guess hints for a game in progress.
+//
+// The heuristic is the same one the par solver uses: score every guess in the
+// pool against every remaining candidate, bucket the candidates by feedback,
+// and pick the guess with the smallest sum of squared bucket sizes (i.e. the
+// fewest candidates left on average). Ties prefer guesses that could still be
+// the answer, then earlier pool words. With two or fewer candidates left the
+// first of them is suggested outright.
+//
+// Cost is pool × candidates pattern evaluations. Above hintBudget the pool is
+// cut down to the candidates themselves, and if that is still too large to
+// the first hintBudget/len(candidates) of them (at least one), so a hint on
+// an untouched board stays well under a second. The context is checked every
+// cancelEvery guesses, so an abandoned request stops early.
+
+package analysis
+
+import (
+	"context"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// hintBudget bounds the pattern evaluations one Hint may spend.
+const hintBudget = 2_000_000
+
+// Hint returns the suggested next guess from pool given the remaining
+// candidates, or "" when there are none. Pool words of a different length
+// than the candidates are ignored. It returns ctx.Err() if ctx is done first.
+func Hint(ctx context.Context, pool, cands []string) (string, error) {
+	if len(cands) == 0 {
+		return "", nil
+	}
+	var guesses []string
+	for _, w := range pool {
+		if len(w) == len(cands[0]) {
+			guesses = append(guesses, w)
+		}
+	}
+	if len(guesses)*len(cands) > hintBudget {
+		guesses = cands
+		if n := max(hintBudget/len(cands), 1); len(guesses) > n {
+			guesses = guesses[:n]
+		}
+	}
+	return bestGuess(ctx, guesses, cands)
+}
+
+// Candidates returns the pool words (in pool order) that score every guess
+// exactly as answer does, answer included; it is appended if pool lacks it.
+func Candidates(pool []string, answer string, guesses []string) []string {
+	want := make([]uint16, len(guesses))
+	for i, g := range guesses {
+		want[i] = words.PatternCode(g, answer)
+	}
+	out := []string{}
+	seen := false
+	for _, w := range pool {
+		if len(w) != len(answer) {
+			continue
+		}
+		ok := true
+		for i, g := range guesses {
+			if words.PatternCode(g, w) != want[i] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			out = append(out, w)
+			seen = seen || w == answer
+		}
+	}
+	if !seen {
+		out = append(out, answer)
+	}
+	return out
+}
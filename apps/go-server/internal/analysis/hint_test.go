@@ -0,0 +1,48 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+var hintPool = []string{
+	"crane", "slate", "trace", "crate", "react", "caret", "cater",
+	"stare", "share", "shard", "chard", "charm", "pound", "mound",
+	"round", "sound", "wound", "bound", "found", "hound",
+}
+
+func TestHintNarrowsAfterInformativeGuess(t *testing.T) {
+	ctx := context.Background()
+	before := Candidates(hintPool, "sound", nil)
+	after := Candidates(hintPool, "sound", []string{"crane"})
+	if len(after) >= len(before) {
+		t.Fatalf("candidates %d after crane, want fewer than %d", len(after), len(before))
+	}
+	if !slices.Contains(after, "sound") {
+		t.Fatalf("candidates %v lost the answer", after)
+	}
+	got, err := Hint(ctx, hintPool, after)
+	if err != nil {
+		t.Fatalf("Hint: %v", err)
+	}
+	if !slices.Contains(hintPool, got) {
+		t.Fatalf("suggestion %q not in the pool", got)
+	}
+}
+
+func TestHintEmptyCandidates(t *testing.T) {
+	got, err := Hint(context.Background(), hintPool, nil)
+	if got != "" || err != nil {
+		t.Fatalf("Hint(nil cands) = %q, %v; want \"\", nil", got, err)
+	}
+}
+
+func TestHintCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Hint(ctx, hintPool, hintPool); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Hint on a cancelled context: err = %v, want context.Canceled", err)
+	}
+}
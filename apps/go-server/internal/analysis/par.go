@@ -0,0 +1,116 @@
+// apps/go-server/internal/analysis/par.go
+//
+// Solver par: how many guesses a fixed, deterministic solver needs for an
+// answer, as a "par" to compare players against.
+//
+// The solver plays the openers in order, then repeatedly guesses the pool
+// word that leaves the fewest candidates on average (sum of squared feedback
+// bucket sizes), preferring words that could still be the answer and then
+// earlier pool words on ties. With two or fewer candidates left it guesses
+// the first of them.
+//
+// The result depends only on (pool, openers, answer), so it is stable across
+// runs and machines as long as the pool order is.
+
+package analysis
+
+import (
+	"context"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// parCap bounds the solver; no real pool needs anywhere near this many.
+const parCap = 20
+
+// cancelEvery is how many guesses bestGuess scores between context checks.
+const cancelEvery = 64
+
+// SolverPar returns the solver's guess count for answer, or 0 when answer is
+// empty or longer than words.MaxLength. Openers and pool words of a
+// different length than answer are skipped; answer is treated as a candidate
+// even if pool lacks it.
+func SolverPar(pool, openers []string, answer string) int {
+	if answer == "" || len(answer) > words.MaxLength {
+		return 0
+	}
+	var guesses []string
+	cands := []string{}
+	inPool := false
+	for _, w := range pool {
+		if len(w) == len(answer) {
+			guesses = append(guesses, w)
+			cands = append(cands, w)
+			inPool = inPool || w == answer
+		}
+	}
+	if !inPool {
+		cands = append(cands, answer)
+	}
+
+	n := 0
+	for _, op := range openers {
+		if len(op) != len(answer) {
+			continue
+		}
+		n++
+		if op == answer {
+			return n
+		}
+		cands = narrow(cands, op, answer)
+	}
+	for n < parCap {
+		n++
+		g, _ := bestGuess(context.Background(), guesses, cands)
+		if g == answer {
+			return n
+		}
+		cands = narrow(cands, g, answer)
+	}
+	return n
+}
+
+// bestGuess picks the guess minimising the expected candidates left, or
+// returns ctx.Err() if ctx is done before the pool has been scored.
+func bestGuess(ctx context.Context, pool, cands []string) (string, error) {
+	if len(cands) <= 2 {
+		return cands[0], nil
+	}
+	isCand := make(map[string]bool, len(cands))
+	for _, c := range cands {
+		isCand[c] = true
+	}
+	best, bestCost, bestCand := "", -1, false
+	buckets := make(map[uint16]int, len(cands))
+	for i, g := range pool {
+		if i%cancelEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+		}
+		clear(buckets)
+		for _, c := range cands {
+			buckets[words.PatternCode(g, c)]++
+		}
+		cost := 0
+		for _, k := range buckets {
+			cost += k * k
+		}
+		if bestCost < 0 || cost < bestCost || (cost == bestCost && isCand[g] && !bestCand) {
+			best, bestCost, bestCand = g, cost, isCand[g]
+		}
+	}
+	return best, nil
+}
+
+// narrow keeps the candidates that give the same feedback for guess as answer.
+func narrow(cands []string, guess, answer string) []string {
+	want := words.PatternCode(guess, answer)
+	out := cands[:0:0]
+	for _, c := range cands {
+		if words.PatternCode(guess, c) == want {
+			out = append(out, c)
+		}
+	}
+	return out
+}
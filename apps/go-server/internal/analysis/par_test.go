@@ -0,0 +1,31 @@
+package analysis
+
+import "testing"
+
+func TestSolverPar(t *testing.T) {
+	pool := []string{"batch", "catch", "hatch", "latch", "match", "patch", "watch"}
+	for _, tc := range []struct {
+		name    string
+		pool    []string
+		openers []string
+		answer  string
+		want    int
+	}{
+		{"opener is the answer", pool, []string{"watch"}, "watch", 1},
+		// batch leaves six -atch words; every later guess is a candidate that
+		// rules out only itself, taken in pool order.
+		{"worst case pool", pool, []string{"batch"}, "watch", 7},
+		{"found early", pool, []string{"batch"}, "catch", 2},
+		{"wrong-length opener skipped", pool, []string{"batches", "crane"}, "batch", 2},
+		{"answer missing from the pool", []string{"crane", "slate"}, []string{"slate"}, "trace", 2},
+		{"no answer", pool, nil, "", 0},
+	} {
+		first := SolverPar(tc.pool, tc.openers, tc.answer)
+		if first != tc.want {
+			t.Errorf("%s: SolverPar = %d, want %d", tc.name, first, tc.want)
+		}
+		if again := SolverPar(tc.pool, tc.openers, tc.answer); again != first {
+			t.Errorf("%s: second run = %d, first %d", tc.name, again, first)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// apps/go-server/internal/badges/badges.go
+//
+// Derived achievements ("badges") computed from a user's finished games.
+//
+// Rules are pure functions over a chronological list of results, so the same
+// history always yields the same badges. Each badge is earned at most once,
+// at the time of the first result that satisfies it.
+//
+// Badges:
+//   - first_win    – first won game (classic or daily)
+//   - streak_10    – 10 consecutive wins
+//   - under_30s    – a win in under 30 seconds (needs a recorded duration)
+//   - solved_in_2  – a win in two guesses or fewer
+//   - daily_solver – first won daily challenge
+
+package badges
+
+import (
+	"sort"
+	"time"
+)
+
+// Result is one finished game as seen by the badge rules.
+type Result struct {
+	At         time.Time // when the game finished
+	Won        bool
+	Guesses    int
+	DurationMs int64 // 0 when unknown (duration not recorded)
+	Daily      bool  // true for daily challenge results
+}
+
+// Badge is an earned achievement.
+type Badge struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	EarnedAt time.Time `json:"earnedAt"`
+}
+
+// rule awards a badge for the first result where ok returns true.
+// streak is the number of consecutive wins up to and including r.
+type rule struct {
+	id, name string
+	ok       func(r Result, streak int) bool
+}
+
+var rules = []rule{
+	{"first_win", "First win", func(r Result, _ int) bool { return r.Won }},
+	{"streak_10", "10-win streak", func(_ Result, streak int) bool { return streak >= 10 }},
+	{"under_30s", "Solved in under 30 seconds", func(r Result, _ int) bool {
+		return r.Won && r.DurationMs > 0 && r.DurationMs < 30_000
+	}},
+	{"solved_in_2", "Solved in 2", func(r Result, _ int) bool { return r.Won && r.Guesses > 0 && r.Guesses <= 2 }},
+	{"daily_solver", "Daily solver", func(r Result, _ int) bool { return r.Won && r.Daily }},
+}
+
+// Compute returns the badges earned by history, in the order they were earned
+// (ties keep rule order). history need not be sorted.
+func Compute(history []Result) []Badge {
+	sorted := append([]Result(nil), history...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].At.Before(sorted[j].At) })
+
+	earned := make(map[string]bool, len(rules))
+	out := []Badge{}
+	streak := 0
+	for _, r := range sorted {
+		if r.Won {
+			streak++
+		} else {
+			streak = 0
+		}
+		for _, rl := range rules {
+			if !earned[rl.id] && rl.ok(r, streak) {
+				earned[rl.id] = true
+				out = append(out, Badge{ID: rl.id, Name: rl.name, EarnedAt: r.At})
+			}
+		}
+	}
+	return out
+}
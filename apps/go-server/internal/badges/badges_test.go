@@ -0,0 +1,66 @@
+package badges
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompute(t *testing.T) {
+	t0 := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(i int) time.Time { return t0.Add(time.Duration(i) * time.Hour) }
+
+	var history []Result
+	// A loss, then a slow classic win: first_win only.
+	history = append(history,
+		Result{At: at(0), Won: false, Guesses: 6},
+		Result{At: at(1), Won: true, Guesses: 5, DurationMs: 90_000},
+	)
+	// Nine more wins make a streak of ten; the fourth is a fast daily in 2.
+	for i := 2; i <= 10; i++ {
+		r := Result{At: at(i), Won: true, Guesses: 4, DurationMs: 60_000}
+		if i == 4 {
+			r = Result{At: at(i), Won: true, Guesses: 2, DurationMs: 20_000, Daily: true}
+		}
+		history = append(history, r)
+	}
+	// Reverse so Compute has to sort.
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
+	}
+
+	want := []struct {
+		id string
+		at time.Time
+	}{
+		{"first_win", at(1)},
+		{"under_30s", at(4)},
+		{"solved_in_2", at(4)},
+		{"daily_solver", at(4)},
+		{"streak_10", at(10)},
+	}
+	got := Compute(history)
+	if len(got) != len(want) {
+		t.Fatalf("Compute = %+v, want %d badges", got, len(want))
+	}
+	for i, w := range want {
+		if got[i].ID != w.id || !got[i].EarnedAt.Equal(w.at) || got[i].Name == "" {
+			t.Errorf("badge %d = %+v, want %s at %s", i, got[i], w.id, w.at)
+		}
+	}
+}
+
+func TestComputeNeedsDurationAndUnbrokenStreak(t *testing.T) {
+	t0 := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	var history []Result
+	for i := 0; i < 15; i++ {
+		// Nine wins, a loss, then five more: never ten in a row.
+		history = append(history, Result{At: t0.AddDate(0, 0, i), Won: i != 9, Guesses: 3})
+	}
+	got := Compute(history)
+	if len(got) != 1 || got[0].ID != "first_win" {
+		t.Fatalf("Compute = %+v, want only first_win (no durations, streak broken)", got)
+	}
+	if got := Compute(nil); got == nil || len(got) != 0 {
+		t.Fatalf("Compute(nil) = %#v, want an empty non-nil slice", got)
+	}
+}
@@ -4,6 +4,12 @@
 // Provides deterministic mapping from dates to word indices,
 // ensuring that all players see the same solution word on a given date
 // (while allowing server operators to rotate the mapping with a secret salt).
+// Salts are versioned so a rotation can be told apart from older mappings;
+// see Store.PinWordIndex for how past dates stay stable. PickIndex moves a
+// date off an answer that was used recently.
+//
+// Dates roll over at midnight in a configurable zone (DAILY_TIMEZONE; UTC by
+// default): DateKey, WordIndex, and Proof take the *time.Location, nil = UTC.
 
 package daily
 
@@ -11,26 +17,64 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"time"
+	_ "time/tzdata" // zone database for DAILY_TIMEZONE on images without one
 )
 
 /**
- * DateKey returns a normalized string representation of a date.
+ * Location resolves the daily rollover zone from an IANA name.
+ *
+ * - "" is UTC.
+ * - An unknown name returns UTC along with the lookup error.
+ */
+func Location(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC, err
+	}
+	return loc, nil
+}
+
+/**
+ * DateKey returns the calendar date of t in loc.
  *
- * - Always UTC.
+ * - loc nil means UTC.
+ * - Times parsed from a date key are UTC midnight; pass nil for those.
  * - Format: "YYYY-MM-DD"
  *
- * Example: 2025-08-24 15:32 UTC → "2025-08-24"
+ * Example: 2025-08-24 03:32 UTC → "2025-08-24" (UTC), "2025-08-23" (America/Los_Angeles)
+ */
+func DateKey(t time.Time, loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format("2006-01-02")
+}
+
+/**
+ * NextRollover returns the first instant after t whose DateKey in loc differs
+ * from t's: the next local midnight (loc nil means UTC).
+ *
+ * - Built with time.Date, so 23- and 25-hour DST days still land on midnight
+ *   (or on the first valid time, where midnight is skipped).
  */
-func DateKey(t time.Time) string {
-	return t.UTC().Format("2006-01-02")
+func NextRollover(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	l := t.In(loc)
+	return time.Date(l.Year(), l.Month(), l.Day()+1, 0, 0, 0, 0, loc)
 }
 
 /**
  * WordIndex produces a deterministic index into the answers list for a given date.
  *
  * Implementation:
- *   - Normalize the date to "YYYY-MM-DD" via DateKey.
+ *   - Normalize the date to "YYYY-MM-DD" via DateKey (in loc).
  *   - Compute HMAC-SHA256 of that string using the provided salt.
  *   - Take the first 8 bytes of the digest as a uint64.
  *   - Return (digest % answersLen) as the index.
@@ -40,20 +84,153 @@ func DateKey(t time.Time) string {
  *   - Changing the salt → rotates the mapping (useful if word list order is known).
  *
  * @param date        Date for which to compute index.
+ * @param loc         Rollover zone date is read in (nil = UTC).
  * @param salt        Secret string that personalizes HMAC; should be constant server-side.
  * @param answersLen  Length of answers list (must be > 0).
  * @return int index in [0, answersLen).
  */
-func WordIndex(date time.Time, salt string, answersLen int) int {
+func WordIndex(date time.Time, loc *time.Location, salt string, answersLen int) int {
 	if answersLen <= 0 {
 		return 0
 	}
-	dk := DateKey(date)
-	h := hmac.New(sha256.New, []byte(salt))
-	h.Write([]byte(dk))
-	sum := h.Sum(nil)
+	sum := digest(date, loc, salt)
 
 	// Use first 8 bytes → uint64 for uniform modulus distribution.
 	n := binary.BigEndian.Uint64(sum[:8])
 	return int(n % uint64(answersLen))
 }
+
+/**
+ * Proof returns the hex-encoded HMAC-SHA256 of DateKey(date, loc) under salt.
+ *
+ * - WordIndex is the first 8 bytes of this digest (big-endian) mod answersLen,
+ *   so once the salt is published a client can recompute the proof and
+ *   confirm the index it was shown.
+ */
+func Proof(date time.Time, loc *time.Location, salt string) string {
+	return hex.EncodeToString(digest(date, loc, salt))
+}
+
+/**
+ * Commitment returns the hex-encoded SHA-256 of salt.
+ *
+ * - Publishing it binds the server to one salt without revealing it; once the
+ *   salt is disclosed anyone can hash it, compare, and recompute every index
+ *   and proof made under it.
+ */
+func Commitment(salt string) string {
+	sum := sha256.Sum256([]byte(salt))
+	return hex.EncodeToString(sum[:])
+}
+
+// digest is HMAC-SHA256(salt, DateKey(date, loc)).
+func digest(date time.Time, loc *time.Location, salt string) []byte {
+	h := hmac.New(sha256.New, []byte(salt))
+	h.Write([]byte(DateKey(date, loc)))
+	return h.Sum(nil)
+}
+
+/**
+ * Salt is a versioned daily salt.
+ *
+ * - Bump Version whenever Secret changes; results and pinned dates record it.
+ */
+type Salt struct {
+	Version int
+	Secret  string
+}
+
+/**
+ * WordIndex is daily.WordIndex under this salt.
+ */
+func (s Salt) WordIndex(date time.Time, loc *time.Location, answersLen int) int {
+	return WordIndex(date, loc, s.Secret, answersLen)
+}
+
+/**
+ * Proof is daily.Proof under this salt.
+ */
+func (s Salt) Proof(date time.Time, loc *time.Location) string {
+	return Proof(date, loc, s.Secret)
+}
+
+/**
+ * PickIndex is WordIndex with recently used answers skipped.
+ *
+ * - Starts at WordIndex and steps forward (wrapping) past every index for
+ *   which used returns true, so the result is still deterministic given the
+ *   salt and the history used reports.
+ * - If every index is used, the plain WordIndex is returned.
+ */
+func PickIndex(date time.Time, loc *time.Location, salt string, answersLen int, used func(idx int) bool) int {
+	start := WordIndex(date, loc, salt, answersLen)
+	for i := 0; i < answersLen; i++ {
+		idx := (start + i) % answersLen
+		if !used(idx) {
+			return idx
+		}
+	}
+	return start
+}
+
+/**
+ * Commitment is daily.Commitment of this salt.
+ */
+func (s Salt) Commitment() string {
+	return Commitment(s.Secret)
+}
+
+/**
+ * PickIndex is daily.PickIndex under this salt.
+ */
+func (s Salt) PickIndex(date time.Time, loc *time.Location, answersLen int, used func(idx int) bool) int {
+	return PickIndex(date, loc, s.Secret, answersLen, used)
+}
+
+/**
+ * PuzzleNumber returns the 1-based puzzle number for a date key.
+ *
+ * - Puzzle #1 is the daily for the epoch date itself.
+ * - Dates before the epoch (or unparsable input) return 0.
+ *
+ * @param date   Date key ("YYYY-MM-DD").
+ * @param epoch  Date key of the first puzzle.
+ * @return int puzzle number, or 0 if it cannot be derived.
+ */
+func PuzzleNumber(date, epoch string) int {
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 0
+	}
+	e, err := time.Parse("2006-01-02", epoch)
+	if err != nil || d.Before(e) {
+		return 0
+	}
+	return int(d.Sub(e).Hours()/24) + 1
+}
+
+/**
+ * Difficulty levels a player can choose for their daily.
+ *
+ *   - easy:   normal + each in-progress guess reveals one unsolved letter.
+ *   - normal: guesses must be in the allowed list (default).
+ *   - hard:   normal + revealed hints must be reused (hard mode).
+ *
+ * Every level validates guesses against the allowed list.
+ */
+const (
+	DifficultyEasy   = "easy"
+	DifficultyNormal = "normal"
+	DifficultyHard   = "hard"
+)
+
+/**
+ * ValidDifficulty reports whether s is one of the known difficulty levels.
+ */
+func ValidDifficulty(s string) bool {
+	switch s {
+	case DifficultyEasy, DifficultyNormal, DifficultyHard:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package daily
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateKeyStraddlesLocalMidnight(t *testing.T) {
+	la, err := Location("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 23:59 and 00:01 Pacific (PDT, UTC-7) on either side of local midnight.
+	before := time.Date(2026, 10, 15, 6, 59, 0, 0, time.UTC)
+	after := time.Date(2026, 10, 15, 7, 1, 0, 0, time.UTC)
+
+	if got := DateKey(before, la); got != "2026-10-14" {
+		t.Errorf("DateKey(%v, LA) = %s, want 2026-10-14", before, got)
+	}
+	if got := DateKey(after, la); got != "2026-10-15" {
+		t.Errorf("DateKey(%v, LA) = %s, want 2026-10-15", after, got)
+	}
+	if DateKey(before, nil) != DateKey(after, nil) {
+		t.Errorf("UTC keys differ: %s vs %s", DateKey(before, nil), DateKey(after, nil))
+	}
+
+	if WordIndex(before, la, "salt", 1000) != WordIndex(time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC), nil, "salt", 1000) {
+		t.Error("WordIndex before local midnight doesn't match the previous date key's index")
+	}
+	if WordIndex(after, la, "salt", 1000) != WordIndex(after, nil, "salt", 1000) {
+		t.Error("WordIndex after local midnight doesn't match the same date key's index")
+	}
+
+	if got, want := NextRollover(before, la), time.Date(2026, 10, 15, 0, 0, 0, 0, la); !got.Equal(want) {
+		t.Errorf("NextRollover(%v, LA) = %v, want %v", before, got, want)
+	}
+}
+
+func TestLocationFallsBackToUTC(t *testing.T) {
+	if loc, err := Location(""); err != nil || loc != time.UTC {
+		t.Fatalf(`Location("") = %v, %v; want UTC`, loc, err)
+	}
+	if loc, err := Location("Not/AZone"); err == nil || loc != time.UTC {
+		t.Fatalf("Location(invalid) = %v, %v; want UTC and an error", loc, err)
+	}
+}
+
+// simulatePicks picks n consecutive days from start, each skipping the
+// indices picked in the previous window days, as the daily server does.
+func simulatePicks(start time.Time, n, answersLen, window int) []int {
+	var picks []int
+	for d := 0; d < n; d++ {
+		recent := map[int]bool{}
+		for i := max(0, d-window); i < d; i++ {
+			recent[picks[i]] = true
+		}
+		idx := PickIndex(start.AddDate(0, 0, d), nil, "salt", answersLen, func(idx int) bool { return recent[idx] })
+		picks = append(picks, idx)
+	}
+	return picks
+}
+
+func TestPickIndexNoRepeatWithinWindow(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, answersLen := range []int{15, 20, 40, 2309} {
+		picks := simulatePicks(start, 30, answersLen, 14)
+		for d, idx := range picks {
+			if idx < 0 || idx >= answersLen {
+				t.Fatalf("len %d day %d: index %d out of range", answersLen, d, idx)
+			}
+			for prev := max(0, d-14); prev < d; prev++ {
+				if picks[prev] == idx {
+					t.Fatalf("len %d: index %d on day %d repeats day %d", answersLen, idx, d, prev)
+				}
+			}
+		}
+		again := simulatePicks(start, 30, answersLen, 14)
+		for d := range picks {
+			if again[d] != picks[d] {
+				t.Fatalf("len %d day %d: second run picked %d, first %d", answersLen, d, again[d], picks[d])
+			}
+		}
+	}
+
+	// With nothing used it is plain WordIndex; with everything used too.
+	day := start.AddDate(0, 0, 3)
+	want := WordIndex(day, nil, "salt", 10)
+	if got := PickIndex(day, nil, "salt", 10, func(int) bool { return false }); got != want {
+		t.Errorf("nothing used: %d, want WordIndex %d", got, want)
+	}
+	if got := PickIndex(day, nil, "salt", 10, func(int) bool { return true }); got != want {
+		t.Errorf("everything used: %d, want WordIndex %d", got, want)
+	}
+}
+
+func TestCommitment(t *testing.T) {
+	// SHA-256("abc"), FIPS 180-2 appendix B.1.
+	const want = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if got := Commitment("abc"); got != want {
+		t.Fatalf("Commitment(abc) = %s, want %s", got, want)
+	}
+	if got := (Salt{Version: 2, Secret: "abc"}).Commitment(); got != want {
+		t.Fatalf("Salt.Commitment = %s, want %s", got, want)
+	}
+	day := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	if Proof(day, nil, "abc") != Proof(day, nil, "abc") || Proof(day, nil, "abc") == Proof(day, nil, "abd") {
+		t.Fatal("Proof is not a deterministic function of the salt")
+	}
+}
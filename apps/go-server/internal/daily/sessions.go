@@ -0,0 +1,108 @@
+// apps/go-server/internal/daily/sessions.go
+//
+// Persistence for in-progress daily sessions (table daily_sessions), so a
+// restart doesn't lose games being played. The HTTP layer keeps its own
+// in-memory copy as a cache and writes through here.
+
+package daily
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+/**
+ * Session is the stored state of one player's daily game for one date.
+ * The answer is not stored; it is derived from WordIndex.
+ */
+type Session struct {
+	UserID       string
+	Date         string // "YYYY-MM-DD"
+	GameID       string
+	WordIndex    int
+	SaltVersion  int
+	Words        []string // guesses in order
+	Start        time.Time
+	LastSeen     time.Time
+	ActiveMs     int64
+	Finished     bool
+	Won          bool
+	Difficulty   string
+	Fingerprint  string
+	PracticeCode string
+}
+
+/**
+ * SaveSession inserts or updates a session.
+ *
+ * - A save holding fewer guesses than the stored row is ignored, so a stale
+ *   snapshot written late can't roll a session back.
+ */
+func (s *Store) SaveSession(ctx context.Context, sess Session) error {
+	board, err := json.Marshal(sess.Words)
+	if err != nil {
+		return err
+	}
+	if sess.Words == nil {
+		board = []byte("[]")
+	}
+	if sess.Difficulty == "" {
+		sess.Difficulty = DifficultyNormal
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO daily_sessions (user_id, date, game_id, word_index, salt_version, guesses, started_at, last_seen,
+		                             active_ms, finished, won, difficulty, fingerprint, practice_code)
+		 VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?)
+		 ON CONFLICT(user_id, date) DO UPDATE SET
+		   guesses=excluded.guesses, last_seen=excluded.last_seen, active_ms=excluded.active_ms,
+		   finished=excluded.finished, won=excluded.won, practice_code=excluded.practice_code
+		 WHERE json_array_length(excluded.guesses) >= json_array_length(daily_sessions.guesses)`,
+		sess.UserID, sess.Date, sess.GameID, sess.WordIndex, max(sess.SaltVersion, 1), string(board),
+		sess.Start.UTC().Format(time.RFC3339Nano), sess.LastSeen.UTC().Format(time.RFC3339Nano),
+		sess.ActiveMs, sess.Finished, sess.Won, sess.Difficulty, sess.Fingerprint, sess.PracticeCode,
+	)
+	return err
+}
+
+/**
+ * LoadSession loads a user's session for the date.
+ *
+ * @return the session and true, or false if there is none.
+ */
+func (s *Store) LoadSession(ctx context.Context, userID, date string) (Session, bool, error) {
+	sess := Session{UserID: userID, Date: date}
+	var board, start, seen string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT game_id, word_index, salt_version, guesses, started_at, last_seen, active_ms,
+		        finished, won, difficulty, fingerprint, practice_code
+		   FROM daily_sessions WHERE user_id=? AND date=?`, userID, date,
+	).Scan(&sess.GameID, &sess.WordIndex, &sess.SaltVersion, &board, &start, &seen, &sess.ActiveMs,
+		&sess.Finished, &sess.Won, &sess.Difficulty, &sess.Fingerprint, &sess.PracticeCode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Session{}, false, nil
+	}
+	if err != nil {
+		return Session{}, false, err
+	}
+	if err := json.Unmarshal([]byte(board), &sess.Words); err != nil {
+		return Session{}, false, err
+	}
+	sess.Start, _ = time.Parse(time.RFC3339Nano, start)
+	sess.LastSeen, _ = time.Parse(time.RFC3339Nano, seen)
+	return sess, true, nil
+}
+
+/**
+ * DeleteSessionsBefore removes sessions for dates strictly before cutoff
+ * ("YYYY-MM-DD") and returns how many were removed.
+ */
+func (s *Store) DeleteSessionsBefore(ctx context.Context, cutoff string) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM daily_sessions WHERE date < ?`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
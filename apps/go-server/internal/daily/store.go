@@ -10,13 +10,28 @@
 //   - guesses INT
 //   - elapsed_ms INT
 //   - created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+//   - board TEXT (JSON array of guessed words; NULL for older rows)
+//   - difficulty TEXT ('easy' | 'normal' | 'hard')
+//   - won INT (1 = solved, 0 = out of guesses)
+//   - salt_version INT (salt version the day's index came from)
 //   - UNIQUE(user_id, date)
+//
+// Table expected: daily_words
+//   - date TEXT PRIMARY KEY, word_index INT, salt_version INT
+//   - par INT NULL (solver par; NULL until computed)
+//
+// Table expected: daily_streak_freezes (dates bridged by a spent freeze)
+//   - user_id TEXT, date TEXT, PRIMARY KEY(user_id, date)
+//   - users.streak_freezes holds the unspent balance
 
 package daily
 
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
 )
 
 /**
@@ -24,20 +39,33 @@ import (
  * Stored in daily_results table (one row per user per date).
  */
 type Result struct {
-	UserID    string `json:"userId"`   // User identifier
-	Date      string `json:"date"`     // "YYYY-MM-DD"
-	WordIndex int    `json:"wordIndex"`// Index of day's answer word
-	Guesses   int    `json:"guesses"`  // Number of guesses taken
-	ElapsedMs int    `json:"elapsedMs"`// Duration from start to win in ms
+	UserID      string   `json:"userId"`     // User identifier
+	Date        string   `json:"date"`       // "YYYY-MM-DD"
+	WordIndex   int      `json:"wordIndex"`  // Index of day's answer word
+	Guesses     int      `json:"guesses"`    // Number of guesses taken
+	ElapsedMs   int      `json:"elapsedMs"`  // Duration from start to win in ms
+	Board       []string `json:"-"`          // Guessed words in order (never sent to clients)
+	Difficulty  string   `json:"difficulty"` // Difficulty the day was played at
+	Won         bool     `json:"won"`        // False for an out-of-guesses loss
+	SaltVersion int      `json:"-"`          // Salt version that produced WordIndex
+	Fingerprint string   `json:"-"`          // Guest device fingerprint ("" = not recorded)
 }
 
 /**
  * Store wraps a sql.DB and provides methods for daily challenge persistence.
  */
-type Store struct{ db *sql.DB }
+type Store struct {
+	db  *sql.DB
+	loc *time.Location   // daily rollover zone (nil = UTC)
+	now func() time.Time // clock (overridable for tests)
+}
 
-/** NewStore constructs a daily challenge store bound to the given DB. */
-func NewStore(db *sql.DB) *Store { return &Store{db: db} }
+/**
+ * NewStore constructs a daily challenge store bound to the given DB.
+ *
+ * @param loc  Zone "today" is taken in (see DateKey); nil = UTC.
+ */
+func NewStore(db *sql.DB, loc *time.Location) *Store { return &Store{db: db, loc: loc, now: time.Now} }
 
 /**
  * AlreadyPlayed checks if a user has already played the daily challenge
@@ -61,19 +89,73 @@ func (s *Store) AlreadyPlayed(ctx context.Context, userID, date string) (bool, e
  * - If the user already has a row for the given date, this is a no-op.
  */
 func (s *Store) InsertResult(ctx context.Context, r Result) error {
-	_, err := s.db.ExecContext(ctx,
-		`INSERT OR IGNORE INTO daily_results(user_id, date, word_index, guesses, elapsed_ms)
-		 VALUES(?,?,?,?,?)`,
-		r.UserID, r.Date, r.WordIndex, r.Guesses, r.ElapsedMs,
+	board, err := json.Marshal(r.Board)
+	if err != nil {
+		return err
+	}
+	if r.Difficulty == "" {
+		r.Difficulty = DifficultyNormal
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO daily_results(user_id, date, word_index, guesses, elapsed_ms, board, difficulty, won, salt_version, fingerprint)
+		 VALUES(?,?,?,?,?,?,?,?,?,NULLIF(?, ''))`,
+		r.UserID, r.Date, r.WordIndex, r.Guesses, r.ElapsedMs, string(board), r.Difficulty, r.Won, max(r.SaltVersion, 1), r.Fingerprint,
 	)
 	return err
 }
 
+/**
+ * FingerprintPlayed reports whether another player already recorded a result
+ * for the date from the same device fingerprint.
+ *
+ * @param userID  The caller, excluded so a player never matches themselves.
+ */
+func (s *Store) FingerprintPlayed(ctx context.Context, date, fingerprint, userID string) (bool, error) {
+	var cnt int
+	err := s.db.QueryRowContext(ctx,
+		"SELECT COUNT(1) FROM daily_results WHERE date=? AND fingerprint=? AND user_id<>?",
+		date, fingerprint, userID,
+	).Scan(&cnt)
+	return cnt > 0, err
+}
+
+/**
+ * GetResult loads a user's stored result for the given date.
+ *
+ * - Returns sql.ErrNoRows if the user has no result for that date.
+ * - Board is empty for rows recorded before boards were persisted.
+ */
+func (s *Store) GetResult(ctx context.Context, userID, date string) (*Result, error) {
+	var r Result
+	var board sql.NullString
+	err := s.db.QueryRowContext(ctx,
+		`SELECT user_id, date, word_index, guesses, elapsed_ms, board, difficulty, won
+		   FROM daily_results
+		  WHERE user_id=? AND date=?`, userID, date,
+	).Scan(&r.UserID, &r.Date, &r.WordIndex, &r.Guesses, &r.ElapsedMs, &board, &r.Difficulty, &r.Won)
+	if err != nil {
+		return nil, err
+	}
+	if board.Valid && board.String != "" {
+		if err := json.Unmarshal([]byte(board.String), &r.Board); err != nil {
+			return nil, err
+		}
+	}
+	return &r, nil
+}
+
+/**
+ * GuestLabel is the display name for leaderboard entries without a users row
+ * (guests, or accounts deleted since they played).
+ */
+const GuestLabel = "Guest"
+
 /**
  * LBRow represents a leaderboard entry for a given day.
  */
 type LBRow struct {
 	UserID    string `json:"userId"`
+	Username  string `json:"username"` // GuestLabel when there is no matching user
 	Guesses   int    `json:"guesses"`
 	ElapsedMs int    `json:"elapsedMs"`
 }
@@ -81,16 +163,21 @@ type LBRow struct {
 /**
  * Leaderboard returns the top players for a given date.
  *
+ * - Only wins are ranked; losses count towards Participation instead.
  * - Sorted by elapsed_ms ASC, then guesses ASC, then created_at ASC.
+ * - difficulty != "" restricts the board to results played at that level.
+ * - Usernames come from a LEFT JOIN on users, so guests and deleted
+ *   accounts still appear (as GuestLabel).
  * - Limit is enforced by the query.
  */
-func (s *Store) Leaderboard(ctx context.Context, date string, limit int) ([]LBRow, error) {
+func (s *Store) Leaderboard(ctx context.Context, date, difficulty string, limit int) ([]LBRow, error) {
 	rows, err := s.db.QueryContext(ctx,
-		`SELECT user_id, guesses, elapsed_ms
-		   FROM daily_results
-		  WHERE date=?
-		  ORDER BY elapsed_ms ASC, guesses ASC, created_at ASC
-		  LIMIT ?`, date, limit,
+		`SELECT r.user_id, COALESCE(u.username, ?), r.guesses, r.elapsed_ms
+		   FROM daily_results r
+		   LEFT JOIN users u ON u.id = r.user_id
+		  WHERE r.date=? AND r.won=1 AND (?='' OR r.difficulty=?)
+		  ORDER BY r.elapsed_ms ASC, r.guesses ASC, r.created_at ASC
+		  LIMIT ?`, GuestLabel, date, difficulty, difficulty, limit,
 	)
 	if err != nil {
 		return nil, err
@@ -100,10 +187,402 @@ func (s *Store) Leaderboard(ctx context.Context, date string, limit int) ([]LBRo
 	var out []LBRow
 	for rows.Next() {
 		var r LBRow
-		if err := rows.Scan(&r.UserID, &r.Guesses, &r.ElapsedMs); err != nil {
+		if err := rows.Scan(&r.UserID, &r.Username, &r.Guesses, &r.ElapsedMs); err != nil {
 			return nil, err
 		}
 		out = append(out, r)
 	}
 	return out, rows.Err()
 }
+
+/**
+ * RankFor returns the user's 1-based leaderboard position for a date,
+ * using the same ordering as Leaderboard (across all difficulties).
+ *
+ * - Returns sql.ErrNoRows if the user has no winning result for that date.
+ */
+func (s *Store) RankFor(ctx context.Context, userID, date string) (int, error) {
+	var rank int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT 1 + (SELECT COUNT(*)
+		               FROM daily_results o
+		              WHERE o.date = me.date AND o.won = 1
+		                AND (o.elapsed_ms < me.elapsed_ms
+		                  OR (o.elapsed_ms = me.elapsed_ms AND o.guesses < me.guesses)
+		                  OR (o.elapsed_ms = me.elapsed_ms AND o.guesses = me.guesses AND o.created_at < me.created_at)))
+		   FROM daily_results me
+		  WHERE me.user_id=? AND me.date=? AND me.won=1`, userID, date,
+	).Scan(&rank)
+	return rank, err
+}
+
+/**
+ * Participation counts every recorded attempt for a date, wins and losses.
+ */
+func (s *Store) Participation(ctx context.Context, date string) (attempts, wins int, err error) {
+	err = s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*), COALESCE(SUM(won), 0) FROM daily_results WHERE date=?`, date,
+	).Scan(&attempts, &wins)
+	return attempts, wins, err
+}
+
+/**
+ * GuessDistribution counts wins for a date by number of guesses (guesses → players).
+ */
+func (s *Store) GuessDistribution(ctx context.Context, date string) (map[int]int, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT guesses, COUNT(*) FROM daily_results WHERE date=? AND won=1 GROUP BY guesses`, date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := map[int]int{}
+	for rows.Next() {
+		var guesses, n int
+		if err := rows.Scan(&guesses, &n); err != nil {
+			return nil, err
+		}
+		out[guesses] = n
+	}
+	return out, rows.Err()
+}
+
+/**
+ * PinnedWordIndex returns the index and salt version pinned for a date by
+ * PinWordIndex.
+ *
+ * - Returns sql.ErrNoRows if the date was never served.
+ */
+func (s *Store) PinnedWordIndex(ctx context.Context, date string) (idx, version int, err error) {
+	err = s.db.QueryRowContext(ctx,
+		`SELECT word_index, salt_version FROM daily_words WHERE date=?`, date,
+	).Scan(&idx, &version)
+	return idx, version, err
+}
+
+/**
+ * PinnedBetween returns the pinned indices for dates in [from, to), keyed by date.
+ *
+ * - Dates that were never served are absent.
+ */
+func (s *Store) PinnedBetween(ctx context.Context, from, to string) (map[string]int, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT date, word_index FROM daily_words WHERE date>=? AND date<?`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := map[string]int{}
+	for rows.Next() {
+		var date string
+		var idx int
+		if err := rows.Scan(&date, &idx); err != nil {
+			return nil, err
+		}
+		out[date] = idx
+	}
+	return out, rows.Err()
+}
+
+/**
+ * SetPar records a date's solver par.
+ *
+ * - Only fills a missing par; the first value stored for a date is kept.
+ * - A date that was never pinned is left alone.
+ */
+func (s *Store) SetPar(ctx context.Context, date string, par int) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE daily_words SET par=? WHERE date=? AND par IS NULL`, par, date)
+	return err
+}
+
+/**
+ * Par returns the solver par stored for a date.
+ *
+ * - ok is false when the date was never pinned or its par was never computed.
+ */
+func (s *Store) Par(ctx context.Context, date string) (par int, ok bool, err error) {
+	var v sql.NullInt64
+	err = s.db.QueryRowContext(ctx, `SELECT par FROM daily_words WHERE date=?`, date).Scan(&v)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil || !v.Valid {
+		return 0, false, err
+	}
+	return int(v.Int64), true, nil
+}
+
+/**
+ * PlayedDates lists the dates (ascending) on or after `since` for which the user has a result.
+ */
+func (s *Store) PlayedDates(ctx context.Context, userID, since string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT date FROM daily_results WHERE user_id=? AND date>=? ORDER BY date ASC`, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var d string
+		if err := rows.Scan(&d); err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+/**
+ * Results returns the user's daily results, newest first.
+ *
+ * - from/to ("YYYY-MM-DD", inclusive) bound the range; "" leaves that side open.
+ * - Boards are not loaded.
+ */
+func (s *Store) Results(ctx context.Context, userID, from, to string) ([]Result, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT user_id, date, word_index, guesses, elapsed_ms, difficulty, won
+		   FROM daily_results
+		  WHERE user_id=? AND (?='' OR date>=?) AND (?='' OR date<=?)
+		  ORDER BY date DESC`, userID, from, from, to, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Result
+	for rows.Next() {
+		var r Result
+		if err := rows.Scan(&r.UserID, &r.Date, &r.WordIndex, &r.Guesses, &r.ElapsedMs, &r.Difficulty, &r.Won); err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+/**
+ * PinWordIndex fixes the word index for a date.
+ *
+ * - The first pin for a date wins; later calls (e.g. after a salt rotation)
+ *   get the existing index and version back unchanged.
+ * - Returns the pinned index and salt version.
+ */
+func (s *Store) PinWordIndex(ctx context.Context, date string, idx, version int) (int, int, error) {
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO daily_words(date, word_index, salt_version) VALUES(?,?,?)`,
+		date, idx, version,
+	); err != nil {
+		return 0, 0, err
+	}
+	err := s.db.QueryRowContext(ctx,
+		`SELECT word_index, salt_version FROM daily_words WHERE date=?`, date,
+	).Scan(&idx, &version)
+	return idx, version, err
+}
+
+/**
+ * ArchiveBefore removes results for dates strictly before cutoff ("YYYY-MM-DD").
+ *
+ * - archive=true copies rows into daily_results_archive before deleting them.
+ * - archive=false deletes them outright.
+ * - Runs in a single transaction; returns the number of rows removed.
+ */
+func (s *Store) ArchiveBefore(ctx context.Context, cutoff string, archive bool) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if archive {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO daily_results_archive(id, user_id, date, word_index, guesses, elapsed_ms, created_at, board, difficulty, won, salt_version)
+			 SELECT id, user_id, date, word_index, guesses, elapsed_ms, created_at, board, difficulty, won, salt_version
+			   FROM daily_results
+			  WHERE date < ?`, cutoff,
+		); err != nil {
+			return 0, err
+		}
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM daily_results WHERE date < ?`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	n, _ := res.RowsAffected()
+	return n, tx.Commit()
+}
+
+/**
+ * DifficultyFor returns the user's preferred daily difficulty.
+ *
+ * - Guests (no users row) and unknown values default to "normal".
+ */
+func (s *Store) DifficultyFor(ctx context.Context, userID string) (string, error) {
+	var d string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT daily_difficulty FROM users WHERE id=?`, userID,
+	).Scan(&d)
+	if err == sql.ErrNoRows {
+		return DifficultyNormal, nil
+	}
+	if err != nil {
+		return DifficultyNormal, err
+	}
+	if !ValidDifficulty(d) {
+		return DifficultyNormal, nil
+	}
+	return d, nil
+}
+
+/**
+ * SetDifficulty stores the user's preferred daily difficulty.
+ */
+func (s *Store) SetDifficulty(ctx context.Context, userID, difficulty string) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE users SET daily_difficulty=? WHERE id=?`, difficulty, userID,
+	)
+	return err
+}
+
+/**
+ * StreakHistory returns every date the user played, mapped to whether they won.
+ */
+func (s *Store) StreakHistory(ctx context.Context, userID string) (map[string]bool, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT date, won FROM daily_results WHERE user_id=?`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := map[string]bool{}
+	for rows.Next() {
+		var date string
+		var won bool
+		if err := rows.Scan(&date, &won); err != nil {
+			return nil, err
+		}
+		out[date] = won
+	}
+	return out, rows.Err()
+}
+
+/**
+ * CurrentStreak counts the consecutive days, ending today, on which the
+ * user played the daily (won or lost).
+ *
+ * - If today isn't played yet, the count ends at yesterday instead.
+ * - The first missing date ends the streak; freezes are not considered.
+ */
+func (s *Store) CurrentStreak(ctx context.Context, userID string) (int, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT date FROM daily_results WHERE user_id=? ORDER BY date DESC`, userID)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	day, _ := time.Parse("2006-01-02", DateKey(s.now(), s.loc))
+	n := 0
+	for rows.Next() {
+		var date string
+		if err := rows.Scan(&date); err != nil {
+			return 0, err
+		}
+		key := DateKey(day, nil)
+		if date > key {
+			continue // future-dated row (clock skew, imports)
+		}
+		if n == 0 && date != key {
+			day = day.AddDate(0, 0, -1) // today is still open
+			key = DateKey(day, nil)
+		}
+		if date != key {
+			break
+		}
+		n++
+		day = day.AddDate(0, 0, -1)
+	}
+	return n, rows.Err()
+}
+
+/**
+ * Freezes returns the user's unspent freeze balance and the dates already bridged.
+ *
+ * - Guests (no users row) have a zero balance.
+ */
+func (s *Store) Freezes(ctx context.Context, userID string) (int, map[string]bool, error) {
+	var n int
+	err := s.db.QueryRowContext(ctx, `SELECT streak_freezes FROM users WHERE id=?`, userID).Scan(&n)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, nil, err
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT date FROM daily_streak_freezes WHERE user_id=?`, userID)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+
+	frozen := map[string]bool{}
+	for rows.Next() {
+		var d string
+		if err := rows.Scan(&d); err != nil {
+			return 0, nil, err
+		}
+		frozen[d] = true
+	}
+	return n, frozen, rows.Err()
+}
+
+/**
+ * SpendFreezes records dates bridged by freezes and deducts them from the balance.
+ *
+ * - Runs in one transaction; dates already recorded are not charged twice.
+ * - Fails (changing nothing) if the balance would go negative.
+ */
+func (s *Store) SpendFreezes(ctx context.Context, userID string, dates []string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	spent := int64(0)
+	for _, d := range dates {
+		res, err := tx.ExecContext(ctx,
+			`INSERT OR IGNORE INTO daily_streak_freezes(user_id, date) VALUES(?,?)`, userID, d)
+		if err != nil {
+			return err
+		}
+		n, _ := res.RowsAffected()
+		spent += n
+	}
+	res, err := tx.ExecContext(ctx,
+		`UPDATE users SET streak_freezes = streak_freezes - ? WHERE id=? AND streak_freezes >= ?`,
+		spent, userID, spent)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n != 1 {
+		return sql.ErrNoRows
+	}
+	return tx.Commit()
+}
+
+/**
+ * AddFreeze grants one freeze, up to max held at once.
+ *
+ * @return true if the balance grew.
+ */
+func (s *Store) AddFreeze(ctx context.Context, userID string, max int) (bool, error) {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE users SET streak_freezes = streak_freezes + 1 WHERE id=? AND streak_freezes < ?`, userID, max)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n == 1, nil
+}
@@ -0,0 +1,186 @@
+package daily
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openTestDB opens a SQLite database in a temp dir with every ../../sql
+// migration applied in order.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db")+"?_busy_timeout=5000&_foreign_keys=on")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	files, err := filepath.Glob(filepath.Join("..", "..", "sql", "*.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no migrations found: %v", err)
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(string(b)); err != nil {
+			t.Fatalf("apply %s: %v", f, err)
+		}
+	}
+	return db
+}
+
+// newTestStore is a Store on a fresh database, rolling over in UTC.
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	return NewStore(openTestDB(t), time.UTC)
+}
+
+func TestLossesCountButDontRank(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	const date = "2025-04-01"
+	for _, r := range []Result{
+		{UserID: "winner", Date: date, Guesses: 4, ElapsedMs: 40_000, Won: true},
+		{UserID: "loser", Date: date, Guesses: 6, ElapsedMs: 10_000, Won: false},
+		{UserID: "quick", Date: date, Guesses: 3, ElapsedMs: 20_000, Won: true},
+	} {
+		if err := s.InsertResult(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stored, err := s.GetResult(ctx, "loser", date)
+	if err != nil || stored.Won || stored.Guesses != 6 {
+		t.Fatalf("stored loss = %+v, %v", stored, err)
+	}
+
+	lb, err := s.Leaderboard(ctx, date, "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lb) != 2 || lb[0].UserID != "quick" || lb[1].UserID != "winner" {
+		t.Fatalf("leaderboard = %+v, want quick then winner", lb)
+	}
+	if lb[0].Username != GuestLabel {
+		t.Fatalf("username without a users row = %q, want %q", lb[0].Username, GuestLabel)
+	}
+	if _, err := s.RankFor(ctx, "loser", date); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("RankFor(loser): err %v, want sql.ErrNoRows", err)
+	}
+
+	attempts, wins, err := s.Participation(ctx, date)
+	if err != nil || attempts != 3 || wins != 2 {
+		t.Fatalf("Participation = %d attempts, %d wins, %v; want 3 and 2", attempts, wins, err)
+	}
+	dist, err := s.GuessDistribution(ctx, date)
+	if err != nil || len(dist) != 2 || dist[3] != 1 || dist[4] != 1 {
+		t.Fatalf("GuessDistribution = %v, %v; want wins only", dist, err)
+	}
+}
+
+func TestFreezeBalance(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	if _, err := s.db.Exec(`INSERT INTO users (id, username, password_hash, created_at) VALUES ('u1','u1','x','2025-01-01T00:00:00Z')`); err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []bool{true, true, false} {
+		if ok, err := s.AddFreeze(ctx, "u1", 2); err != nil || ok != want {
+			t.Fatalf("AddFreeze #%d = %v, %v; want %v", i+1, ok, err, want)
+		}
+	}
+
+	if err := s.SpendFreezes(ctx, "u1", []string{"2025-04-02"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SpendFreezes(ctx, "u1", []string{"2025-04-02"}); err != nil {
+		t.Fatalf("re-spending a recorded date: %v", err)
+	}
+	n, frozen, err := s.Freezes(ctx, "u1")
+	if err != nil || n != 1 || !frozen["2025-04-02"] || len(frozen) != 1 {
+		t.Fatalf("Freezes = %d, %v, %v; want 1 left and one bridged date", n, frozen, err)
+	}
+	if err := s.SpendFreezes(ctx, "u1", []string{"2025-04-05", "2025-04-07"}); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("overspending: err %v, want sql.ErrNoRows", err)
+	}
+	if n, frozen, _ := s.Freezes(ctx, "u1"); n != 1 || len(frozen) != 1 {
+		t.Fatalf("failed spend changed state: %d, %v", n, frozen)
+	}
+	if n, _, err := s.Freezes(ctx, "guest"); err != nil || n != 0 {
+		t.Fatalf("guest Freezes = %d, %v; want 0", n, err)
+	}
+}
+
+func TestLeaderboardUsernames(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	const date = "2025-04-01"
+	for _, id := range []string{"u-ada", "u-gone"} {
+		if _, err := s.db.Exec(`INSERT INTO users (id, username, password_hash, created_at) VALUES (?,?,'x','2025-01-01T00:00:00Z')`,
+			id, id[2:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, uid := range []string{"u-ada", "u-gone", "anon-cookie"} {
+		if err := s.InsertResult(ctx, Result{UserID: uid, Date: date, Guesses: 3, ElapsedMs: 1000 * (i + 1), Won: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.db.Exec(`DELETE FROM users WHERE id='u-gone'`); err != nil {
+		t.Fatal(err)
+	}
+
+	lb, err := s.Leaderboard(ctx, date, "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"ada", GuestLabel, GuestLabel}
+	if len(lb) != len(want) {
+		t.Fatalf("leaderboard = %+v, want %d rows", lb, len(want))
+	}
+	for i, name := range want {
+		if lb[i].Username != name {
+			t.Fatalf("row %d username = %q, want %q (%+v)", i, lb[i].Username, name, lb)
+		}
+	}
+}
+
+func TestCurrentStreak(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+	s.now = func() time.Time { return time.Date(2025, 3, 2, 15, 0, 0, 0, time.UTC) }
+	for _, r := range []Result{
+		// unbroken across February into March; today (03-02) not played yet
+		{UserID: "steady", Date: "2025-02-27", Guesses: 4, Won: true},
+		{UserID: "steady", Date: "2025-02-28", Guesses: 6, Won: false},
+		{UserID: "steady", Date: "2025-03-01", Guesses: 3, Won: true},
+		// broken: 02-27 is missing, so only today and yesterday count
+		{UserID: "lapsed", Date: "2025-02-25", Guesses: 4, Won: true},
+		{UserID: "lapsed", Date: "2025-02-26", Guesses: 4, Won: true},
+		{UserID: "lapsed", Date: "2025-02-28", Guesses: 4, Won: true},
+		{UserID: "lapsed", Date: "2025-03-01", Guesses: 4, Won: true},
+		{UserID: "lapsed", Date: "2025-03-02", Guesses: 4, Won: true},
+		// last played two days ago
+		{UserID: "gone", Date: "2025-02-28", Guesses: 2, Won: true},
+	} {
+		if err := s.InsertResult(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for user, want := range map[string]int{"steady": 3, "lapsed": 3, "gone": 0, "nobody": 0} {
+		if n, err := s.CurrentStreak(ctx, user); err != nil || n != want {
+			t.Errorf("CurrentStreak(%s) = %d, %v; want %d", user, n, err, want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// apps/go-server/internal/daily/streak.go
+//
+// Daily win streaks with optional "streak freeze" tokens.
+// A streak counts consecutive days with a daily win, ending today (or
+// yesterday, while today is still unplayed). A loss always ends it. A single
+// missed day can be bridged by a freeze: either one already spent on that
+// date, or a new one taken from the user's balance.
+
+package daily
+
+import "time"
+
+/**
+ * Streak computes the current daily win streak.
+ *
+ * - days maps date keys to the outcome (true = won) of each day played.
+ * - frozen holds dates already bridged by a spent freeze; they keep the
+ *   streak alive but don't add to it.
+ * - freezes is the unspent balance; a missed day is only bridged when the day
+ *   before it was won or frozen (one gap at a time).
+ *
+ * @param today  Date key the streak is measured at.
+ * @return streak length, and the dates that newly consumed a freeze (newest first).
+ */
+func Streak(days map[string]bool, frozen map[string]bool, today string, freezes int) (int, []string) {
+	d, err := time.Parse("2006-01-02", today)
+	if err != nil {
+		return 0, nil
+	}
+	key := DateKey(d, nil)
+	if _, played := days[key]; !played && !frozen[key] {
+		d = d.AddDate(0, 0, -1) // today is still open
+	}
+
+	streak := 0
+	var spend []string
+	for {
+		key = DateKey(d, nil)
+		prev := DateKey(d.AddDate(0, 0, -1), nil)
+		won, played := days[key]
+		switch {
+		case played && won:
+			streak++
+		case played:
+			return streak, spend // lost
+		case frozen[key]:
+		case freezes > 0 && (days[prev] || frozen[prev]):
+			spend = append(spend, key)
+			freezes--
+		default:
+			return streak, spend
+		}
+		d = d.AddDate(0, 0, -1)
+	}
+}
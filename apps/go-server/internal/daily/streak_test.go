@@ -0,0 +1,32 @@
+package daily
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStreak(t *testing.T) {
+	const today = "2026-03-10"
+	for _, tc := range []struct {
+		name    string
+		days    map[string]bool
+		frozen  map[string]bool
+		freezes int
+		want    int
+		spend   []string
+	}{
+		{"unbroken", map[string]bool{"2026-03-08": true, "2026-03-09": true, "2026-03-10": true}, nil, 0, 3, nil},
+		{"today still open", map[string]bool{"2026-03-08": true, "2026-03-09": true}, nil, 0, 2, nil},
+		{"missed day breaks", map[string]bool{"2026-03-07": true, "2026-03-08": true, "2026-03-10": true}, nil, 0, 1, nil},
+		{"missed day with a freeze", map[string]bool{"2026-03-07": true, "2026-03-08": true, "2026-03-10": true}, nil, 1, 3, []string{"2026-03-09"}},
+		{"already frozen", map[string]bool{"2026-03-08": true, "2026-03-10": true}, map[string]bool{"2026-03-09": true}, 0, 2, nil},
+		{"two missed days", map[string]bool{"2026-03-07": true, "2026-03-10": true}, nil, 2, 1, nil},
+		{"loss is never bridged", map[string]bool{"2026-03-08": true, "2026-03-09": false, "2026-03-10": true}, nil, 1, 1, nil},
+		{"freezes run out", map[string]bool{"2026-03-04": true, "2026-03-06": true, "2026-03-08": true, "2026-03-10": true}, nil, 2, 3, []string{"2026-03-09", "2026-03-07"}},
+	} {
+		got, spend := Streak(tc.days, tc.frozen, today, tc.freezes)
+		if got != tc.want || !slices.Equal(spend, tc.spend) {
+			t.Errorf("%s: Streak = %d, %v, want %d, %v", tc.name, got, spend, tc.want, tc.spend)
+		}
+	}
+}
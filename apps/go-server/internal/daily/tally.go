@@ -0,0 +1,69 @@
+// apps/go-server/internal/daily/tally.go
+//
+// Running count of today's finished dailies for the live solve-rate banner.
+// The tally covers a single date: recording a result for a newer date (or
+// reading it after midnight) starts a fresh, empty day. Seed it from the
+// database at startup with Reset so a restart doesn't zero the banner.
+
+package daily
+
+import "sync"
+
+/**
+ * Tally counts finished attempts and wins for one date. Safe for concurrent use.
+ */
+type Tally struct {
+	mu       sync.Mutex
+	date     string
+	attempts int
+	wins     int
+}
+
+/**
+ * Reset replaces the tally with counts for date (e.g. from Store.Participation).
+ */
+func (t *Tally) Reset(date string, attempts, wins int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.date, t.attempts, t.wins = date, attempts, wins
+}
+
+/**
+ * Record counts one finished attempt for date.
+ *
+ * - A newer date rolls the tally over before counting.
+ * - Results for an older date are ignored (the day is already over).
+ */
+func (t *Tally) Record(date string, won bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if date < t.date {
+		return
+	}
+	t.rollTo(date)
+	t.attempts++
+	if won {
+		t.wins++
+	}
+}
+
+/**
+ * Snapshot returns the counts for date; zero if the tally holds an older day,
+ * which it then drops.
+ */
+func (t *Tally) Snapshot(date string) (attempts, wins int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if date < t.date {
+		return 0, 0
+	}
+	t.rollTo(date)
+	return t.attempts, t.wins
+}
+
+// rollTo starts an empty day when date is newer than the tally. Caller holds mu.
+func (t *Tally) rollTo(date string) {
+	if date > t.date {
+		t.date, t.attempts, t.wins = date, 0, 0
+	}
+}
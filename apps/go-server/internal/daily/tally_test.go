@@ -0,0 +1,30 @@
+package daily
+
+import "testing"
+
+func TestTally(t *testing.T) {
+	var tl Tally
+	tl.Reset("2026-03-10", 4, 1)
+	tl.Record("2026-03-10", true)
+	tl.Record("2026-03-10", false)
+	if a, w := tl.Snapshot("2026-03-10"); a != 6 || w != 2 {
+		t.Fatalf("Snapshot = %d, %d, want 6, 2", a, w)
+	}
+
+	tl.Record("2026-03-09", true) // the day is over
+	if a, w := tl.Snapshot("2026-03-10"); a != 6 || w != 2 {
+		t.Fatalf("after a late result: %d, %d, want 6, 2", a, w)
+	}
+
+	// Midnight: reading the new date starts it empty and drops the old one.
+	if a, w := tl.Snapshot("2026-03-11"); a != 0 || w != 0 {
+		t.Fatalf("new day Snapshot = %d, %d, want 0, 0", a, w)
+	}
+	if a, _ := tl.Snapshot("2026-03-10"); a != 0 {
+		t.Fatalf("old day after rollover = %d, want 0", a)
+	}
+	tl.Record("2026-03-12", true)
+	if a, w := tl.Snapshot("2026-03-12"); a != 1 || w != 1 {
+		t.Fatalf("Record on a newer date = %d, %d, want 1, 1", a, w)
+	}
+}
@@ -0,0 +1,164 @@
+// apps/go-server/internal/dialect/dialect.go
+//
+// SQL dialect selection and query rewriting.
+// Responsibilities:
+//   - Pick the dialect from the DATABASE_URL scheme: postgres:// and
+//     postgresql:// are Postgres, anything else is a SQLite path/DSN.
+//   - Rewrite queries written for SQLite (the form every query in this repo is
+//     written in) into the target dialect:
+//       • ? placeholders → $1, $2, … (Postgres)
+//       • INSERT OR IGNORE INTO … → INSERT INTO … ON CONFLICT DO NOTHING
+//
+// Notes:
+//   - Rewriting is lexical: placeholders and keywords inside '…' strings,
+//     "…" identifiers and -- comments are left alone. It does not translate
+//     functions (datetime('now')), PRAGMAs or schema DDL; Postgres has its
+//     own migrations (sql/postgres).
+//   - OpenDB (driver.go) applies Rewrite to every query on a connection.
+//   - For SQLite every rewrite is the identity.
+
+package dialect
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect identifies a SQL backend.
+type Dialect int
+
+const (
+	SQLite Dialect = iota
+	Postgres
+)
+
+// FromDSN returns the dialect a DATABASE_URL value selects.
+func FromDSN(dsn string) Dialect {
+	lower := strings.ToLower(dsn)
+	if strings.HasPrefix(lower, "postgres://") || strings.HasPrefix(lower, "postgresql://") {
+		return Postgres
+	}
+	return SQLite
+}
+
+// String names the dialect (for logs and errors).
+func (d Dialect) String() string {
+	if d == Postgres {
+		return "postgres"
+	}
+	return "sqlite"
+}
+
+// Rewrite translates a SQLite-style query into d: the upsert form first, then
+// placeholders.
+func (d Dialect) Rewrite(query string) string {
+	return d.Rebind(d.insertIgnore(query))
+}
+
+// Rebind replaces ? placeholders with $n for Postgres.
+func (d Dialect) Rebind(query string) string {
+	if d != Postgres || !strings.Contains(query, "?") {
+		return query
+	}
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	scan(query, func(i int, code bool) {
+		if code && query[i] == '?' {
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			return
+		}
+		b.WriteByte(query[i])
+	})
+	return b.String()
+}
+
+// insertIgnore turns INSERT OR IGNORE into Postgres' ON CONFLICT DO NOTHING.
+// Queries that already carry an ON CONFLICT clause are left as they are.
+func (d Dialect) insertIgnore(query string) string {
+	if d != Postgres {
+		return query
+	}
+	i, j := keywordAt(query, "INSERT OR IGNORE")
+	if i < 0 {
+		return query
+	}
+	out := query[:i] + "INSERT" + query[j:]
+	if k, _ := keywordAt(out, "ON CONFLICT"); k >= 0 {
+		return out
+	}
+	out = strings.TrimRight(out, " \t\r\n;")
+	return out + " ON CONFLICT DO NOTHING"
+}
+
+// keywordAt returns the bounds [start, end) of the first case-insensitive
+// match of kw (words separated by any whitespace run) outside strings and
+// comments, or -1, -1.
+func keywordAt(query, kw string) (start, end int) {
+	words := strings.Fields(strings.ToUpper(kw))
+	found, foundEnd := -1, -1
+	scan(query, func(i int, code bool) {
+		if found >= 0 || !code || (i > 0 && isWordByte(query[i-1])) {
+			return
+		}
+		j := i
+		for k, w := range words {
+			if k > 0 {
+				from := j
+				for j < len(query) && isSpace(query[j]) {
+					j++
+				}
+				if j == from {
+					return
+				}
+			}
+			if len(query)-j < len(w) || strings.ToUpper(query[j:j+len(w)]) != w {
+				return
+			}
+			j += len(w)
+		}
+		if j < len(query) && isWordByte(query[j]) {
+			return
+		}
+		found, foundEnd = i, j
+	})
+	return found, foundEnd
+}
+
+// scan calls fn for every byte of query, reporting whether it is SQL code
+// (true) or part of a quoted string, quoted identifier or -- comment.
+func scan(query string, fn func(i int, code bool)) {
+	var quote byte // ' or " while inside a quoted run
+	comment := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case comment:
+			fn(i, false)
+			if c == '\n' {
+				comment = false
+			}
+		case quote != 0:
+			fn(i, false)
+			if c == quote {
+				quote = 0 // a doubled quote just reopens on the next byte
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			fn(i, false)
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			comment = true
+			fn(i, false)
+		default:
+			fn(i, true)
+		}
+	}
+}
+
+func isSpace(c byte) bool { return c == ' ' || c == '\t' || c == '\n' || c == '\r' }
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
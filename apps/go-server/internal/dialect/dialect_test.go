@@ -0,0 +1,83 @@
+package dialect
+
+import "testing"
+
+func TestFromDSN(t *testing.T) {
+	for dsn, want := range map[string]Dialect{
+		"./data/app.db":                   SQLite,
+		"file:test.db?cache=shared":       SQLite,
+		"postgres://u:p@localhost/db":     Postgres,
+		"POSTGRESQL://u@localhost/db":     Postgres,
+		"postgresish.db":                  SQLite,
+		"/var/lib/postgres://nonsense.db": SQLite,
+	} {
+		if got := FromDSN(dsn); got != want {
+			t.Errorf("FromDSN(%q) = %v, want %v", dsn, got, want)
+		}
+	}
+}
+
+func TestRebind(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{`SELECT 1`, `SELECT 1`},
+		{`SELECT * FROM t WHERE a=? AND b=?`, `SELECT * FROM t WHERE a=$1 AND b=$2`},
+		{`INSERT INTO t VALUES (?,?,?)`, `INSERT INTO t VALUES ($1,$2,$3)`},
+		{`SELECT '?' FROM t WHERE a=?`, `SELECT '?' FROM t WHERE a=$1`},
+		{`SELECT 'it''s ?' , ? FROM t`, `SELECT 'it''s ?' , $1 FROM t`},
+		{`SELECT "a?b" FROM t WHERE c=?`, `SELECT "a?b" FROM t WHERE c=$1`},
+		{"SELECT a -- why?\nFROM t WHERE b=?", "SELECT a -- why?\nFROM t WHERE b=$1"},
+	}
+	for _, c := range cases {
+		if got := Postgres.Rebind(c.in); got != c.want {
+			t.Errorf("Rebind(%q) = %q, want %q", c.in, got, c.want)
+		}
+		if got := SQLite.Rebind(c.in); got != c.in {
+			t.Errorf("SQLite.Rebind(%q) = %q, want it unchanged", c.in, got)
+		}
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{
+			`INSERT OR IGNORE INTO daily_words (date, word_index) VALUES (?, ?)`,
+			`INSERT INTO daily_words (date, word_index) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		},
+		{
+			"insert  or\n\tignore into t (a) values (?);\n",
+			"INSERT into t (a) values ($1) ON CONFLICT DO NOTHING",
+		},
+		{
+			`INSERT OR IGNORE INTO t (a) VALUES (?) ON CONFLICT (a) DO NOTHING`,
+			`INSERT INTO t (a) VALUES ($1) ON CONFLICT (a) DO NOTHING`,
+		},
+		{
+			`INSERT INTO t (a) SELECT a FROM u WHERE b=?`,
+			`INSERT INTO t (a) SELECT a FROM u WHERE b=$1`,
+		},
+		{
+			`INSERT INTO t (note) VALUES ('INSERT OR IGNORE')`,
+			`INSERT INTO t (note) VALUES ('INSERT OR IGNORE')`,
+		},
+		{
+			`INSERT INTO t (a) VALUES (?) ON CONFLICT(a) DO UPDATE SET a=excluded.a`,
+			`INSERT INTO t (a) VALUES ($1) ON CONFLICT(a) DO UPDATE SET a=excluded.a`,
+		},
+	}
+	for _, c := range cases {
+		if got := Postgres.Rewrite(c.in); got != c.want {
+			t.Errorf("Rewrite(%q) =\n  %q\nwant\n  %q", c.in, got, c.want)
+		}
+		if got := SQLite.Rewrite(c.in); got != c.in {
+			t.Errorf("SQLite.Rewrite(%q) = %q, want it unchanged", c.in, got)
+		}
+	}
+}
+
+func TestRewriteIdempotent(t *testing.T) {
+	q := `INSERT OR IGNORE INTO t (a, b) VALUES (?, ?)`
+	once := Postgres.Rewrite(q)
+	if twice := Postgres.Rewrite(once); twice != once {
+		t.Fatalf("Rewrite not idempotent: %q then %q", once, twice)
+	}
+}
@@ -0,0 +1,117 @@
+// apps/go-server/internal/dialect/driver.go
+//
+// database/sql driver wrapper that rewrites every query for its dialect.
+// Responsibilities:
+//   - Wrap a driver.Connector so each connection passes its queries
+//     (Exec, Query, Prepare, in or out of a transaction) through Rewrite
+//     before the real driver sees them. Callers keep a plain *sql.DB and
+//     write SQLite-style SQL everywhere.
+//   - Pass Go bools as 0/1 on Postgres, since the schema keeps SQLite's
+//     INTEGER flag columns (won, finished, verified, …).
+//
+// Notes:
+//   - Optional driver interfaces (contexts, Ping, ResetSession, IsValid,
+//     CheckNamedValue) are forwarded when the wrapped connection has them.
+
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+)
+
+// OpenDB returns a *sql.DB on c whose queries are all rewritten for d.
+func OpenDB(d Dialect, c driver.Connector) *sql.DB {
+	return sql.OpenDB(connector{c: c, d: d})
+}
+
+// connector wraps each connection c opens.
+type connector struct {
+	c driver.Connector
+	d Dialect
+}
+
+func (c connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.c.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &rewriteConn{Conn: conn, d: c.d}, nil
+}
+
+func (c connector) Driver() driver.Driver { return c.c.Driver() }
+
+// rewriteConn rewrites queries before handing them to the wrapped Conn.
+type rewriteConn struct {
+	driver.Conn
+	d Dialect
+}
+
+func (c *rewriteConn) Prepare(query string) (driver.Stmt, error) {
+	return c.Conn.Prepare(c.d.Rewrite(query))
+}
+
+func (c *rewriteConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if pc, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return pc.PrepareContext(ctx, c.d.Rewrite(query))
+	}
+	return c.Prepare(query)
+}
+
+func (c *rewriteConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if ec, ok := c.Conn.(driver.ExecerContext); ok {
+		return ec.ExecContext(ctx, c.d.Rewrite(query), args)
+	}
+	return nil, driver.ErrSkip // database/sql falls back to PrepareContext
+}
+
+func (c *rewriteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if qc, ok := c.Conn.(driver.QueryerContext); ok {
+		return qc.QueryContext(ctx, c.d.Rewrite(query), args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *rewriteConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if bc, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return bc.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin() //nolint:staticcheck // fallback for drivers without BeginTx
+}
+
+func (c *rewriteConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *rewriteConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *rewriteConn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+// CheckNamedValue turns bools into 0/1 on Postgres, then defers to the
+// wrapped driver's checker (or database/sql's default conversion).
+func (c *rewriteConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if b, ok := nv.Value.(bool); ok && c.d == Postgres {
+		nv.Value = int64(0)
+		if b {
+			nv.Value = int64(1)
+		}
+	}
+	if nc, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return nc.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
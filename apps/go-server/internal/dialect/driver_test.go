@@ -0,0 +1,133 @@
+package dialect
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+)
+
+// fakeConn records the queries and arguments that reach the driver.
+type fakeConn struct {
+	queries []string
+	args    [][]driver.NamedValue
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	c.queries = append(c.queries, query)
+	return fakeStmt{}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.queries = append(c.queries, query)
+	c.args = append(c.args, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.queries = append(c.queries, query)
+	c.args = append(c.args, args)
+	return fakeRows{}, nil
+}
+
+type (
+	fakeStmt      struct{}
+	fakeTx        struct{}
+	fakeRows      struct{}
+	fakeConnector struct{ conn *fakeConn }
+)
+
+func (fakeStmt) Close() error                               { return nil }
+func (fakeStmt) NumInput() int                              { return -1 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) { return driver.RowsAffected(0), nil }
+func (fakeStmt) Query([]driver.Value) (driver.Rows, error)  { return fakeRows{}, nil }
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+func (fakeRows) Columns() []string         { return nil }
+func (fakeRows) Close() error              { return nil }
+func (fakeRows) Next([]driver.Value) error { return io.EOF }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return c.conn, nil }
+func (fakeConnector) Driver() driver.Driver                          { return nil }
+
+func TestOpenDBRewritesEveryQuery(t *testing.T) {
+	conn := &fakeConn{}
+	db := OpenDB(Postgres, fakeConnector{conn})
+	defer db.Close()
+	ctx := context.Background()
+
+	if _, err := db.ExecContext(ctx, `INSERT OR IGNORE INTO t (a, won) VALUES (?, ?)`, "x", true); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := db.QueryContext(ctx, `SELECT a FROM t WHERE b=? AND c=?`, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows.Close()
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Exec(`DELETE FROM t WHERE a=?`, "x"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Prepare(`SELECT a FROM t WHERE b=?`); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`INSERT INTO t (a, won) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		`SELECT a FROM t WHERE b=$1 AND c=$2`,
+		`DELETE FROM t WHERE a=$1`,
+		`SELECT a FROM t WHERE b=$1`,
+	}
+	if len(conn.queries) != len(want) {
+		t.Fatalf("queries = %q, want %q", conn.queries, want)
+	}
+	for i := range want {
+		if conn.queries[i] != want[i] {
+			t.Errorf("query %d = %q, want %q", i, conn.queries[i], want[i])
+		}
+	}
+
+	// Bools reach Postgres as the 0/1 the INTEGER flag columns hold.
+	if got := conn.args[0][1].Value; got != int64(1) {
+		t.Errorf("true passed as %#v, want int64(1)", got)
+	}
+	if got := conn.args[1][1].Value; got != int64(0) {
+		t.Errorf("false passed as %#v, want int64(0)", got)
+	}
+}
+
+func TestOpenDBSQLiteLeavesQueriesAlone(t *testing.T) {
+	conn := &fakeConn{}
+	db := OpenDB(SQLite, fakeConnector{conn})
+	defer db.Close()
+
+	q := `INSERT OR IGNORE INTO t (a, won) VALUES (?, ?)`
+	if _, err := db.Exec(q, "x", true); err != nil {
+		t.Fatal(err)
+	}
+	if len(conn.queries) != 1 || conn.queries[0] != q {
+		t.Fatalf("queries = %q, want [%q]", conn.queries, q)
+	}
+	if got := conn.args[0][1].Value; got != true {
+		t.Errorf("true passed as %#v, want it unchanged", got)
+	}
+}
+
+func TestCheckNamedValueDefersToDriver(t *testing.T) {
+	c := &rewriteConn{Conn: &fakeConn{}, d: Postgres}
+	nv := &driver.NamedValue{Ordinal: 1, Value: "x"}
+	if err := c.CheckNamedValue(nv); !errors.Is(err, driver.ErrSkip) {
+		t.Fatalf("CheckNamedValue on a driver without a checker = %v, want driver.ErrSkip", err)
+	}
+}
@@ -0,0 +1,197 @@
+// apps/go-server/internal/featureflags/featureflags.go
+//
+// Runtime feature flags.
+// Responsibilities:
+//   - Define the known boolean flags and the env var each one defaults from.
+//   - Hold a concurrency-safe set of values that operators can override at
+//     runtime (POST /admin/flags) without a restart.
+//
+// Notes:
+//   - Overrides live in process memory; they reset to the env defaults on restart
+//     and are per instance.
+//   - Unknown flag names are rejected so typos don't silently do nothing.
+
+package featureflags
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Flag names a known feature flag.
+type Flag string
+
+const (
+	Maintenance        Flag = "maintenance"          // MAINTENANCE_MODE: gameplay routes return 503
+	DailyEnabled       Flag = "daily_enabled"        // DAILY_ENABLED: /daily/* is served
+	DailyRequireAuth   Flag = "daily_require_auth"   // DAILY_REQUIRE_AUTH: /daily/* is registered-only, even with guests allowed
+	GuestsAllowed      Flag = "guests_allowed"       // ALLOW_GUESTS: guests may play without an account
+	PersistGameResults Flag = "persist_game_results" // PERSIST_GAME_RESULTS: full finish records for classic games
+	HistoryTimestamps  Flag = "history_timestamps"   // HISTORY_TIMESTAMPS: include per-guess times in history
+	WordsMatch         Flag = "words_match"          // WORDS_MATCH_ENABLED: serve /words/match
+	LocalizedErrors    Flag = "localized_errors"     // LOCALIZED_ERRORS: translate user-facing error messages
+	GameDifficulty     Flag = "game_difficulty"      // GAME_DIFFICULTY_SCORES: score finished classic games' answers
+	Webhooks           Flag = "webhooks"             // WEBHOOK_ENABLED: send completion webhooks (needs WEBHOOK_URL)
+	DataExport         Flag = "data_export"          // ACCOUNT_EXPORT_ENABLED: serve GET /auth/me/export
+	ResultTokens       Flag = "result_tokens"        // RESULT_TOKENS_ENABLED: issue signed result tokens, serve GET /game/verify
+	DailyReveal        Flag = "daily_reveal"         // DAILY_REVEAL_ENABLED: finished dailies return index + proof for verification
+	ShortLinks         Flag = "short_links"          // SHORT_LINKS_ENABLED: serve /links and start games from them
+	DailyRecap         Flag = "daily_recap"          // DAILY_RECAP_ENABLED: serve GET /daily/recap
+	AccountVerify      Flag = "account_verification" // REQUIRE_ACCOUNT_VERIFICATION: new accounts must verify before gated routes
+	StreakFreeze       Flag = "streak_freeze"        // DAILY_STREAK_FREEZE_ENABLED: earn and spend daily streak freezes
+	WordsAnalyze       Flag = "words_analyze"        // WORDS_ANALYZE_ENABLED: serve GET /words/analyze (admin token)
+	ChallengeBoards    Flag = "challenge_boards"     // CHALLENGE_LEADERBOARD_ENABLED: submit link games to per-challenge boards
+	DailyPractice      Flag = "daily_practice"       // DAILY_PRACTICE_LINKS_ENABLED: finished dailies return a practice link code
+	DailyLive          Flag = "daily_live"           // DAILY_LIVE_RATE_ENABLED: add the live solve rate to GET /daily/today
+	ServeUI            Flag = "serve_ui"             // SERVE_UI: serve the embedded smoke-test board at /play
+	CustomAllowed      Flag = "custom_allowed"       // CUSTOM_ALLOWED_ENABLED: links and custom games may carry their own guess list
+	GameReview         Flag = "game_review"          // GAME_REVIEW_ENABLED: serve GET /game/{id}/review (per-guess grades)
+	GameRecovery       Flag = "game_recovery"        // GAME_STORE_RECOVER: snapshot new games; reload active ones into the memory store at startup
+	EvaluatedGuess     Flag = "evaluated_guess"      // ECHO_EVALUATED_GUESS: guess responses echo the normalized guess that was scored
+	DailyRevealTime    Flag = "daily_reveal_time"    // DAILY_REVEAL_TIME_ENABLED: serve GET /daily/reveal-time (next rollover)
+	Metrics            Flag = "metrics"              // METRICS_ENABLED: record request metrics, serve GET /metrics (Prometheus)
+	DailyPar           Flag = "daily_par"            // DAILY_PAR_ENABLED: compute each daily's solver par; past recaps show it
+	Bootstrap          Flag = "bootstrap"            // BOOTSTRAP_ENABLED: serve GET /bootstrap (one-call client start-up payload)
+	Hints              Flag = "hints"                // HINTS_ENABLED: serve POST /game/{id}/hint (suggested next guess)
+	FixedAnswer        Flag = "fixed_answer"         // ALLOW_FIXED_ANSWER: POST /game/new honours "answer" (dev/test only)
+	DailyVerify        Flag = "daily_verify"         // DAILY_VERIFY_ENABLED: serve GET /daily/verify (past word indices + salt commitment)
+	DebugWords         Flag = "debug_words"          // DEBUG_WORDS_ENABLED: GET /debug/words?sample=N lists random words (not for production)
+)
+
+// spec describes where a flag's default comes from.
+type spec struct {
+	env string
+	def bool
+}
+
+// known lists every flag with its env var and built-in default.
+var known = map[Flag]spec{
+	Maintenance:        {"MAINTENANCE_MODE", false},
+	DailyEnabled:       {"DAILY_ENABLED", true},
+	DailyRequireAuth:   {"DAILY_REQUIRE_AUTH", false},
+	GuestsAllowed:      {"ALLOW_GUESTS", true},
+	PersistGameResults: {"PERSIST_GAME_RESULTS", false},
+	HistoryTimestamps:  {"HISTORY_TIMESTAMPS", false},
+	WordsMatch:         {"WORDS_MATCH_ENABLED", false},
+	LocalizedErrors:    {"LOCALIZED_ERRORS", false},
+	GameDifficulty:     {"GAME_DIFFICULTY_SCORES", false},
+	Webhooks:           {"WEBHOOK_ENABLED", false},
+	DataExport:         {"ACCOUNT_EXPORT_ENABLED", true},
+	ResultTokens:       {"RESULT_TOKENS_ENABLED", false},
+	DailyReveal:        {"DAILY_REVEAL_ENABLED", false},
+	ShortLinks:         {"SHORT_LINKS_ENABLED", false},
+	DailyRecap:         {"DAILY_RECAP_ENABLED", false},
+	AccountVerify:      {"REQUIRE_ACCOUNT_VERIFICATION", false},
+	StreakFreeze:       {"DAILY_STREAK_FREEZE_ENABLED", false},
+	WordsAnalyze:       {"WORDS_ANALYZE_ENABLED", false},
+	ChallengeBoards:    {"CHALLENGE_LEADERBOARD_ENABLED", false},
+	DailyPractice:      {"DAILY_PRACTICE_LINKS_ENABLED", false},
+	DailyLive:          {"DAILY_LIVE_RATE_ENABLED", false},
+	ServeUI:            {"SERVE_UI", false},
+	CustomAllowed:      {"CUSTOM_ALLOWED_ENABLED", false},
+	GameReview:         {"GAME_REVIEW_ENABLED", false},
+	GameRecovery:       {"GAME_STORE_RECOVER", false},
+	EvaluatedGuess:     {"ECHO_EVALUATED_GUESS", false},
+	DailyRevealTime:    {"DAILY_REVEAL_TIME_ENABLED", false},
+	Metrics:            {"METRICS_ENABLED", false},
+	DailyPar:           {"DAILY_PAR_ENABLED", false},
+	Bootstrap:          {"BOOTSTRAP_ENABLED", false},
+	Hints:              {"HINTS_ENABLED", false},
+	FixedAnswer:        {"ALLOW_FIXED_ANSWER", false},
+	DailyVerify:        {"DAILY_VERIFY_ENABLED", false},
+	DebugWords:         {"DEBUG_WORDS_ENABLED", false},
+}
+
+// ErrUnknownFlag is returned when setting a flag that is not defined above.
+var ErrUnknownFlag = errors.New("unknown flag")
+
+// Flags is a concurrency-safe flag set.
+type Flags struct {
+	mu       sync.RWMutex
+	defaults map[Flag]bool // from env at construction
+	override map[Flag]bool // runtime overrides (admin)
+	version  uint64        // bumped by every Set and Reset
+}
+
+// New builds a flag set with defaults read from the environment.
+// Env values are parsed with strconv.ParseBool; unparsable values keep the built-in default.
+func New() *Flags {
+	f := &Flags{defaults: make(map[Flag]bool, len(known)), override: map[Flag]bool{}}
+	for name, sp := range known {
+		v := sp.def
+		if b, err := strconv.ParseBool(os.Getenv(sp.env)); err == nil {
+			v = b
+		}
+		f.defaults[name] = v
+	}
+	return f
+}
+
+// Enabled reports the current value of flag (override, else env default).
+// Unknown flags are always false.
+func (f *Flags) Enabled(flag Flag) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if v, ok := f.override[flag]; ok {
+		return v
+	}
+	return f.defaults[flag]
+}
+
+// Set overrides flag at runtime.
+func (f *Flags) Set(flag Flag, v bool) error {
+	if _, ok := known[flag]; !ok {
+		return ErrUnknownFlag
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.override[flag] = v
+	f.version++
+	return nil
+}
+
+// Reset drops any runtime override so flag reverts to its env default.
+func (f *Flags) Reset(flag Flag) error {
+	if _, ok := known[flag]; !ok {
+		return ErrUnknownFlag
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.override, flag)
+	f.version++
+	return nil
+}
+
+// Version changes whenever a flag is set or reset at runtime, so callers can
+// tell when values derived from the flags are stale.
+func (f *Flags) Version() uint64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.version
+}
+
+// State is a flag's effective value and whether it is overridden.
+type State struct {
+	Name       Flag `json:"name"`
+	Enabled    bool `json:"enabled"`
+	Overridden bool `json:"overridden"`
+}
+
+// All returns every known flag's state, sorted by name.
+func (f *Flags) All() []State {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	out := make([]State, 0, len(known))
+	for name := range known {
+		v, ok := f.override[name]
+		if !ok {
+			v = f.defaults[name]
+		}
+		out = append(out, State{Name: name, Enabled: v, Overridden: ok})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
@@ -0,0 +1,65 @@
+package featureflags
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDefaultsFromEnv(t *testing.T) {
+	t.Setenv("ALLOW_GUESTS", "false")
+	t.Setenv("MAINTENANCE_MODE", "1")
+	t.Setenv("WORDS_MATCH_ENABLED", "maybe") // unparsable: built-in default
+	t.Setenv("DAILY_ENABLED", "")
+	f := New()
+
+	for flag, want := range map[Flag]bool{
+		GuestsAllowed: false,
+		Maintenance:   true,
+		WordsMatch:    false,
+		DailyEnabled:  true,
+		"no_such":     false,
+	} {
+		if got := f.Enabled(flag); got != want {
+			t.Errorf("Enabled(%s) = %v, want %v", flag, got, want)
+		}
+	}
+}
+
+func TestOverrideAndReset(t *testing.T) {
+	t.Setenv("MAINTENANCE_MODE", "false")
+	f := New()
+	v0 := f.Version()
+
+	if err := f.Set(Maintenance, true); err != nil {
+		t.Fatal(err)
+	}
+	if !f.Enabled(Maintenance) {
+		t.Fatal("override not applied")
+	}
+	var st State
+	for _, s := range f.All() {
+		if s.Name == Maintenance {
+			st = s
+		}
+	}
+	if !st.Enabled || !st.Overridden {
+		t.Fatalf("All() reports %+v, want enabled and overridden", st)
+	}
+	if f.Version() == v0 {
+		t.Fatal("Set did not bump the version")
+	}
+
+	if err := f.Reset(Maintenance); err != nil {
+		t.Fatal(err)
+	}
+	if f.Enabled(Maintenance) {
+		t.Fatal("Reset did not restore the env default")
+	}
+
+	if err := f.Set("no_such", true); !errors.Is(err, ErrUnknownFlag) {
+		t.Fatalf("Set(unknown): err %v, want ErrUnknownFlag", err)
+	}
+	if err := f.Reset("no_such"); !errors.Is(err, ErrUnknownFlag) {
+		t.Fatalf("Reset(unknown): err %v, want ErrUnknownFlag", err)
+	}
+}
@@ -0,0 +1,58 @@
+// apps/go-server/internal/game/allowed.go
+//
+// Per-game allowed-guess overrides.
+// A themed or constrained puzzle (e.g. "only 5-letter animals") can carry its
+// own guess list; ApplyGuess then checks that list instead of the global one
+// from the words package.
+//
+// Notes:
+//   - Game.Allowed is kept lowercase, de-duplicated, and sorted so lookups are
+//     a binary search and stored copies compare equal.
+//   - The blocklist still applies on top of an override.
+
+package game
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// ErrInvalidAllowed is returned by NormalizeAllowed for an unusable list.
+var ErrInvalidAllowed = errors.New("invalid allowed list")
+
+// NormalizeAllowed validates a custom allowed-guess list and returns it
+// lowercased, de-duplicated, and sorted. Every word must be alphabetic and
+// the same length; an empty list is an error.
+func NormalizeAllowed(list []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(list))
+	out := make([]string, 0, len(list))
+	for _, w := range list {
+		w = strings.ToLower(strings.TrimSpace(w))
+		if w == "" || !isAlpha(w) || (len(out) > 0 && len(w) != len(out[0])) {
+			return nil, ErrInvalidAllowed
+		}
+		if _, dup := seen[w]; dup {
+			continue
+		}
+		seen[w] = struct{}{}
+		out = append(out, w)
+	}
+	if len(out) == 0 {
+		return nil, ErrInvalidAllowed
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// allows reports whether guess is a legal guess in g: a member of g.Allowed
+// when the game has an override, otherwise of the global list for g.Cols.
+func (g *Game) allows(guess string) bool {
+	if len(g.Allowed) == 0 {
+		return words.IsAllowedLen(guess, g.Cols)
+	}
+	i := sort.SearchStrings(g.Allowed, guess)
+	return i < len(g.Allowed) && g.Allowed[i] == guess
+}
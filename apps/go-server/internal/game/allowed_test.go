@@ -0,0 +1,43 @@
+package game
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestNormalizeAllowed(t *testing.T) {
+	got, err := NormalizeAllowed([]string{" Zebra", "horse", "ZEBRA", "camel"})
+	if err != nil || !slices.Equal(got, []string{"camel", "horse", "zebra"}) {
+		t.Fatalf("NormalizeAllowed = %v, %v", got, err)
+	}
+	for _, bad := range [][]string{nil, {""}, {"horse", "cat"}, {"h0rse"}} {
+		if _, err := NormalizeAllowed(bad); !errors.Is(err, ErrInvalidAllowed) {
+			t.Errorf("NormalizeAllowed(%q): err %v, want ErrInvalidAllowed", bad, err)
+		}
+	}
+}
+
+func TestCustomAllowedOverridesGlobalList(t *testing.T) {
+	useWords(t, []string{"zebra", "crane"}, []string{"zebra", "crane", "slate", "horse"})
+
+	g := New("zebra", 0)
+	g.Allowed, _ = NormalizeAllowed([]string{"zebra", "horse", "camel"})
+	if _, _, err := g.ApplyGuess("slate"); err == nil {
+		t.Fatal("globally allowed slate accepted in an animals-only game")
+	}
+	if _, _, err := g.ApplyGuess("camel"); err != nil {
+		t.Fatalf("camel (override only): %v", err)
+	}
+	if _, _, err := g.ApplyGuess("horse"); err != nil {
+		t.Fatalf("horse: %v", err)
+	}
+	if len(g.Guesses) != 2 {
+		t.Fatalf("guesses = %v, want the rejected one not counted", g.Guesses)
+	}
+
+	plain := New("crane", 0)
+	if _, _, err := plain.ApplyGuess("slate"); err != nil {
+		t.Fatalf("no override: slate rejected: %v", err)
+	}
+}
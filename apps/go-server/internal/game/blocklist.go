@@ -0,0 +1,61 @@
+// apps/go-server/internal/game/blocklist.go
+//
+// Runtime guess blocklist.
+// Lets operators reject specific words as guesses (e.g. during an incident
+// where a word is offensive or breaks scoring) without a restart.
+//
+// Notes:
+//   - Independent of the word lists: a blocked word stays in the allowed list
+//     but ApplyGuess refuses it with ErrGuessBlocked before scoring.
+//   - Concurrency-safe; mutated via the admin endpoints in httpserver.
+
+package game
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrGuessBlocked is returned by ApplyGuess for guesses on the blocklist.
+var ErrGuessBlocked = errors.New("guess temporarily blocked")
+
+var (
+	blockMu sync.RWMutex
+	blocked = map[string]struct{}{}
+)
+
+// BlockGuess adds w to the guess blocklist.
+func BlockGuess(w string) {
+	blockMu.Lock()
+	defer blockMu.Unlock()
+	blocked[strings.ToLower(strings.TrimSpace(w))] = struct{}{}
+}
+
+// UnblockGuess removes w from the guess blocklist.
+func UnblockGuess(w string) {
+	blockMu.Lock()
+	defer blockMu.Unlock()
+	delete(blocked, strings.ToLower(strings.TrimSpace(w)))
+}
+
+// IsGuessBlocked reports whether w is currently blocked as a guess.
+func IsGuessBlocked(w string) bool {
+	blockMu.RLock()
+	defer blockMu.RUnlock()
+	_, ok := blocked[strings.ToLower(strings.TrimSpace(w))]
+	return ok
+}
+
+// BlockedGuesses returns the current blocklist in sorted order.
+func BlockedGuesses() []string {
+	blockMu.RLock()
+	defer blockMu.RUnlock()
+	out := make([]string, 0, len(blocked))
+	for w := range blocked {
+		out = append(out, w)
+	}
+	sort.Strings(out)
+	return out
+}
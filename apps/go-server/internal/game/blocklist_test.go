@@ -0,0 +1,31 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBlockedGuess(t *testing.T) {
+	useWords(t, []string{"crane"}, []string{"crane", "slate"})
+	t.Cleanup(func() { UnblockGuess("slate") })
+	g := New("crane", 0)
+
+	BlockGuess(" SLATE ")
+	if !IsGuessBlocked("slate") {
+		t.Fatal("slate not blocked")
+	}
+	if _, _, err := g.ApplyGuess("slate"); !errors.Is(err, ErrGuessBlocked) {
+		t.Fatalf("blocked guess: err %v, want ErrGuessBlocked", err)
+	}
+	if len(g.Guesses) != 0 {
+		t.Fatalf("blocked guess consumed a row: %v", g.Guesses)
+	}
+	if got := BlockedGuesses(); len(got) != 1 || got[0] != "slate" {
+		t.Fatalf("BlockedGuesses = %v, want [slate]", got)
+	}
+
+	UnblockGuess("slate")
+	if _, _, err := g.ApplyGuess("slate"); err != nil {
+		t.Fatalf("unblocked guess: %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+// apps/go-server/internal/game/cheat.go
+//
+// Cheat mode (Absurdle-style adversarial host).
+// The game commits to no answer: each guess splits the answers still possible
+// by the marks that guess would get, and the host keeps the largest group,
+// so the player is told as little as possible and the win is put off for as
+// long as the candidates allow.
+//
+// Notes:
+//   - g.Answer always holds one member of the kept group. Every candidate
+//     scores the past guesses identically, so replaying the guesses against
+//     g.Answer (share grids, history, finish records) gives the marks that
+//     were actually returned.
+//   - Candidates are therefore derivable from Answer + Guesses; they are
+//     cached on the Game and rebuilt when missing (e.g. after a store
+//     round-trip), never serialized.
+//   - The pool is the game's custom allowed list if it has one, otherwise
+//     the answer list for g.Cols.
+//   - Groups are keyed by words.PatternCode, so splitting the candidates
+//     allocates nothing per word.
+//   - Ties prefer fewer hits, then fewer presents, then the lowest pattern
+//     code, so the same guesses always meet the same host.
+
+package game
+
+import (
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// narrowCandidates keeps the largest group of candidates that score guess
+// identically and moves g.Answer into it. The win only happens once guess is
+// the sole candidate left.
+func (g *Game) narrowCandidates(guess string) {
+	if g.Candidates == nil {
+		g.Candidates = g.cheatPool()
+	}
+	groups := make(map[uint16][]string)
+	for _, c := range g.Candidates {
+		k := words.PatternCode(guess, c)
+		groups[k] = append(groups[k], c)
+	}
+
+	var best uint16
+	found := false
+	for k, list := range groups {
+		if !found || betterGroup(k, len(list), best, len(groups[best])) {
+			best, found = k, true
+		}
+	}
+	if !found {
+		return // no candidates at all; fall back to the fixed answer
+	}
+	g.Candidates = groups[best]
+	g.Answer = g.Candidates[0]
+}
+
+// cheatPool returns the answers still consistent with the guesses so far,
+// given that g.Answer is one of them.
+func (g *Game) cheatPool() []string {
+	pool := g.Allowed
+	if len(pool) == 0 {
+		pool = words.AnswersLen(g.Cols)
+	}
+	want := make([]uint16, len(g.Guesses))
+	for i, guess := range g.Guesses {
+		want[i] = words.PatternCode(guess, g.Answer)
+	}
+	out := []string{}
+	seen := false
+	for _, w := range pool {
+		if len(w) != g.Cols {
+			continue
+		}
+		ok := true
+		for i, guess := range g.Guesses {
+			if words.PatternCode(guess, w) != want[i] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			out = append(out, w)
+			seen = seen || w == g.Answer
+		}
+	}
+	if !seen {
+		out = append(out, g.Answer)
+	}
+	return out
+}
+
+// betterGroup reports whether group a (pattern code ka, size na) should be
+// kept over group b.
+func betterGroup(ka uint16, na int, kb uint16, nb int) bool {
+	if na != nb {
+		return na > nb
+	}
+	ha, pa := markCounts(ka)
+	hb, pb := markCounts(kb)
+	if ha != hb {
+		return ha < hb
+	}
+	if pa != pb {
+		return pa < pb
+	}
+	return ka < kb
+}
+
+// markCounts returns the hits and presents in a words.PatternCode.
+func markCounts(k uint16) (hits, presents int) {
+	for ; k != 0; k >>= 2 {
+		switch k & 0b11 {
+		case 2:
+			hits++
+		case 1:
+			presents++
+		}
+	}
+	return hits, presents
+}
@@ -0,0 +1,99 @@
+package game
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+func TestCheatCandidatesOnlyShrink(t *testing.T) {
+	pool := []string{"batch", "catch", "hatch", "latch", "match", "patch", "watch", "crane"}
+	useWords(t, pool, append([]string{"vivid"}, pool...))
+	g := New("batch", 20)
+	g.Mode = ModeCheat
+
+	// A guess sharing no letters with the pool rules nothing out.
+	if _, state, err := g.ApplyGuess("vivid"); err != nil || state != "playing" {
+		t.Fatalf("vivid: state %q err %v", state, err)
+	}
+	if len(g.Candidates) != len(pool) {
+		t.Fatalf("after vivid: candidates %v, want the whole pool", g.Candidates)
+	}
+
+	prev := map[string]bool{}
+	for _, c := range g.Candidates {
+		prev[c] = true
+	}
+	for turn := 0; ; turn++ {
+		guess := g.Candidates[0]
+		marks, state, err := g.ApplyGuess(guess)
+		if err != nil {
+			t.Fatalf("guess %s: %v", guess, err)
+		}
+		if state == "won" {
+			if len(prev) != 1 {
+				t.Fatalf("won on %s with %d candidates left, want the win forced only at 1", guess, len(prev))
+			}
+			break
+		}
+		if len(g.Candidates) >= len(prev) {
+			t.Fatalf("guess %s: candidates grew or stayed at %d", guess, len(g.Candidates))
+		}
+		for _, c := range g.Candidates {
+			if !prev[c] {
+				t.Fatalf("guess %s: %s rejoined the candidates", guess, c)
+			}
+		}
+		if !reflect.DeepEqual(marks, scoreGuess(g.Answer, guess)) {
+			t.Fatalf("guess %s: marks %v don't score the answer %s", guess, marks, g.Answer)
+		}
+		for _, c := range g.Candidates {
+			if words.PatternCode(guess, c) != words.PatternCode(guess, g.Answer) {
+				t.Fatalf("guess %s: candidate %s scores differently from the answer %s", guess, c, g.Answer)
+			}
+		}
+		prev = map[string]bool{}
+		for _, c := range g.Candidates {
+			prev[c] = true
+		}
+		if turn > len(pool) {
+			t.Fatal("no win after exhausting the pool")
+		}
+	}
+}
+
+func TestCheatCandidatesRebuiltAfterRoundTrip(t *testing.T) {
+	pool := []string{"batch", "catch", "hatch", "latch", "match", "patch", "watch", "crane"}
+	useWords(t, pool, pool)
+	g := New("batch", 20)
+	g.Mode = ModeCheat
+	if _, _, err := g.ApplyGuess("catch"); err != nil {
+		t.Fatal(err)
+	}
+	want := append([]string(nil), g.Candidates...)
+
+	b, err := Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := Unmarshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.Candidates != nil {
+		t.Fatalf("candidates were serialized: %v", back.Candidates)
+	}
+	got := back.cheatPool()
+	sort.Strings(got)
+	sort.Strings(want)
+	if len(got) != len(want) {
+		t.Fatalf("rebuilt candidates = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("rebuilt candidates = %v, want %v", got, want)
+		}
+	}
+}
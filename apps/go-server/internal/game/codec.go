@@ -0,0 +1,135 @@
+// apps/go-server/internal/game/codec.go
+//
+// Canonical, versioned JSON form of a Game for stores and client sync.
+//
+// Compatibility rules:
+//   - Every document carries "v" (SchemaVersion at the time it was written).
+//   - Unknown fields are ignored, so documents from newer servers still load.
+//   - Missing fields are defaulted, so older documents load too:
+//       v0 (no "v"; guesses and answer only) → normal mode, DefaultRows rows,
+//       len(answer) columns, and finished/won derived from the guesses.
+//   - Times are RFC3339Nano UTC; omitted when zero.
+//
+// Bump SchemaVersion when a field changes meaning, and teach Unmarshal to
+// upgrade the older shape; adding a field with a sensible zero value doesn't
+// need a bump.
+
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// SchemaVersion is the version Marshal writes.
+const SchemaVersion = 1
+
+// ErrNoAnswer is returned by Unmarshal for documents without an answer.
+var ErrNoAnswer = errors.New("game: serialized game has no answer")
+
+// wireGame is the serialized shape. Pointers tell "missing" from zero.
+type wireGame struct {
+	V          int        `json:"v"`
+	ID         string     `json:"id"`
+	Mode       Mode       `json:"mode,omitempty"`
+	Answer     string     `json:"answer"`
+	Rows       int        `json:"rows,omitempty"`
+	Cols       int        `json:"cols,omitempty"`
+	Guesses    []string   `json:"guesses"`
+	Counts     []int      `json:"counts,omitempty"`
+	Allowed    []string   `json:"allowed,omitempty"`
+	Finished   *bool      `json:"finished,omitempty"`
+	Won        *bool      `json:"won,omitempty"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// Marshal encodes g in the current canonical form.
+func Marshal(g *Game) ([]byte, error) {
+	guesses := g.Guesses
+	if guesses == nil {
+		guesses = []string{}
+	}
+	return json.Marshal(wireGame{
+		V:          SchemaVersion,
+		ID:         g.ID,
+		Mode:       g.Mode,
+		Answer:     g.Answer,
+		Rows:       g.Rows,
+		Cols:       g.Cols,
+		Guesses:    guesses,
+		Counts:     g.Counts,
+		Allowed:    g.Allowed,
+		Finished:   &g.Finished,
+		Won:        &g.Won,
+		CreatedAt:  timePtr(g.CreatedAt),
+		FinishedAt: timePtr(g.FinishedAt),
+	})
+}
+
+// timePtr returns t in UTC, or nil for the zero time.
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+// Unmarshal decodes any known version of the canonical form, filling in
+// defaults for fields the writer didn't know about.
+func Unmarshal(data []byte) (*Game, error) {
+	var w wireGame
+	if err := json.Unmarshal(data, &w); err != nil {
+		return nil, err
+	}
+	if w.Answer == "" {
+		return nil, ErrNoAnswer
+	}
+	g := &Game{
+		ID:      w.ID,
+		Mode:    w.Mode,
+		Answer:  w.Answer,
+		Rows:    w.Rows,
+		Cols:    w.Cols,
+		Guesses: w.Guesses,
+		Counts:  w.Counts,
+		Allowed: w.Allowed,
+	}
+	if w.CreatedAt != nil {
+		g.CreatedAt = w.CreatedAt.UTC()
+	}
+	if w.FinishedAt != nil {
+		g.FinishedAt = w.FinishedAt.UTC()
+	}
+	if g.Mode == "" {
+		g.Mode = ModeNormal
+	}
+	if g.Rows <= 0 {
+		g.Rows = DefaultRows
+	}
+	if g.Cols <= 0 {
+		g.Cols = len(g.Answer)
+	}
+	if g.Guesses == nil {
+		g.Guesses = []string{}
+	}
+
+	// Older documents don't record the outcome; derive it from the guesses.
+	solved := false
+	for _, guess := range g.Guesses {
+		if guess == g.Answer {
+			solved = true
+		}
+	}
+	g.Won = solved
+	if w.Won != nil {
+		g.Won = *w.Won
+	}
+	g.Finished = g.Won || len(g.Guesses) >= g.Rows
+	if w.Finished != nil {
+		g.Finished = *w.Finished
+	}
+	return g, nil
+}
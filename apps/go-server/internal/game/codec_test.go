@@ -0,0 +1,69 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 42, time.UTC)
+	for _, g := range []*Game{
+		{ID: "a", Mode: ModeNormal, Answer: "crane", Rows: 6, Cols: 5, Guesses: []string{}, CreatedAt: at},
+		{ID: "b", Mode: ModeJotto, Answer: "crane", Rows: 8, Cols: 5, Guesses: []string{"slate", "crane"}, Counts: []int{2, 5},
+			Allowed: []string{"crane", "slate"}, Finished: true, Won: true, CreatedAt: at, FinishedAt: at.Add(time.Minute)},
+		{ID: "c", Mode: ModeHard, Answer: "crane", Rows: 1, Cols: 5, Guesses: []string{"slate"}, Finished: true},
+	} {
+		data, err := Marshal(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var v struct{ V int }
+		if err := json.Unmarshal(data, &v); err != nil || v.V != SchemaVersion {
+			t.Fatalf("%s: v = %d, want %d", g.ID, v.V, SchemaVersion)
+		}
+		got, err := Unmarshal(data)
+		if err != nil {
+			t.Fatalf("%s: Unmarshal: %v", g.ID, err)
+		}
+		if !reflect.DeepEqual(got, g) {
+			t.Fatalf("%s round trip:\n got %+v\nwant %+v", g.ID, got, g)
+		}
+	}
+}
+
+func TestUnmarshalOlderAndNewerDocuments(t *testing.T) {
+	// v0: answer and guesses only; the outcome is derived.
+	for _, tc := range []struct {
+		doc            string
+		finished, won  bool
+		wantRows, cols int
+	}{
+		{`{"id":"w","answer":"crane","guesses":["slate","crane"]}`, true, true, DefaultRows, 5},
+		{`{"id":"p","answer":"planet","guesses":["silver"]}`, false, false, DefaultRows, 6},
+		{`{"id":"l","answer":"crane","guesses":["a","b","c","d","e","f"]}`, true, false, DefaultRows, 5},
+	} {
+		g, err := Unmarshal([]byte(tc.doc))
+		if err != nil {
+			t.Fatalf("%s: %v", tc.doc, err)
+		}
+		if g.Mode != ModeNormal || g.Rows != tc.wantRows || g.Cols != tc.cols || g.Finished != tc.finished || g.Won != tc.won {
+			t.Fatalf("%s: got %+v", tc.doc, g)
+		}
+	}
+
+	// A newer writer's unknown fields are ignored; explicit fields win.
+	g, err := Unmarshal([]byte(`{"v":7,"id":"n","answer":"crane","guesses":[],"finished":true,"won":false,"rows":4,"hint":{"x":1}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.Finished || g.Won || g.Rows != 4 || g.Guesses == nil {
+		t.Fatalf("newer document = %+v", g)
+	}
+
+	if _, err := Unmarshal([]byte(`{"v":1,"guesses":[]}`)); !errors.Is(err, ErrNoAnswer) {
+		t.Fatalf("no answer: err %v, want ErrNoAnswer", err)
+	}
+}
@@ -2,9 +2,12 @@
 //
 // Core game engine for a single Wordle session.
 // Responsibilities:
-//   - Create new games with deterministic dimensions (6x5).
-//   - Validate and apply guesses (length, alphabetic, allowed list).
-//   - Score guesses using the classic two‑pass Wordle algorithm.
+//   - Create new games (6 rows unless asked for 1–12; columns follow the
+//     answer length, 5 by default), or practice games whose answer is derived
+//     from a seed (NewSeeded).
+//   - Validate and apply guesses (length, alphabetic, allowed list for that length).
+//   - Score guesses using the classic two‑pass Wordle algorithm
+//     (or a shared‑letter count in Jotto mode).
 //   - Track state transitions: playing → won/lost.
 //
 // Notes:
@@ -17,41 +20,117 @@ package game
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/robalobadob/wordle/apps/go-server/internal/words"
 )
 
+// DefaultRows is the number of guesses a game allows; the daily uses the same limit.
+const DefaultRows = 6
+
+// MinRows and MaxRows bound the row count a classic game may ask for.
 const (
-	defaultRows = 6
-	defaultCols = 5
+	MinRows = 1
+	MaxRows = 12
 )
 
+const defaultCols = 5
+
 // New constructs a new game instance.
 // If withAnswer is empty, a random answer is chosen from the words package.
-func New(withAnswer string) *Game {
-	ans := withAnswer
+// The column count follows the answer length so variant lengths are scored
+// against the matching allowed set. rows <= 0 means DefaultRows; anything else
+// is clamped to MinRows–MaxRows.
+func New(withAnswer string, rows int) *Game {
+	ans := strings.ToLower(withAnswer)
 	if ans == "" {
 		ans = words.RandomAnswer()
 	}
-	return &Game {
-		ID:      randomID(),
-		Answer:  strings.ToLower(ans),
-		Rows:    defaultRows,
-		Cols:    defaultCols,
-		Guesses: []string{},
+	cols := len(ans)
+	if cols == 0 {
+		cols = defaultCols
+	}
+	if rows <= 0 {
+		rows = DefaultRows
+	}
+	rows = min(max(rows, MinRows), MaxRows)
+	return &Game{
+		ID:        randomID(),
+		Mode:      ModeNormal,
+		Answer:    ans,
+		Rows:      rows,
+		Cols:      cols,
+		Guesses:   []string{},
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// NewSeeded is New for a practice game: the answer is the default-length
+// classic answer at SHA-256(seed) (first 8 bytes, big-endian) mod the list
+// size. The same seed gives the same answer as long as the answer list is
+// unchanged; themes and weighting don't apply.
+func NewSeeded(seed string, rows int) *Game {
+	g := New(SeedAnswer(seed), rows)
+	g.Mode = ModePractice
+	return g
+}
+
+// SeedAnswer returns the answer NewSeeded picks for seed ("" if no
+// default-length answers are loaded).
+func SeedAnswer(seed string) string {
+	list := words.AnswersLen(words.DefaultLength)
+	if len(list) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(seed))
+	return list[binary.BigEndian.Uint64(sum[:8])%uint64(len(list))]
+}
+
+// ParseMode maps a client-supplied mode string to a Mode.
+// Empty and "normal" map to ModeNormal.
+func ParseMode(s string) (Mode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "normal":
+		return ModeNormal, nil
+	case "cheat":
+		return ModeCheat, nil
+	case "hard":
+		return ModeHard, nil
+	case "jotto":
+		return ModeJotto, nil
+	case "practice":
+		return ModePractice, nil
 	}
+	return "", errors.New("invalid mode")
+}
+
+// NormalizeGuess is the form a raw guess is scored in: surrounding
+// whitespace trimmed and lowercased. Both classic and daily guesses use it.
+func NormalizeGuess(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
 }
 
 // ApplyGuess validates and scores a guess, mutating the game state.
 // Returns: the per‑letter marks, the new state string ("playing"/"won"/"lost"), or an error.
+// In Jotto mode marks are nil; the shared-letter count is appended to g.Counts instead.
 //
 // Validation rules:
 //   - Game must not be finished.
 //   - Guess must be exactly g.Cols letters and alphabetic a–z.
-//   - Guess must be present in the allowed list.
+//   - Guess must be present in g.Allowed when the game has an override,
+//     otherwise in the global allowed list for g.Cols-letter words.
+//   - Guess must not be on the runtime blocklist (ErrGuessBlocked).
+//   - Hard mode: guess must honour every hint revealed so far
+//     (words.CheckHardMode, which re-scores earlier guesses). A rejected
+//     guess does not consume a row.
+//
+// Cheat mode first moves the answer to the largest group of candidates that
+// the guess can't tell apart (narrowCandidates), then scores as usual.
 //
 // State transitions:
 //   - If all tiles are Hit → Finished = true, Won = true.
@@ -60,25 +139,53 @@ func (g *Game) ApplyGuess(guess string) ([]Mark, string, error) {
 	if g.Finished {
 		return nil, g.state(), errors.New("game finished")
 	}
-	guess = strings.ToLower(strings.TrimSpace(guess))
+	guess = NormalizeGuess(guess)
 	if len(guess) != g.Cols || !isAlpha(guess) {
 		return nil, g.state(), errors.New("invalid guess")
 	}
-	if !words.IsAllowed(guess) {
+	if !g.allows(guess) {
 		return nil, g.state(), errors.New("not in word list")
 	}
+	if IsGuessBlocked(guess) {
+		return nil, g.state(), ErrGuessBlocked
+	}
+	if g.Mode == ModeHard {
+		if err := words.CheckHardMode(g.Answer, g.Guesses, guess); err != nil {
+			return nil, g.state(), err
+		}
+	}
+
+	if g.Mode == ModeJotto {
+		g.Guesses = append(g.Guesses, guess)
+		g.Counts = append(g.Counts, jottoScore(g.Answer, guess))
+		if guess == g.Answer {
+			g.finish(true)
+		} else if len(g.Guesses) >= g.Rows {
+			g.finish(false)
+		}
+		return nil, g.state(), nil
+	}
 
+	if g.Mode == ModeCheat {
+		g.narrowCandidates(guess)
+	}
 	marks := scoreGuess(g.Answer, guess)
 	g.Guesses = append(g.Guesses, guess)
 
 	if allHit(marks) {
-		g.Finished, g.Won = true, true
+		g.finish(true)
 	} else if len(g.Guesses) >= g.Rows {
-		g.Finished = true
+		g.finish(false)
 	}
 	return marks, g.state(), nil
 }
 
+// finish marks the game over and stamps FinishedAt.
+func (g *Game) finish(won bool) {
+	g.Finished, g.Won = true, won
+	g.FinishedAt = time.Now().UTC()
+}
+
 // state reports a coarse string representation of the current game state.
 func (g *Game) state() string {
 	if g.Finished {
@@ -135,6 +242,24 @@ func scoreGuess(answer, guess string) []Mark {
 	return res
 }
 
+// jottoScore counts letters shared by answer and guess regardless of position.
+// Repeated letters count only as often as they appear in both words
+// (e.g. answer "apple", guess "paper" → a, p, p, e = 4).
+func jottoScore(answer, guess string) int {
+	var counts [26]int
+	for _, r := range answer {
+		counts[idx(r)]++
+	}
+	n := 0
+	for _, r := range guess {
+		if j := idx(r); j >= 0 && j < 26 && counts[j] > 0 {
+			counts[j]--
+			n++
+		}
+	}
+	return n
+}
+
 // idx maps a lowercase ASCII letter rune to 0..25.
 // Assumes inputs are validated to a–z elsewhere.
 func idx(r rune) int { return int(r - 'a') }
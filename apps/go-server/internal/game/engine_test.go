@@ -0,0 +1,164 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// useWords points the words package at answers and allowed for the rest of
+// the test and restores the configured lists afterwards.
+func useWords(t *testing.T, answers, allowed []string) {
+	t.Helper()
+	// Registered first so it runs after the env is restored.
+	t.Cleanup(func() { _, _ = words.Reload() })
+	dir := t.TempDir()
+	for name, list := range map[string][]string{"answers.txt": answers, "allowed.txt": allowed} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(strings.Join(list, "\n")+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("WORDS_ANSWERS_FILE", filepath.Join(dir, "answers.txt"))
+	t.Setenv("WORDS_ALLOWED_FILE", filepath.Join(dir, "allowed.txt"))
+	if _, err := words.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+}
+
+func TestSixLetterWordsOnlyInSixLetterGames(t *testing.T) {
+	useWords(t, []string{"crane", "planet"}, []string{"crane", "slate", "planet", "silver"})
+
+	six := New("planet", 0)
+	if six.Cols != 6 {
+		t.Fatalf("Cols = %d, want 6", six.Cols)
+	}
+	if _, _, err := six.ApplyGuess("silver"); err != nil {
+		t.Fatalf("silver in a 6-letter game: %v", err)
+	}
+	if _, _, err := six.ApplyGuess("slate"); err == nil {
+		t.Fatal("slate accepted in a 6-letter game")
+	}
+
+	five := New("crane", 0)
+	if _, _, err := five.ApplyGuess("silver"); err == nil {
+		t.Fatal("silver accepted in a 5-letter game")
+	}
+	if _, _, err := five.ApplyGuess("slate"); err != nil {
+		t.Fatalf("slate in a 5-letter game: %v", err)
+	}
+	if len(five.Guesses) != 1 || len(six.Guesses) != 1 {
+		t.Fatalf("rejected guesses consumed rows: five=%v six=%v", five.Guesses, six.Guesses)
+	}
+}
+
+func TestJottoScore(t *testing.T) {
+	for _, tc := range []struct {
+		answer, guess string
+		want          int
+	}{
+		{"crane", "crane", 5},
+		{"crane", "nacre", 5},
+		{"crane", "pious", 0},
+		{"crane", "slate", 2},
+		{"sassy", "asses", 4}, // answer has three s, guess three s and one a
+		{"abbey", "bobby", 3}, // b, b, y; the guess's third b has nothing left to match
+		{"llama", "hello", 2},
+	} {
+		if got := jottoScore(tc.answer, tc.guess); got != tc.want {
+			t.Errorf("jottoScore(%q, %q) = %d, want %d", tc.answer, tc.guess, got, tc.want)
+		}
+	}
+}
+
+func TestJottoApplyGuess(t *testing.T) {
+	useWords(t, []string{"crane"}, []string{"crane", "nacre", "slate"})
+	g := New("crane", 0)
+	g.Mode = ModeJotto
+
+	marks, state, err := g.ApplyGuess("nacre")
+	if err != nil || marks != nil || state != "playing" {
+		t.Fatalf("nacre: marks %v state %q err %v", marks, state, err)
+	}
+	if _, _, err := g.ApplyGuess("zzzzz"); err == nil {
+		t.Fatal("jotto skipped the allowed-list check")
+	}
+	if _, state, _ = g.ApplyGuess("crane"); state != "won" {
+		t.Fatalf("state after the answer = %q, want won", state)
+	}
+	if len(g.Counts) != 2 || g.Counts[0] != 5 || g.Counts[1] != 5 {
+		t.Fatalf("Counts = %v, want [5 5]", g.Counts)
+	}
+}
+
+func TestNormalizeGuess(t *testing.T) {
+	for in, want := range map[string]string{
+		"slate":     "slate",
+		"  SLATE ":  "slate",
+		"\tCrAnE\n": "crane",
+		"sl ate":    "sl ate", // inner spaces are kept (and then rejected)
+		"":          "",
+	} {
+		if got := NormalizeGuess(in); got != want {
+			t.Errorf("NormalizeGuess(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRows(t *testing.T) {
+	for rows, want := range map[int]int{0: DefaultRows, -2: DefaultRows, 1: 1, 3: 3, 12: 12, 50: MaxRows} {
+		if g := New("crane", rows); g.Rows != want {
+			t.Errorf("New(rows=%d).Rows = %d, want %d", rows, g.Rows, want)
+		}
+	}
+
+	useWords(t, []string{"crane"}, []string{"crane", "slate", "trace", "adieu"})
+	g := New("crane", 3)
+	for i, w := range []string{"slate", "trace", "adieu"} {
+		_, state, err := g.ApplyGuess(w)
+		if err != nil {
+			t.Fatalf("guess %d: %v", i+1, err)
+		}
+		if want := map[bool]string{false: "playing", true: "lost"}[i == 2]; state != want {
+			t.Fatalf("state after guess %d = %q, want %q", i+1, state, want)
+		}
+	}
+	if !g.Finished || g.Won {
+		t.Fatalf("3-row game after 3 misses: finished %v won %v", g.Finished, g.Won)
+	}
+	if _, _, err := g.ApplyGuess("crane"); err == nil {
+		t.Fatal("a fourth guess was accepted")
+	}
+}
+
+func TestSeedAnswer(t *testing.T) {
+	answers := []string{"crane", "slate", "trace", "adieu", "plant", "ghost", "brick"}
+	useWords(t, answers, answers)
+
+	seen := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		seed := "seed-" + strings.Repeat("x", i)
+		a := SeedAnswer(seed)
+		if a == "" || a != SeedAnswer(seed) {
+			t.Fatalf("SeedAnswer(%q) = %q then %q", seed, a, SeedAnswer(seed))
+		}
+		seen[a] = true
+	}
+	if len(seen) < 3 {
+		t.Fatalf("50 seeds picked only %v", seen)
+	}
+
+	a, b := NewSeeded("share-me", 0), NewSeeded("share-me", 4)
+	if a.Answer != b.Answer || a.Answer != SeedAnswer("share-me") {
+		t.Fatalf("NewSeeded answers %q and %q, want %q", a.Answer, b.Answer, SeedAnswer("share-me"))
+	}
+	if a.Mode != ModePractice || b.Rows != 4 || a.ID == b.ID {
+		t.Fatalf("NewSeeded: mode %v rows %d ids %s/%s", a.Mode, b.Rows, a.ID, b.ID)
+	}
+	if m, err := ParseMode(" Practice "); err != nil || m != ModePractice {
+		t.Fatalf("ParseMode(practice) = %v, %v", m, err)
+	}
+}
@@ -0,0 +1,71 @@
+// apps/go-server/internal/game/share.go
+//
+// Shareable emoji grids for finished games.
+//
+// The grid never includes letters: each guess becomes one row of squares,
+// under a header like "Wordle 4/6" ("X/6" for a loss, "*" marks hard mode).
+// Jotto games have no positional marks, so each row shows the shared-letter
+// count as that many Hit squares followed by Miss squares.
+
+package game
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Palette selects the emoji used for each mark in a share grid.
+type Palette struct {
+	Hit, Present, Miss string
+}
+
+var (
+	// PaletteClassic is the standard green/yellow grid.
+	PaletteClassic = Palette{Hit: "🟩", Present: "🟨", Miss: "⬛"}
+	// PaletteHighContrast matches the color-blind (high contrast) setting.
+	PaletteHighContrast = Palette{Hit: "🟧", Present: "🟦", Miss: "⬛"}
+)
+
+// ShareGrid renders g with PaletteClassic. See ShareGridWith.
+func ShareGrid(g *Game) string {
+	return ShareGridWith(g, PaletteClassic)
+}
+
+// ShareGridWith renders the header and one emoji row per guess of g using p.
+// Marks are recomputed from the stored guesses with the same scoring as play.
+func ShareGridWith(g *Game, p Palette) string {
+	score := "X"
+	if g.Won {
+		score = strconv.Itoa(len(g.Guesses))
+	}
+	header := "Wordle " + score + "/" + strconv.Itoa(g.Rows)
+	if g.Mode == ModeHard {
+		header += "*"
+	}
+
+	lines := []string{header, ""}
+	for i, guess := range g.Guesses {
+		var b strings.Builder
+		if g.Mode == ModeJotto {
+			n := 0
+			if i < len(g.Counts) {
+				n = g.Counts[i]
+			}
+			b.WriteString(strings.Repeat(p.Hit, n))
+			b.WriteString(strings.Repeat(p.Miss, max(g.Cols-n, 0)))
+		} else {
+			for _, m := range scoreGuess(g.Answer, guess) {
+				switch m {
+				case MarkHit:
+					b.WriteString(p.Hit)
+				case MarkPresent:
+					b.WriteString(p.Present)
+				default:
+					b.WriteString(p.Miss)
+				}
+			}
+		}
+		lines = append(lines, b.String())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,27 @@
+package game
+
+import "testing"
+
+func TestShareGrid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		g    *Game
+		want string
+	}{
+		{"won", &Game{Answer: "crane", Guesses: []string{"slate", "react", "crane"}, Won: true, Rows: 6, Cols: 5, Mode: ModeNormal},
+			"Wordle 3/6\n\n⬛⬛🟩⬛🟩\n🟨🟨🟩🟨⬛\n🟩🟩🟩🟩🟩"},
+		{"lost hard", &Game{Answer: "crane", Guesses: []string{"slate"}, Rows: 1, Cols: 5, Mode: ModeHard},
+			"Wordle X/1*\n\n⬛⬛🟩⬛🟩"},
+		{"jotto", &Game{Answer: "crane", Guesses: []string{"slate", "crane"}, Counts: []int{2, 5}, Won: true, Rows: 6, Cols: 5, Mode: ModeJotto},
+			"Wordle 2/6\n\n🟩🟩⬛⬛⬛\n🟩🟩🟩🟩🟩"},
+	} {
+		if got := ShareGrid(tc.g); got != tc.want {
+			t.Errorf("%s: ShareGrid =\n%s\nwant\n%s", tc.name, got, tc.want)
+		}
+	}
+
+	g := &Game{Answer: "crane", Guesses: []string{"react"}, Rows: 1, Cols: 5, Mode: ModeNormal}
+	if got, want := ShareGridWith(g, PaletteHighContrast), "Wordle X/1\n\n🟦🟦🟧🟦⬛"; got != want {
+		t.Errorf("high contrast = %q, want %q", got, want)
+	}
+}
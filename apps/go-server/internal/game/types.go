@@ -3,10 +3,14 @@
 // Core type definitions for the Wordle game engine.
 // Defines:
 //   - Mark: per-letter result of a guess (hit/present/miss).
+//   - Mode: game variant (normal Wordle, hard mode, count-only Jotto scoring,
+//     the adversarial cheat host, or a seeded practice board).
 //   - Game: state for a single in-progress or finished game.
 
 package game
 
+import "time"
+
 // Mark represents the evaluation result for a single letter in a guess.
 // Possible values:
 //   - "hit":    letter is correct and in the correct position.
@@ -15,18 +19,44 @@ package game
 type Mark string
 
 const (
-	MarkHit    Mark = "hit"
-	MarkPresent     = "present"
-	MarkMiss        = "miss"
+	MarkHit     Mark = "hit"
+	MarkPresent Mark = "present"
+	MarkMiss    Mark = "miss"
+)
+
+// Mode selects how guesses are scored.
+//   - "normal": classic per-letter marks.
+//   - "hard":   classic marks; revealed hints must be used in later guesses.
+//   - "jotto":  position-independent; each guess scores the count of shared letters.
+//   - "cheat":  classic marks, but the answer dodges each guess (see cheat.go).
+//   - "practice": classic marks; the answer comes from a seed (see NewSeeded).
+type Mode string
+
+const (
+	ModeNormal   Mode = "normal"
+	ModeHard     Mode = "hard"
+	ModeJotto    Mode = "jotto"
+	ModeCheat    Mode = "cheat"
+	ModePractice Mode = "practice"
 )
 
 // Game holds the state of a single Wordle game session.
 type Game struct {
 	ID       string   // Unique game identifier (random hex string).
+	Mode     Mode     // Scoring variant (ModeNormal unless requested otherwise).
 	Answer   string   // The solution word (always lowercase).
 	Rows     int      // Maximum number of guesses allowed (typically 6).
 	Cols     int      // Number of letters per word (typically 5).
 	Guesses  []string // List of guesses made so far (lowercased).
+	Counts   []int    // Jotto only: shared-letter count per guess (parallel to Guesses).
 	Finished bool     // True once the game is over (won or lost).
 	Won      bool     // True if the game was finished with a win.
+	Allowed  []string // Custom allowed-guess list (sorted, lowercase); nil = the global list.
+
+	// Cheat only: answers still consistent with every guess (a cache; rebuilt
+	// from Answer and Guesses when nil, and not serialized).
+	Candidates []string
+
+	CreatedAt  time.Time // When New created the game (UTC).
+	FinishedAt time.Time // When the final guess was applied (UTC); zero while playing.
 }
@@ -0,0 +1,14 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarkConstantsAreTyped(t *testing.T) {
+	for _, m := range []any{MarkHit, MarkPresent, MarkMiss} {
+		if name := reflect.TypeOf(m).Name(); name != "Mark" {
+			t.Errorf("%v has type %s, want Mark", m, name)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// apps/go-server/internal/httpserver/bodylimit.go
+//
+// Request body size cap, applied to every route in New.
+//   - A declared Content-Length over the cap is refused with 413
+//     payload_too_large before the handler runs.
+//   - Bodies without a length (chunked) are read through
+//     http.MaxBytesReader; the read that crosses the cap writes the 413
+//     itself, and whatever the handler writes afterwards (typically its own
+//     bad_json error) is discarded, so clients see 413 either way.
+//
+// Config:
+//   - MAX_BODY_BYTES    cap for all routes (default 64 KiB; 0 = no cap)
+//   - IMPORT_MAX_BYTES  cap for POST /admin/import instead (default 10 MiB)
+
+package httpserver
+
+import (
+	"errors"
+	"io"
+	"net/http"
+)
+
+// bodyLimitOverrides replaces the cap for routes that take large documents.
+var bodyLimitOverrides = map[string]func() int64{
+	"/admin/import": func() int64 { return int64(envInt("IMPORT_MAX_BYTES", 10<<20)) },
+}
+
+// withBodyLimit caps request bodies at MAX_BODY_BYTES (see file comment).
+func withBodyLimit() func(http.Handler) http.Handler {
+	limit := int64(envInt("MAX_BODY_BYTES", 64<<10))
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := limit
+			if f, ok := bodyLimitOverrides[r.URL.Path]; ok {
+				n = f()
+			}
+			if n <= 0 || r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if r.ContentLength > n {
+				tooLarge(w)
+				return
+			}
+			lw := &limitedWriter{ResponseWriter: w}
+			r.Body = &limitedBody{ReadCloser: http.MaxBytesReader(w, r.Body, n), w: lw}
+			next.ServeHTTP(lw, r)
+		})
+	}
+}
+
+// tooLarge writes the 413 response.
+func tooLarge(w http.ResponseWriter) {
+	writeError(w, http.StatusRequestEntityTooLarge, "payload_too_large", "")
+}
+
+// limitedWriter drops everything the handler writes once the body cap has
+// answered the request.
+type limitedWriter struct {
+	http.ResponseWriter
+	rejected bool
+}
+
+func (lw *limitedWriter) WriteHeader(status int) {
+	if !lw.rejected {
+		lw.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (lw *limitedWriter) Write(b []byte) (int, error) {
+	if lw.rejected {
+		return len(b), nil
+	}
+	return lw.ResponseWriter.Write(b)
+}
+
+// limitedBody answers 413 on the read that crosses the cap.
+type limitedBody struct {
+	io.ReadCloser
+	w *limitedWriter
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var tooBig *http.MaxBytesError
+	if err != nil && !b.w.rejected && errors.As(err, &tooBig) {
+		tooLarge(b.w.ResponseWriter)
+		b.w.rejected = true
+	}
+	return n, err
+}
@@ -0,0 +1,45 @@
+package httpserver
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBodyLimit(t *testing.T) {
+	ts := newTestServer(t, "MAX_BODY_BYTES", "1024")
+	c := ts.client()
+	c.signup("big_sender")
+	id := c.newGame(nil)
+	huge := `{"username":"x","password":"` + strings.Repeat("a", 4096) + `"}`
+
+	// Declared length over the cap.
+	if status, raw := ts.client().do("POST", "/auth/signup", huge); status != http.StatusRequestEntityTooLarge || errorCode(raw) != "payload_too_large" {
+		t.Fatalf("signup with a large body: %d %s, want 413 payload_too_large", status, raw)
+	}
+
+	// Chunked (no Content-Length): the decoder hits the cap mid-read.
+	body := `{"gameId":"` + id + `","guess":"` + strings.Repeat("a", 4096) + `"}`
+	req, err := http.NewRequest("POST", ts.url+"/game/guess", io.MultiReader(strings.NewReader(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := c.hc.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	if res.StatusCode != http.StatusRequestEntityTooLarge || errorCode(raw) != "payload_too_large" {
+		t.Fatalf("chunked guess: %d %s, want 413 payload_too_large", res.StatusCode, raw)
+	}
+	if strings.Count(string(raw), `"error"`) != 1 {
+		t.Fatalf("chunked guess wrote more than one error: %s", raw)
+	}
+
+	// Small bodies are untouched.
+	if status, _ := c.guess(id, "zz"); status != http.StatusBadRequest {
+		t.Fatalf("small invalid guess: status %d, want its own 400", status)
+	}
+}
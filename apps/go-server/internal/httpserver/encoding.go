@@ -0,0 +1,87 @@
+// apps/go-server/internal/httpserver/encoding.go
+//
+// Mark encoding negotiation for guess responses.
+// Responsibilities:
+//   - Decide, per request, how per-letter marks are serialized.
+//   - Default (legacy): each endpoint keeps its historical encoding —
+//     /game/guess returns strings ("hit"/"present"/"miss"), /daily/guess ints.
+//   - Accept: application/vnd.wordle.v2+json: every endpoint returns ints
+//     (0=miss, 1=present, 2=hit), matching words.Score.
+//   - ?marks=packed or X-Marks-Encoding: packed: marks are a single integer,
+//     2 bits per letter (see words.PackMarks); takes precedence over Accept.
+
+package httpserver
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// mediaTypeV2 selects the unified integer mark encoding.
+const mediaTypeV2 = "application/vnd.wordle.v2+json"
+
+// markEncoding is the negotiated wire format for marks.
+type markEncoding int
+
+const (
+	marksLegacy markEncoding = iota // per-endpoint historical encoding
+	marksInts                       // 0=miss, 1=present, 2=hit everywhere
+	marksPacked                     // words.PackMarks bitmask
+)
+
+// negotiateMarks picks the mark encoding from the request and, for v2,
+// labels the response with the vendor media type.
+func negotiateMarks(w http.ResponseWriter, r *http.Request) markEncoding {
+	w.Header().Add("Vary", "Accept")
+	w.Header().Add("Vary", "X-Marks-Encoding")
+	if r.URL.Query().Get("marks") == "packed" || r.Header.Get("X-Marks-Encoding") == "packed" {
+		return marksPacked
+	}
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mt, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err == nil && mt == mediaTypeV2 {
+			w.Header().Set("Content-Type", mediaTypeV2+"; charset=utf-8")
+			return marksInts
+		}
+	}
+	return marksLegacy
+}
+
+// classic encodes engine marks; nil stays nil so omitempty still applies (jotto).
+func (e markEncoding) classic(marks []game.Mark) any {
+	if marks == nil {
+		return nil
+	}
+	if e == marksLegacy {
+		return marks
+	}
+	out := make([]int, len(marks))
+	for i, m := range marks {
+		out[i] = markInt(m)
+	}
+	return e.daily(out)
+}
+
+// daily encodes words.Score output; ints unless packing was requested.
+func (e markEncoding) daily(marks []int) any {
+	if e == marksPacked {
+		return words.PackMarks(marks)
+	}
+	return marks
+}
+
+// markInt maps an engine mark to the words.Score integer scale.
+func markInt(m game.Mark) int {
+	switch m {
+	case game.MarkHit:
+		return 2
+	case game.MarkPresent:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,62 @@
+package httpserver
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+func TestNegotiateMarks(t *testing.T) {
+	for _, tc := range []struct {
+		accept, query string
+		want          markEncoding
+		v2            bool
+	}{
+		{"", "", marksLegacy, false},
+		{"application/json", "", marksLegacy, false},
+		{mediaTypeV2, "", marksInts, true},
+		{"application/json, " + mediaTypeV2 + "; q=0.9", "", marksInts, true},
+		{mediaTypeV2, "?marks=packed", marksPacked, false},
+	} {
+		r := httptest.NewRequest("POST", "/game/guess"+tc.query, nil)
+		r.Header.Set("Accept", tc.accept)
+		w := httptest.NewRecorder()
+		if got := negotiateMarks(w, r); got != tc.want {
+			t.Errorf("Accept %q %s: encoding %d, want %d", tc.accept, tc.query, got, tc.want)
+		}
+		if v2 := w.Header().Get("Content-Type") == mediaTypeV2+"; charset=utf-8"; v2 != tc.v2 {
+			t.Errorf("Accept %q %s: Content-Type %q", tc.accept, tc.query, w.Header().Get("Content-Type"))
+		}
+	}
+}
+
+func TestMarkEncodingPerAccept(t *testing.T) {
+	ts := newTestServer(t, "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("negotiator")
+	list := words.AnswersLen(5)
+	miss := list[1]
+	dailyID, dailyAnswer := c.startDaily(ts)
+	dailyMiss := wrongGuesses(dailyAnswer, 2)
+
+	for i, tc := range []struct {
+		accept       string
+		classic, day reflect.Type
+	}{
+		{"", reflect.TypeOf(""), reflect.TypeOf(0.0)},
+		{mediaTypeV2, reflect.TypeOf(0.0), reflect.TypeOf(0.0)},
+	} {
+		var classic struct{ Marks []any }
+		c.call("POST", "/game/guess", guessReq{GameID: c.newGame(newGameReq{Answer: list[0]}), Guess: miss}, &classic, "Accept", tc.accept)
+		if len(classic.Marks) != 5 || reflect.TypeOf(classic.Marks[0]) != tc.classic {
+			t.Errorf("Accept %q: /game/guess marks %v, want %v elements", tc.accept, classic.Marks, tc.classic)
+		}
+		var day struct{ Marks []any }
+		c.call("POST", "/daily/guess", map[string]string{"gameId": dailyID, "word": dailyMiss[i]}, &day, "Accept", tc.accept)
+		if len(day.Marks) != 5 || reflect.TypeOf(day.Marks[0]) != tc.day {
+			t.Errorf("Accept %q: /daily/guess marks %v, want %v elements", tc.accept, day.Marks, tc.day)
+		}
+	}
+}
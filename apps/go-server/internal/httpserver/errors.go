@@ -0,0 +1,63 @@
+// apps/go-server/internal/httpserver/errors.go
+//
+// Error responses.
+//   - Every error is written as {"error": {"code": "...", "message": "..."}}
+//     with the route's usual HTTP status. Clients branch on the snake_case
+//     code; the message is for people and may change or be translated.
+//   - writeError writes the envelope; an empty message falls back to the
+//     status text ("Internal Server Error" for db_error and the like).
+//   - writeLocalized is for messages users see (validation, word-list
+//     rejections): with the localized_errors flag on (LOCALIZED_ERRORS=true)
+//     the message is translated for the caller's locale (?lang= or
+//     Accept-Language) via internal/i18n; unknown locales and messages stay
+//     in English. The code is never translated.
+
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/i18n"
+)
+
+// errorRes is the body of every error response.
+type errorRes struct {
+	Error errorBody `json:"error"`
+}
+
+type errorBody struct {
+	Code    string `json:"code"`    // stable, snake_case
+	Message string `json:"message"` // human-readable; may be localized
+}
+
+// writeError writes the JSON error envelope with the given status.
+// Like http.Error it drops any Content-Length and marks the body nosniff.
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", "application/json; charset=utf-8")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorRes{Error: errorBody{Code: code, Message: message}})
+}
+
+// localize translates msg for the caller when localized errors are enabled.
+func (s *Server) localize(w http.ResponseWriter, r *http.Request, msg string) string {
+	if !s.flags.Enabled(featureflags.LocalizedErrors) {
+		return msg
+	}
+	locale := i18n.Locale(r)
+	w.Header().Set("Content-Language", locale)
+	w.Header().Add("Vary", "Accept-Language")
+	return i18n.T(locale, msg)
+}
+
+// writeLocalized writes the error envelope with a (possibly localized) message.
+func (s *Server) writeLocalized(w http.ResponseWriter, r *http.Request, status int, code, msg string) {
+	writeError(w, status, code, s.localize(w, r, msg))
+}
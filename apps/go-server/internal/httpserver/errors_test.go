@@ -0,0 +1,65 @@
+package httpserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestErrorEnvelopeIsUniform(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client()
+	c.signup("clumsy")
+	gameID := c.newGame(nil)
+
+	for _, tc := range []struct {
+		name         string
+		client       *testClient
+		method, path string
+		body         any
+		status       int
+		code         string
+	}{
+		{"unknown route", c, "GET", "/no/such/route", nil, http.StatusNotFound, "not_found"},
+		{"wrong method", c, "DELETE", "/game/new", nil, http.StatusMethodNotAllowed, "method_not_allowed"},
+		{"no session", ts.client(), "GET", "/auth/me", nil, http.StatusUnauthorized, "unauthorized"},
+		{"bad json", c, "POST", "/game/guess", "{", http.StatusBadRequest, ""},
+		{"unknown game", c, "POST", "/game/guess", guessReq{GameID: "missing", Guess: "crane"}, http.StatusNotFound, "not_found"},
+		{"invalid guess", c, "POST", "/game/guess", guessReq{GameID: gameID, Guess: "zz"}, http.StatusBadRequest, ""},
+		{"daily without a session", c, "POST", "/daily/guess", map[string]string{"gameId": "missing", "word": "crane"}, 0, ""},
+	} {
+		status, raw := tc.client.do(tc.method, tc.path, tc.body)
+		if status < 400 || (tc.status != 0 && status != tc.status) {
+			t.Errorf("%s: status %d, want %d", tc.name, status, tc.status)
+			continue
+		}
+		var env struct {
+			Error struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&env); err != nil {
+			t.Errorf("%s: body %s is not the error envelope: %v", tc.name, raw, err)
+			continue
+		}
+		if env.Error.Code == "" || env.Error.Message == "" {
+			t.Errorf("%s: envelope %s is missing its code or message", tc.name, raw)
+		}
+		if tc.code != "" && env.Error.Code != tc.code {
+			t.Errorf("%s: code %q, want %q", tc.name, env.Error.Code, tc.code)
+		}
+	}
+
+	res, err := http.Get(ts.url + "/no/such/route")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if ct := res.Header.Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Fatalf("Content-Type = %q, want JSON", ct)
+	}
+}
@@ -0,0 +1,170 @@
+package httpserver
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/store"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// testServer is a Server on a fresh, migrated SQLite database, served by
+// httptest.
+type testServer struct {
+	*Server
+	t   *testing.T
+	url string
+}
+
+// newTestServer sets env (KEY, value, KEY, value, …) for the test, then
+// builds the server, so env-backed flags and settings take effect. Passwords
+// are hashed at the minimum bcrypt cost unless BCRYPT_COST is given.
+func newTestServer(t *testing.T, env ...string) *testServer {
+	t.Helper()
+	return newTestServerStore(t, nil, env...)
+}
+
+// newTestServerStore is newTestServer with a game store built on the test
+// database (nil means a memory store).
+func newTestServerStore(t *testing.T, mk func(*sql.DB) store.Store, env ...string) *testServer {
+	t.Helper()
+	t.Setenv("BCRYPT_COST", "4")
+	for i := 0; i+1 < len(env); i += 2 {
+		t.Setenv(env[i], env[i+1])
+	}
+	if _, err := words.Init(); err != nil {
+		t.Fatalf("words.Init: %v", err)
+	}
+	db := openTestDB(t)
+	st := store.NewMemoryStore()
+	if mk != nil {
+		st = mk(db)
+	}
+	s := New(st, db)
+	ts := httptest.NewServer(s.Router())
+	t.Cleanup(func() {
+		ts.Close()
+		s.Close()
+	})
+	return &testServer{Server: s, t: t, url: ts.URL}
+}
+
+// openTestDB opens a SQLite database in a temp dir with every ../../sql
+// migration applied in order, as migrate does at start-up.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db")+"?_busy_timeout=5000&_foreign_keys=on")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	files, err := filepath.Glob(filepath.Join("..", "..", "sql", "*.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no migrations found: %v", err)
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(string(b)); err != nil {
+			t.Fatalf("apply %s: %v", f, err)
+		}
+	}
+	return db
+}
+
+// testClient is one browser: its own cookie jar against a testServer.
+type testClient struct {
+	t   *testing.T
+	url string
+	hc  *http.Client
+}
+
+// client returns a new client with an empty cookie jar.
+func (ts *testServer) client() *testClient {
+	jar, _ := cookiejar.New(nil)
+	return &testClient{t: ts.t, url: ts.url, hc: &http.Client{Jar: jar}}
+}
+
+// do sends body (JSON-encoded unless it is nil, a string or []byte) with
+// optional header pairs and returns the status and raw response body.
+func (c *testClient) do(method, path string, body any, hdr ...string) (int, []byte) {
+	c.t.Helper()
+	var rd io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		rd = bytes.NewBufferString(b)
+	case []byte:
+		rd = bytes.NewReader(b)
+	default:
+		buf, err := json.Marshal(b)
+		if err != nil {
+			c.t.Fatal(err)
+		}
+		rd = bytes.NewReader(buf)
+	}
+	req, err := http.NewRequest(method, c.url+path, rd)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	for i := 0; i+1 < len(hdr); i += 2 {
+		req.Header.Set(hdr[i], hdr[i+1])
+	}
+	res, err := c.hc.Do(req)
+	if err != nil {
+		c.t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer res.Body.Close()
+	raw, _ := io.ReadAll(res.Body)
+	return res.StatusCode, raw
+}
+
+// call is do with the response decoded into out (if non-nil).
+func (c *testClient) call(method, path string, body, out any, hdr ...string) int {
+	c.t.Helper()
+	status, raw := c.do(method, path, body, hdr...)
+	if out != nil && len(raw) > 0 {
+		if err := json.Unmarshal(raw, out); err != nil {
+			c.t.Fatalf("%s %s: decoding %q: %v", method, path, raw, err)
+		}
+	}
+	return status
+}
+
+// signup registers username (password "password123") and keeps the session
+// cookie; it returns the new user's ID.
+func (c *testClient) signup(username string) string {
+	c.t.Helper()
+	var res struct {
+		ID string `json:"id"`
+	}
+	if status := c.call("POST", "/auth/signup", map[string]string{"username": username, "password": "password123"}, &res); status != http.StatusOK {
+		c.t.Fatalf("signup %s: status %d", username, status)
+	}
+	return res.ID
+}
+
+// errorCode returns the code of an error envelope, or "".
+func errorCode(raw []byte) string {
+	var env struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	_ = json.Unmarshal(raw, &env)
+	return env.Error.Code
+}
@@ -0,0 +1,135 @@
+// apps/go-server/internal/httpserver/idempotency.go
+//
+// Idempotency keys for POST /game/guess.
+// A client that retries a guess after a dropped response sends the same
+// Idempotency-Key header; the retry gets the original response back
+// (with Idempotent-Replayed: true) instead of spending another row.
+//
+// Notes:
+//   - Keys are scoped per game and remembered for IDEMPOTENCY_TTL (default
+//     10m). Only 2xx responses are remembered; an error frees the key so the
+//     client can retry for real.
+//   - Reusing a key for a different guess is 422 idempotency_key_reused; a
+//     duplicate that arrives while the first is still running is 409
+//     request_in_progress.
+//   - Entries live in process memory (per instance), like the rate limiter,
+//     and expired ones are dropped lazily.
+//   - Requests without the header behave exactly as before.
+
+package httpserver
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+	"time"
+
+	chimw "github.com/go-chi/chi/v5/middleware"
+)
+
+// maxIdempotencyKey bounds the Idempotency-Key header length.
+const maxIdempotencyKey = 255
+
+// idemCache remembers guess responses by game ID + Idempotency-Key.
+type idemCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*idemEntry
+	now     func() time.Time
+}
+
+// idemEntry is one remembered request. done is false while the first
+// request is still being handled.
+type idemEntry struct {
+	guess   string
+	done    bool
+	status  int
+	body    []byte
+	expires time.Time
+}
+
+// newIdemCache builds a cache keeping responses for ttl.
+func newIdemCache(ttl time.Duration) *idemCache {
+	return &idemCache{ttl: ttl, entries: make(map[string]*idemEntry), now: time.Now}
+}
+
+// begin claims key for guess on game gameID. When it returns false the
+// response has already been written (a replay or a conflict) and the caller
+// must stop. Otherwise the caller must call the returned finish with the
+// response it produced.
+func (c *idemCache) begin(w http.ResponseWriter, gameID, key, guess string) (func(status int, body []byte), bool) {
+	if len(key) > maxIdempotencyKey {
+		writeError(w, http.StatusBadRequest, "invalid_idempotency_key", "")
+		return nil, false
+	}
+	k := gameID + "|" + key
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	c.gc(now)
+	if e, ok := c.entries[k]; ok && now.Before(e.expires) {
+		switch {
+		case e.guess != guess:
+			writeError(w, http.StatusUnprocessableEntity, "idempotency_key_reused", "")
+		case !e.done:
+			writeError(w, http.StatusConflict, "request_in_progress", "")
+		default:
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(e.status)
+			_, _ = w.Write(e.body)
+		}
+		return nil, false
+	}
+	e := &idemEntry{guess: guess, expires: now.Add(c.ttl)}
+	c.entries[k] = e
+
+	return func(status int, body []byte) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if status < 200 || status > 299 {
+			delete(c.entries, k)
+			return
+		}
+		e.done, e.status, e.body = true, status, body
+		e.expires = c.now().Add(c.ttl)
+	}, true
+}
+
+// gc drops expired entries. Called with mu held; only scans once the map
+// has grown, to keep begin cheap.
+func (c *idemCache) gc(now time.Time) {
+	if len(c.entries) < 1024 {
+		return
+	}
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// withIdempotency runs handle under the request's Idempotency-Key (if any),
+// replaying a remembered response instead of calling it again.
+func (s *Server) withIdempotency(w http.ResponseWriter, r *http.Request, gameID, guess string, handle func(http.ResponseWriter)) {
+	key := r.Header.Get("Idempotency-Key")
+	if key == "" {
+		handle(w)
+		return
+	}
+	finish, ok := s.idem.begin(w, gameID, key, guess)
+	if !ok {
+		return
+	}
+	var body bytes.Buffer
+	ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+	ww.Tee(&body)
+	defer func() {
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		finish(status, body.Bytes())
+	}()
+	handle(ww)
+}
@@ -0,0 +1,64 @@
+package httpserver
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+func TestGuessIdempotencyKey(t *testing.T) {
+	ts := newTestServer(t, "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("retrier")
+	list := words.AnswersLen(5)
+	id := c.newGame(newGameReq{Answer: list[0]})
+	guess := guessReq{GameID: id, Guess: list[1]}
+
+	guesses := func() int {
+		t.Helper()
+		var detail detailRes
+		if status := c.call("GET", "/games/"+id+"/detail", nil, &detail); status != http.StatusOK {
+			t.Fatalf("detail: status %d", status)
+		}
+		return len(detail.Guesses)
+	}
+
+	s1, first := c.do("POST", "/game/guess", guess, "Idempotency-Key", "k1")
+	s2, replay := c.do("POST", "/game/guess", guess, "Idempotency-Key", "k1")
+	if s1 != http.StatusOK || s2 != http.StatusOK || !bytes.Equal(first, replay) {
+		t.Fatalf("replay: %d %s then %d %s, want the same 200 twice", s1, first, s2, replay)
+	}
+	if n := guesses(); n != 1 {
+		t.Fatalf("after a replayed key: %d guesses, want 1", n)
+	}
+
+	if status, raw := c.do("POST", "/game/guess", guessReq{GameID: id, Guess: list[2]}, "Idempotency-Key", "k1"); status != http.StatusUnprocessableEntity || errorCode(raw) != "idempotency_key_reused" {
+		t.Fatalf("key reused for another word: %d %s, want 422", status, raw)
+	}
+
+	// A failed request frees its key.
+	if status, _ := c.do("POST", "/game/guess", guessReq{GameID: id, Guess: "qzxvj"}, "Idempotency-Key", "k2"); status != http.StatusBadRequest {
+		t.Fatalf("invalid guess: status %d, want 400", status)
+	}
+	if status, _ := c.do("POST", "/game/guess", guessReq{GameID: id, Guess: "qzxvj"}, "Idempotency-Key", "k2"); status != http.StatusBadRequest {
+		t.Fatalf("retried invalid guess: status %d, want 400 again rather than a replay", status)
+	}
+
+	// Keys expire after the TTL, and without a key every request counts.
+	ts.idem.now = func() time.Time { return time.Now().Add(time.Hour) }
+	if status, _ := c.do("POST", "/game/guess", guess, "Idempotency-Key", "k1"); status != http.StatusOK {
+		t.Fatalf("expired key: status %d", status)
+	}
+	c.guess(id, list[1])
+	if n := guesses(); n != 3 {
+		t.Fatalf("after an expired key and a keyless guess: %d guesses, want 3", n)
+	}
+
+	other := c.newGame(newGameReq{Answer: list[0]})
+	if status, res := c.guess(other, list[1], "Idempotency-Key", "k1"); status != http.StatusOK || res.GuessNumber != 1 {
+		t.Fatalf("same key on another game: status %d guess %d, want a fresh guess", status, res.GuessNumber)
+	}
+}
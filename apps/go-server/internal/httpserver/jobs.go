@@ -0,0 +1,200 @@
+// apps/go-server/internal/httpserver/jobs.go
+//
+// Background maintenance jobs for the HTTP server.
+// Responsibilities:
+//   - Run periodic tasks on a ticker bound to the server's lifetime.
+//   - Daily retention: archive/delete old daily_results.
+//   - Daily session prune: drop sessions from past days (cache and table).
+//   - Abandon sweep: mark classic games with no recent activity as 'abandoned'.
+//   - Webhooks: drain the completion webhook queue.
+//   - Link purge: delete expired short links.
+//   - Store sweep: evict expired games from stores that support it (store.Sweeper).
+//
+// Notes:
+//   - Jobs stop when Server.Close cancels the background context.
+//   - Each job is best effort: failures are logged and retried on the next tick.
+
+package httpserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/daily"
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/store"
+	"github.com/robalobadob/wordle/apps/go-server/internal/webhook"
+)
+
+// every runs fn on a fixed interval until the server's background context is cancelled.
+func (s *Server) every(name string, interval time.Duration, fn func(ctx context.Context)) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-s.bg.Done():
+				log.Debug().Str("job", name).Msg("job stopped")
+				return
+			case <-t.C:
+				fn(s.bg)
+			}
+		}
+	}()
+}
+
+// startDailyRetention schedules the daily retention job when DAILY_RETENTION_DAYS > 0.
+//
+// Config:
+//   - DAILY_RETENTION_DAYS      results older than this many days are removed (0 = off)
+//   - DAILY_RETENTION_MODE      "archive" (default) copies to daily_results_archive; "delete" drops
+//   - DAILY_RETENTION_INTERVAL  how often the job runs (default 1h)
+func (d *dailyServer) startDailyRetention() {
+	days := envInt("DAILY_RETENTION_DAYS", 0)
+	if days <= 0 {
+		return
+	}
+	archive := getEnv("DAILY_RETENTION_MODE", "archive") != "delete"
+	d.srv.every("daily_retention", envDuration("DAILY_RETENTION_INTERVAL", time.Hour), func(ctx context.Context) {
+		d.runRetention(ctx, time.Now().UTC(), days, archive)
+	})
+}
+
+// runRetention removes results older than the window.
+func (d *dailyServer) runRetention(ctx context.Context, now time.Time, days int, archive bool) {
+	cutoff := daily.DateKey(now.AddDate(0, 0, -days), d.loc)
+	n, err := d.store.ArchiveBefore(ctx, cutoff, archive)
+	if err != nil {
+		log.Warn().Err(err).Str("cutoff", cutoff).Msg("daily retention")
+		return
+	}
+	if n > 0 {
+		log.Info().Int64("results", n).Str("cutoff", cutoff).Msg("daily retention")
+	}
+}
+
+// startSessionPrune schedules removal of daily sessions from past days, which
+// can no longer be played, so the session cache doesn't grow by one entry per
+// player per day.
+//
+// Config:
+//   - DAILY_SESSION_PRUNE_INTERVAL  how often the job runs (default 15m; 0 = off)
+func (d *dailyServer) startSessionPrune() {
+	d.srv.every("daily_session_prune", envDuration("DAILY_SESSION_PRUNE_INTERVAL", 15*time.Minute), func(ctx context.Context) {
+		d.runSessionPrune(ctx, time.Now().UTC())
+	})
+}
+
+// runSessionPrune drops cached and stored sessions dated before now's day.
+func (d *dailyServer) runSessionPrune(ctx context.Context, now time.Time) {
+	today := daily.DateKey(now, d.loc)
+	cached := d.pruneSessions(today)
+	stored, err := d.store.DeleteSessionsBefore(ctx, today)
+	if err != nil {
+		log.Warn().Err(err).Msg("daily session prune")
+	}
+	if cached > 0 || stored > 0 {
+		log.Info().Int("cached", cached).Int64("stored", stored).Msg("daily session prune")
+	}
+}
+
+// startAbandonSweep schedules the abandoned-game sweep when GAME_ABANDON_AFTER > 0.
+//
+// Config:
+//   - GAME_ABANDON_AFTER     playing games idle this long become 'abandoned' (0 = off)
+//   - GAME_ABANDON_INTERVAL  how often the sweep runs (default 1h)
+func (s *Server) startAbandonSweep() {
+	after := envDuration("GAME_ABANDON_AFTER", 0)
+	if after <= 0 {
+		return
+	}
+	s.every("game_abandon", envDuration("GAME_ABANDON_INTERVAL", time.Hour), func(ctx context.Context) {
+		n, err := s.markAbandoned(ctx, time.Now().UTC().Add(-after))
+		if err != nil {
+			log.Warn().Err(err).Msg("abandon sweep")
+			return
+		}
+		if n > 0 {
+			log.Info().Int64("games", n).Msg("abandon sweep")
+		}
+	})
+}
+
+// markAbandoned flags 'playing' games whose last activity (or start, if no
+// guess was made) is before cutoff. Returns the number of games updated.
+func (s *Server) markAbandoned(ctx context.Context, cutoff time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE games SET status='abandoned'
+		  WHERE status='playing' AND COALESCE(last_activity, started_at) < ?`,
+		cutoff.Format(time.RFC3339))
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// startWebhooks sets up completion webhooks when WEBHOOK_URL is set.
+//
+// Config:
+//   - WEBHOOK_URL     endpoint receiving POSTed events (unset = webhooks off)
+//   - WEBHOOK_SECRET  HMAC key for the X-Wordle-Signature header
+//   - WEBHOOK_QUEUE   max queued events before new ones are dropped (default 256)
+//   - webhooks flag (WEBHOOK_ENABLED=true) must also be on for events to be sent
+func (s *Server) startWebhooks() {
+	url := getEnv("WEBHOOK_URL", "")
+	if url == "" {
+		s.hooks = webhook.Nop{}
+		return
+	}
+	d := webhook.NewDispatcher(url, getEnv("WEBHOOK_SECRET", ""), envInt("WEBHOOK_QUEUE", 256))
+	s.hooks = d
+	go d.Run(s.bg)
+}
+
+// notify forwards a completion event to the webhook notifier when enabled.
+func (s *Server) notify(e webhook.Event) {
+	if s.hooks == nil || !s.flags.Enabled(featureflags.Webhooks) {
+		return
+	}
+	s.hooks.Notify(e)
+}
+
+// startLinkPurge schedules deletion of expired short links.
+//
+// Config:
+//   - SHORT_LINK_PURGE_INTERVAL  how often the purge runs (default 1h; 0 = off)
+func (s *Server) startLinkPurge() {
+	s.every("short_link_purge", envDuration("SHORT_LINK_PURGE_INTERVAL", time.Hour), func(ctx context.Context) {
+		res, err := s.db.ExecContext(ctx, `DELETE FROM short_links WHERE expires_at <= ?`,
+			time.Now().UTC().Format(time.RFC3339))
+		if err != nil {
+			log.Warn().Err(err).Msg("short link purge")
+			return
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			log.Info().Int64("links", n).Msg("short link purge")
+		}
+	})
+}
+
+// startStoreSweep schedules eviction of expired games when the game store
+// supports it (the memory store; see store.NewMemoryStoreTTL).
+//
+// Config:
+//   - GAME_STORE_SWEEP_INTERVAL  how often the sweep runs (default 5m; 0 = off)
+func (s *Server) startStoreSweep() {
+	sw, ok := s.store.(store.Sweeper)
+	if !ok {
+		return
+	}
+	s.every("store_sweep", envDuration("GAME_STORE_SWEEP_INTERVAL", 5*time.Minute), func(ctx context.Context) {
+		if n := sw.Sweep(ctx); n > 0 {
+			log.Info().Int("games", n).Msg("store sweep")
+		}
+	})
+}
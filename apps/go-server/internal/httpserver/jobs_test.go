@@ -0,0 +1,194 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/daily"
+	"github.com/robalobadob/wordle/apps/go-server/internal/webhook"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// testDaily is a dailyServer on ts's database with only what the jobs use.
+func (ts *testServer) testDaily() *dailyServer {
+	return &dailyServer{
+		srv:      ts.Server,
+		store:    daily.NewStore(ts.db, time.UTC),
+		loc:      time.UTC,
+		sessions: make(map[string]*dailySession),
+	}
+}
+
+func (ts *testServer) countRows(table string) int {
+	ts.t.Helper()
+	var n int
+	if err := ts.db.QueryRow("SELECT COUNT(1) FROM " + table).Scan(&n); err != nil {
+		ts.t.Fatalf("count %s: %v", table, err)
+	}
+	return n
+}
+
+func TestRetentionKeepsRecentResults(t *testing.T) {
+	for _, archive := range []bool{true, false} {
+		ts := newTestServer(t)
+		d := ts.testDaily()
+		now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+		for _, date := range []string{"2025-05-01", "2025-05-30", "2025-05-31", "2025-06-30"} {
+			ts.insertDaily(daily.Result{UserID: "u1", Date: date, Guesses: 3, Won: true})
+		}
+
+		d.runRetention(context.Background(), now, 30, archive)
+
+		var kept []string
+		rows, err := ts.db.Query("SELECT date FROM daily_results ORDER BY date")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for rows.Next() {
+			var date string
+			if err := rows.Scan(&date); err != nil {
+				t.Fatal(err)
+			}
+			kept = append(kept, date)
+		}
+		rows.Close()
+		if len(kept) != 2 || kept[0] != "2025-05-31" || kept[1] != "2025-06-30" {
+			t.Fatalf("archive=%v: kept %v, want [2025-05-31 2025-06-30]", archive, kept)
+		}
+		wantArchived := 0
+		if archive {
+			wantArchived = 2
+		}
+		if got := ts.countRows("daily_results_archive"); got != wantArchived {
+			t.Fatalf("archive=%v: %d archived rows, want %d", archive, got, wantArchived)
+		}
+		lb, err := d.store.Leaderboard(context.Background(), "2025-05-31", "", 10)
+		if err != nil || len(lb) != 1 {
+			t.Fatalf("archive=%v: leaderboard for a kept date = %v, %v", archive, lb, err)
+		}
+	}
+}
+
+func TestSessionPruneDropsPastDays(t *testing.T) {
+	ts := newTestServer(t)
+	d := ts.testDaily()
+	d.sessions["u1|2025-06-29"] = &dailySession{UserID: "u1", Date: "2025-06-29"}
+	d.sessions["u1|2025-06-30"] = &dailySession{UserID: "u1", Date: "2025-06-30"}
+	for k, sess := range d.sessions {
+		sess.GameID = k
+		if err := d.saveSession(context.Background(), sess); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d.runSessionPrune(context.Background(), time.Date(2025, 6, 30, 0, 5, 0, 0, time.UTC))
+
+	if _, ok := d.sessions["u1|2025-06-29"]; ok {
+		t.Fatal("yesterday's session survived the prune")
+	}
+	if _, ok := d.sessions["u1|2025-06-30"]; !ok {
+		t.Fatal("today's session was pruned")
+	}
+	var date string
+	if err := ts.db.QueryRow("SELECT date FROM daily_sessions").Scan(&date); err != nil || ts.countRows("daily_sessions") != 1 || date != "2025-06-30" {
+		t.Fatalf("stored sessions after the prune: %d rows, first %q, %v; want only 2025-06-30", ts.countRows("daily_sessions"), date, err)
+	}
+}
+
+func TestAbandonSweep(t *testing.T) {
+	ts := newTestServer(t, "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("wanderer")
+	list := words.AnswersLen(5)
+	stale := c.newGame(newGameReq{Answer: list[0]})
+	fresh := c.newGame(newGameReq{Answer: list[0]})
+	done := c.newGame(newGameReq{Answer: list[0]})
+	c.guess(done, list[0])
+
+	old := time.Now().UTC().Add(-2 * time.Hour).Format(time.RFC3339)
+	if _, err := ts.db.Exec(`UPDATE games SET started_at=?, last_activity=? WHERE id IN (?,?)`, old, old, stale, done); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := ts.markAbandoned(context.Background(), time.Now().UTC().Add(-time.Hour))
+	if err != nil || n != 1 {
+		t.Fatalf("markAbandoned = %d, %v; want 1", n, err)
+	}
+	status := func(id string) string {
+		var s string
+		if err := ts.db.QueryRow(`SELECT status FROM games WHERE id=?`, id).Scan(&s); err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	for id, want := range map[string]string{stale: "abandoned", fresh: "playing", done: "won"} {
+		if got := status(id); got != want {
+			t.Errorf("game %s status = %q, want %q", id, got, want)
+		}
+	}
+
+	c.guess(stale, list[1])
+	if got := status(stale); got != "playing" {
+		t.Fatalf("status after a guess on an abandoned game = %q, want playing", got)
+	}
+}
+
+func TestCompletionWebhook(t *testing.T) {
+	got := make(chan webhook.Event, 1)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(webhook.SignatureHeader) != "sha256="+webhook.Sign("hook-secret", body) {
+			t.Errorf("bad signature %q", r.Header.Get(webhook.SignatureHeader))
+		}
+		var e webhook.Event
+		_ = json.Unmarshal(body, &e)
+		got <- e
+	}))
+	defer hook.Close()
+
+	ts := newTestServer(t, "WEBHOOK_URL", hook.URL, "WEBHOOK_SECRET", "hook-secret", "WEBHOOK_ENABLED", "true", "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	uid := c.signup("hooked")
+	answer := words.AnswersLen(5)[0]
+	id := c.newGame(newGameReq{Answer: answer})
+	c.guess(id, answer)
+
+	select {
+	case e := <-got:
+		if e.Type != webhook.GameCompleted || e.GameID != id || e.UserID != uid || e.Result != "won" || e.Guesses != 1 {
+			t.Fatalf("event = %+v", e)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no webhook for a finished game")
+	}
+}
+
+func TestFailingWebhookDoesNotBlockPlay(t *testing.T) {
+	release := make(chan struct{})
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer hook.Close()
+	defer close(release)
+
+	ts := newTestServer(t, "WEBHOOK_URL", hook.URL, "WEBHOOK_ENABLED", "true", "WEBHOOK_QUEUE", "1", "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("impatient")
+	answer := words.AnswersLen(5)[0]
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		id := c.newGame(newGameReq{Answer: answer})
+		if status, res := c.guess(id, answer); status != http.StatusOK || res.State != "won" {
+			t.Fatalf("game %d: status %d state %q", i, status, res.State)
+		}
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Fatalf("five games took %v behind a hung webhook", d)
+	}
+}
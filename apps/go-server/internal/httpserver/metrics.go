@@ -0,0 +1,74 @@
+// apps/go-server/internal/httpserver/metrics.go
+//
+// Prometheus metrics (internal/metrics), opt-in with the metrics flag
+// (METRICS_ENABLED=true).
+//   - withMetrics counts every request by method, matched route pattern, and
+//     status, and records its latency. Requests that match no route are
+//     labelled "unmatched". While the flag is off it only checks the flag.
+//   - GET /metrics serves the text exposition format; 404 while off. It is
+//     unauthenticated, so keep it off the public listener (or filter it at
+//     the proxy) in production.
+//   - Gauges: wordle_memory_games{state} (memory store only) and
+//     wordle_daily_sessions{state} (daily sessions cached in memory).
+
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/metrics"
+	"github.com/robalobadob/wordle/apps/go-server/internal/store"
+)
+
+// withMetrics records request metrics while the metrics flag is on.
+func (s *Server) withMetrics() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !s.flags.Enabled(featureflags.Metrics) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			start := time.Now()
+			ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+			defer func() {
+				route := "unmatched"
+				if rc := chi.RouteContext(r.Context()); rc != nil && rc.RoutePattern() != "" {
+					route = rc.RoutePattern()
+				}
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK // handler wrote nothing
+				}
+				s.metrics.ObserveRequest(r.Method, route, status, time.Since(start))
+			}()
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
+
+// mountMetrics registers GET /metrics and the store gauge.
+func (s *Server) mountMetrics() {
+	if c, ok := s.store.(store.Counter); ok {
+		s.metrics.GaugeFunc("wordle_memory_games", "Games held by the in-memory store, by state.", func() []metrics.Sample {
+			total, playing := c.Count(context.Background())
+			return []metrics.Sample{
+				{Labels: map[string]string{"state": "playing"}, Value: float64(playing)},
+				{Labels: map[string]string{"state": "finished"}, Value: float64(total - playing)},
+			}
+		})
+	}
+	s.r.Get("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		if !s.flags.Enabled(featureflags.Metrics) {
+			writeError(w, http.StatusNotFound, "not_found", "no route for "+r.URL.Path)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = s.metrics.WriteText(w)
+	})
+}
@@ -0,0 +1,45 @@
+package httpserver
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+)
+
+func TestMetricsScrape(t *testing.T) {
+	ts := newTestServer(t, "METRICS_ENABLED", "true")
+	c := ts.client()
+	c.signup("counted")
+	c.newGame(nil)
+	c.newGame(nil)
+	c.do("GET", "/no/such/route", nil)
+
+	status, raw := c.do("GET", "/metrics", nil)
+	if status != http.StatusOK {
+		t.Fatalf("/metrics: status %d", status)
+	}
+	out := string(raw)
+	for _, want := range []string{
+		`http_requests_total{method="POST",route="/game/new",status="200"} 2`,
+		`http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`http_request_duration_seconds_count{method="POST",route="/game/new"} 2`,
+		`wordle_memory_games{state="playing"} 2`,
+		"# TYPE wordle_daily_sessions gauge",
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("scrape is missing %q:\n%s", want, out)
+		}
+	}
+
+	c.newGame(nil)
+	if _, raw := c.do("GET", "/metrics", nil); !strings.Contains(string(raw), `route="/game/new",status="200"} 3`) {
+		t.Errorf("counter didn't move after another request:\n%s", raw)
+	}
+
+	_ = ts.flags.Set(featureflags.Metrics, false)
+	if status, _ := c.do("GET", "/metrics", nil); status != http.StatusNotFound {
+		t.Fatalf("flag off: status %d, want 404", status)
+	}
+}
@@ -0,0 +1,210 @@
+// apps/go-server/internal/httpserver/ratelimit.go
+//
+// In-memory token-bucket rate limiting.
+// Responsibilities:
+//   - limiter: concurrency-safe map of token buckets keyed by an arbitrary string.
+//   - withUserRateLimit: per-user throttling for gameplay routes, keyed by the
+//     authenticated user ID (falling back to the anon cookie, then client IP).
+//   - withAuthRateLimit: brute-force throttling for /auth/login and
+//     /auth/signup, keyed by client IP and separately by the submitted
+//     username/email, so neither many accounts from one IP nor one account
+//     from many IPs gets unlimited attempts.
+//
+// Config (per-user limiter):
+//   - USER_RATE_PER_MIN  sustained requests per minute per user (0 = disabled; default 120)
+//   - USER_RATE_BURST    bucket size, i.e. short bursts allowed (default 30)
+//
+// Config (auth limiters):
+//   - AUTH_RATE_PER_MIN       attempts per minute per IP (0 = disabled; default 10)
+//   - AUTH_RATE_BURST         per-IP burst (default 5)
+//   - AUTH_NAME_RATE_PER_MIN  attempts per minute per username/email (0 = disabled; default 5)
+//   - AUTH_NAME_RATE_BURST    per-name burst (default 5)
+//
+// Notes:
+//   - Buckets live in process memory; limits are per instance.
+//   - Idle buckets are dropped lazily once they are full again.
+
+package httpserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// limiter is a keyed set of token buckets refilling at `rate` tokens/second up to `burst`.
+type limiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// bucket holds the token balance for a single key.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newLimiter builds a limiter allowing perMin requests/minute with the given burst.
+func newLimiter(perMin, burst int) *limiter {
+	if burst <= 0 {
+		burst = 1
+	}
+	return &limiter{
+		rate:    float64(perMin) / 60,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// allow consumes one token for key. When empty it reports false and how long
+// until the next token is available.
+func (l *limiter) allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		l.gc(now)
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+// gc drops buckets that have fully refilled (they carry no state worth keeping).
+// Called with mu held; only scans occasionally to keep allow() cheap.
+func (l *limiter) gc(now time.Time) {
+	if len(l.buckets) < 1024 {
+		return
+	}
+	for k, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+// tooManyRequests writes a 429 with a Retry-After header (whole seconds, min 1).
+func tooManyRequests(w http.ResponseWriter, wait time.Duration) {
+	secs := int(math.Ceil(wait.Seconds()))
+	if secs < 1 {
+		secs = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(secs))
+	writeError(w, http.StatusTooManyRequests, "rate_limited", "")
+}
+
+// withUserRateLimit throttles requests per user. Must run after the auth
+// middleware so the user ID is available in the request context.
+func (s *Server) withUserRateLimit() func(http.Handler) http.Handler {
+	perMin := envInt("USER_RATE_PER_MIN", 120)
+	if perMin <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	lim := newLimiter(perMin, envInt("USER_RATE_BURST", 30))
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ok, wait := lim.allow(rateKey(r)); !ok {
+				tooManyRequests(w, wait)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// maxAuthBody bounds how much of an auth request body is buffered to read the
+// username; the handlers themselves only ever see what was buffered.
+const maxAuthBody = 64 << 10
+
+// withAuthRateLimit throttles credential attempts per client IP and per
+// submitted username/email. The body is buffered and restored for the handler.
+func (s *Server) withAuthRateLimit() func(http.Handler) http.Handler {
+	var byIP, byName *limiter
+	if n := envInt("AUTH_RATE_PER_MIN", 10); n > 0 {
+		byIP = newLimiter(n, envInt("AUTH_RATE_BURST", 5))
+	}
+	if n := envInt("AUTH_NAME_RATE_PER_MIN", 5); n > 0 {
+		byName = newLimiter(n, envInt("AUTH_NAME_RATE_BURST", 5))
+	}
+	return func(next http.Handler) http.Handler {
+		if byIP == nil && byName == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if byIP != nil {
+				if ok, wait := byIP.allow(clientIP(r)); !ok {
+					tooManyRequests(w, wait)
+					return
+				}
+			}
+			if byName != nil {
+				body, _ := io.ReadAll(io.LimitReader(r.Body, maxAuthBody))
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				if name := authName(body); name != "" {
+					if ok, wait := byName.allow(name); !ok {
+						tooManyRequests(w, wait)
+						return
+					}
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// authName extracts the lowercased account identifier from a login/signup
+// body (username, else email). "" if the body isn't JSON or has neither.
+func authName(body []byte) string {
+	var p struct{ Username, Email string }
+	if json.Unmarshal(body, &p) != nil {
+		return ""
+	}
+	if name := strings.ToLower(strings.TrimSpace(p.Username)); name != "" {
+		return "u:" + name
+	}
+	if email := strings.ToLower(strings.TrimSpace(p.Email)); email != "" {
+		return "e:" + email
+	}
+	return ""
+}
+
+// rateKey identifies the caller: user ID, else anon cookie, else client IP.
+func rateKey(r *http.Request) string {
+	if me, _ := r.Context().Value(ctxUserKey{}).(*authUser); me != nil {
+		return "u:" + me.ID
+	}
+	if c, err := r.Cookie(anonCookieName); err == nil && c.Value != "" {
+		return "a:" + c.Value
+	}
+	return "ip:" + clientIP(r)
+}
+
+// clientIP returns the caller's IP from RemoteAddr (already rewritten by
+// chi's RealIP middleware when proxy headers are present), without the port.
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
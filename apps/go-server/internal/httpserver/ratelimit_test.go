@@ -0,0 +1,98 @@
+package httpserver
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLimiterRefills(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newLimiter(60, 2) // one token a second, two at once
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.allow("u"); !ok {
+			t.Fatalf("request %d within the burst was refused", i+1)
+		}
+	}
+	ok, wait := l.allow("u")
+	if ok || wait <= 0 || wait > time.Second {
+		t.Fatalf("over the burst: ok=%v wait=%v, want refused with a wait of at most 1s", ok, wait)
+	}
+	if ok, _ := l.allow("other"); !ok {
+		t.Fatal("another key shares the exhausted bucket")
+	}
+	now = now.Add(time.Second)
+	if ok, _ := l.allow("u"); !ok {
+		t.Fatal("no token after a second of refill")
+	}
+}
+
+func TestUserRateLimit(t *testing.T) {
+	ts := newTestServer(t, "USER_RATE_PER_MIN", "1", "USER_RATE_BURST", "2")
+	busy := ts.client()
+	busy.signup("scripter")
+	for i := 0; i < 2; i++ {
+		busy.newGame(nil)
+	}
+	req, _ := http.NewRequest("POST", ts.url+"/game/new", nil)
+	resp, err := busy.hc.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" {
+		t.Fatalf("third game: status %d Retry-After %q, want 429 with a Retry-After", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+
+	other := ts.client()
+	other.signup("bystander")
+	other.newGame(nil)
+}
+
+// login posts a login for username with a wrong password and returns the
+// status and Retry-After header.
+func (c *testClient) login(username string) (int, string) {
+	c.t.Helper()
+	req, _ := http.NewRequest("POST", c.url+"/auth/login", strings.NewReader(`{"username":"`+username+`","password":"wrong-password"}`))
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode, resp.Header.Get("Retry-After")
+}
+
+func TestAuthRateLimitPerUsername(t *testing.T) {
+	ts := newTestServer(t, "AUTH_RATE_PER_MIN", "1000", "AUTH_RATE_BURST", "1000", "AUTH_NAME_RATE_PER_MIN", "1", "AUTH_NAME_RATE_BURST", "3")
+	c := ts.client()
+	for i := 0; i < 3; i++ {
+		if status, _ := c.login("victim"); status != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: status %d, want 401", i+1, status)
+		}
+	}
+	if status, retry := c.login("VICTIM"); status != http.StatusTooManyRequests || retry == "" {
+		t.Fatalf("attempt 4: status %d Retry-After %q, want 429 with a Retry-After", status, retry)
+	}
+	if status, _ := c.login("someone_else"); status != http.StatusUnauthorized {
+		t.Fatalf("another username: status %d, want 401", status)
+	}
+}
+
+func TestAuthRateLimitPerIP(t *testing.T) {
+	ts := newTestServer(t, "AUTH_RATE_PER_MIN", "1", "AUTH_RATE_BURST", "2", "AUTH_NAME_RATE_PER_MIN", "0")
+	c := ts.client()
+	for i, name := range []string{"one", "two"} {
+		if status, _ := c.login(name); status != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: status %d, want 401", i+1, status)
+		}
+	}
+	if status, retry := c.login("three"); status != http.StatusTooManyRequests || retry == "" {
+		t.Fatalf("attempt 3: status %d Retry-After %q, want 429 with a Retry-After", status, retry)
+	}
+	if status, _ := ts.client().do("POST", "/auth/signup", map[string]string{"username": "fresh", "password": "password123"}); status != http.StatusTooManyRequests {
+		t.Fatalf("signup from the same IP: status %d, want 429", status)
+	}
+}
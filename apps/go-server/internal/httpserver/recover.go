@@ -0,0 +1,92 @@
+// apps/go-server/internal/httpserver/recover.go
+//
+// Startup recovery of active classic games into the memory store.
+//
+// With the game_recovery flag (GAME_STORE_RECOVER=true), POST /game/new keeps
+// each new game's canonical JSON in games.setup. On startup RecoverGames
+// reloads every game still 'playing' from that snapshot and replays its
+// game_guesses rows, so players can carry on after a restart.
+//
+// Notes:
+//   - Only useful with the memory store; main calls it for GAME_STORE=memory
+//     (the SQL store is durable on its own).
+//   - Games started with recovery off have no snapshot and are skipped, as
+//     are games whose replay fails (e.g. a guess has since been blocked).
+//   - A recovered game that the memory store's TTLs would already have
+//     evicted is dropped by the next sweep.
+
+package httpserver
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+)
+
+// RecoverGames rebuilds unfinished games from the database into the game
+// store and returns how many were restored. A no-op unless game_recovery is on.
+func (s *Server) RecoverGames(ctx context.Context) (int, error) {
+	if !s.flags.Enabled(featureflags.GameRecovery) {
+		return 0, nil
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, setup FROM games WHERE status='playing' AND setup IS NOT NULL`)
+	if err != nil {
+		return 0, err
+	}
+	type pending struct{ id, setup string }
+	var list []pending
+	for rows.Next() {
+		var p pending
+		if err := rows.Scan(&p.id, &p.setup); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		list = append(list, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, p := range list {
+		g, err := s.replayGame(ctx, p.id, p.setup)
+		if err != nil {
+			log.Warn().Err(err).Str("gameId", p.id).Msg("recover game")
+			continue
+		}
+		if err := s.store.Save(ctx, g); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+// replayGame decodes a game's setup snapshot and applies its stored guesses in order.
+func (s *Server) replayGame(ctx context.Context, id, setup string) (*game.Game, error) {
+	g, err := game.Unmarshal([]byte(setup))
+	if err != nil {
+		return nil, err
+	}
+	g.ID = id
+	rows, err := s.db.QueryContext(ctx, `SELECT guess FROM game_guesses WHERE game_id=? ORDER BY seq`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var guess string
+		if err := rows.Scan(&guess); err != nil {
+			return nil, err
+		}
+		if _, _, err := g.ApplyGuess(guess); err != nil {
+			return nil, err
+		}
+	}
+	return g, rows.Err()
+}
@@ -0,0 +1,44 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+func TestRecoverGames(t *testing.T) {
+	ts := newTestServer(t, "GAME_STORE_RECOVER", "true", "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("survivor")
+	list := words.AnswersLen(5)
+
+	active := c.newGame(newGameReq{Answer: list[0]})
+	c.guess(active, list[1])
+	finished := c.newGame(newGameReq{Answer: list[0]})
+	c.guess(finished, list[0])
+	_ = ts.flags.Set(featureflags.GameRecovery, false)
+	unsnapshotted := c.newGame(newGameReq{Answer: list[0]})
+	_ = ts.flags.Set(featureflags.GameRecovery, true)
+
+	r := ts.restart()
+	n, err := r.RecoverGames(context.Background())
+	if err != nil || n != 1 {
+		t.Fatalf("RecoverGames = %d, %v; want 1", n, err)
+	}
+	c.url = r.url
+	if status, _ := c.guess(unsnapshotted, list[1]); status != http.StatusNotFound {
+		t.Fatalf("guess on a game without a snapshot: status %d, want 404", status)
+	}
+	status, res := c.guess(active, list[0])
+	if status != http.StatusOK || res.State != "won" || res.GuessNumber != 2 {
+		t.Fatalf("guess on the recovered game: status %d, %+v; want a win on guess 2", status, res)
+	}
+
+	_ = r.flags.Set(featureflags.GameRecovery, false)
+	if n, err := r.RecoverGames(context.Background()); err != nil || n != 0 {
+		t.Fatalf("RecoverGames with the flag off = %d, %v; want 0", n, err)
+	}
+}
@@ -0,0 +1,93 @@
+// apps/go-server/internal/httpserver/routes_account.go
+//
+// Account deletion.
+//   - DELETE /auth/me {"password": "..."} → permanently delete the caller's
+//     account and the data tied to it, then clear the auth cookie
+//
+// Notes:
+//   - The password is re-checked even though the caller is logged in, so a
+//     stolen session cookie alone can't destroy an account.
+//   - Everything is removed in one transaction: the users row, classic games
+//     (with their guesses and stored game_state), daily results (live and
+//     archived), streak freezes, challenge entries, and short links the user
+//     created.
+//   - Guest (anon cookie) data is not touched; it isn't linked to the account.
+
+package httpserver
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// deleteAccountReq is the payload for DELETE /auth/me.
+type deleteAccountReq struct {
+	Password string `json:"password"`
+}
+
+// accountTables lists the per-user cleanup, in order (children before parents).
+var accountTables = []string{
+	`DELETE FROM game_guesses WHERE game_id IN (SELECT id FROM games WHERE user_id=?)`,
+	`DELETE FROM game_state WHERE id IN (SELECT id FROM games WHERE user_id=?)`,
+	`DELETE FROM games WHERE user_id=?`,
+	`DELETE FROM daily_results WHERE user_id=?`,
+	`DELETE FROM daily_results_archive WHERE user_id=?`,
+	`DELETE FROM daily_sessions WHERE user_id=?`,
+	`DELETE FROM daily_streak_freezes WHERE user_id=?`,
+	`DELETE FROM challenge_results WHERE user_id=?`,
+	`DELETE FROM short_links WHERE created_by=?`,
+	`DELETE FROM users WHERE id=?`,
+}
+
+// handleDeleteAccount verifies the password and deletes the caller's account.
+func (s *Server) handleDeleteAccount(w http.ResponseWriter, r *http.Request) {
+	me, _ := r.Context().Value(ctxUserKey{}).(*authUser)
+	if me == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
+		return
+	}
+	var body deleteAccountReq
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_json", "")
+		return
+	}
+	u, err := s.findUserByID(me.ID)
+	if err == sql.ErrNoRows {
+		s.clearAuthCookie(w)
+		writeError(w, http.StatusNotFound, "not_found", "")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	if !checkPassword(u.PasswordHash, body.Password) {
+		s.writeLocalized(w, r, http.StatusUnauthorized, "invalid_password", "Invalid password")
+		return
+	}
+
+	tx, err := s.db.BeginTx(r.Context(), nil)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	defer func() { _ = tx.Rollback() }()
+	for _, q := range accountTables {
+		if _, err := tx.Exec(q, me.ID); err != nil {
+			log.Error().Err(err).Str("user", me.ID).Msg("delete account")
+			writeError(w, http.StatusInternalServerError, "db_error", "")
+			return
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+
+	s.clearAuthCookie(w)
+	log.Info().Str("user", me.ID).Msg("account deleted")
+	_ = json.NewEncoder(w).Encode(map[string]bool{"ok": true})
+}
@@ -0,0 +1,80 @@
+package httpserver
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/daily"
+	"github.com/robalobadob/wordle/apps/go-server/internal/store"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+func TestDeleteAccount(t *testing.T) {
+	ts := newTestServerStore(t, store.NewSQLStore)
+	list := words.AnswersLen(5)
+	// seed gives a user a classic game with a guess, a daily result, and a
+	// daily session, and returns the IDs needed to look them up.
+	seed := func(name string) (c *testClient, uid, gameID string) {
+		c = ts.client()
+		uid = c.signup(name)
+		gameID = c.newGame(nil)
+		c.guess(gameID, list[1])
+		ts.insertDaily(daily.Result{UserID: uid, Date: "2025-01-01", Guesses: 3, ElapsedMs: 1000, Won: true})
+		c.startDaily(ts)
+		return c, uid, gameID
+	}
+	c, uid, gameID := seed("leaver")
+	_, otherUID, otherGame := seed("stayer")
+
+	// owned counts the rows tied to a user (or their game) in each table.
+	owned := func(uid, gameID string) map[string]int {
+		out := map[string]int{}
+		for table, q := range map[string]string{
+			"users":          `SELECT COUNT(*) FROM users WHERE id=?`,
+			"games":          `SELECT COUNT(*) FROM games WHERE user_id=?`,
+			"daily_results":  `SELECT COUNT(*) FROM daily_results WHERE user_id=?`,
+			"daily_sessions": `SELECT COUNT(*) FROM daily_sessions WHERE user_id=?`,
+			"game_state":     `SELECT COUNT(*) FROM game_state WHERE id=?`,
+			"game_guesses":   `SELECT COUNT(*) FROM game_guesses WHERE game_id=?`,
+		} {
+			arg := uid
+			if table == "game_state" || table == "game_guesses" {
+				arg = gameID
+			}
+			var n int
+			if err := ts.db.QueryRow(q, arg).Scan(&n); err != nil {
+				t.Fatalf("%s: %v", table, err)
+			}
+			out[table] = n
+		}
+		return out
+	}
+	for table, n := range owned(uid, gameID) {
+		if n == 0 {
+			t.Fatalf("seed left %s empty", table)
+		}
+	}
+
+	if status, _ := c.do("DELETE", "/auth/me", deleteAccountReq{Password: "wrong-password"}); status != http.StatusUnauthorized {
+		t.Fatalf("wrong password: status %d, want 401", status)
+	}
+	if n := owned(uid, gameID)["users"]; n != 1 {
+		t.Fatal("account deleted despite a wrong password")
+	}
+	if status, raw := c.do("DELETE", "/auth/me", deleteAccountReq{Password: "password123"}); status != http.StatusOK {
+		t.Fatalf("delete: status %d %s", status, raw)
+	}
+	for table, n := range owned(uid, gameID) {
+		if n != 0 {
+			t.Errorf("%s still has %d rows for the deleted user", table, n)
+		}
+	}
+	for table, n := range owned(otherUID, otherGame) {
+		if n == 0 {
+			t.Errorf("%s lost the other user's rows", table)
+		}
+	}
+	if status, _ := c.do("GET", "/auth/me", nil); status != http.StatusUnauthorized {
+		t.Fatalf("after delete: /auth/me status %d, want 401", status)
+	}
+}
@@ -0,0 +1,220 @@
+// apps/go-server/internal/httpserver/routes_admin.go
+//
+// Operator-only endpoints mounted under /admin.
+//   - GET  /admin/blocklist → list words currently blocked as guesses
+//   - POST /admin/blocklist → block/unblock a guess at runtime
+//   - GET  /admin/flags     → list feature flags and their current values
+//   - POST /admin/flags     → override a flag at runtime (enabled: null resets it)
+//   - POST /admin/import    → bulk import users/games/daily results (routes_import.go)
+//   - POST /admin/words/reload → re-read the classic word lists without a restart
+//     (words.Reload; the embedded daily lists are unaffected)
+//   - GET  /admin/users/{id}/stats → /stats/me for any user, plus createdAt
+//     and lastActive
+//
+// Access to the operator routes is gated by requireAdminToken: callers must
+// send X-Admin-Token matching the ADMIN_TOKEN env var. When ADMIN_TOKEN is
+// unset every such call is 403. User lookups are gated by requireAdmin
+// instead: a signed-in account with users.is_admin set (401 without a
+// session, 403 for other accounts).
+
+package httpserver
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// mountAdmin registers /admin routes behind requireAdminToken (operator
+// routes) or requireAdmin (user lookups).
+func (s *Server) mountAdmin() {
+	s.r.Route("/admin", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(s.requireAdminToken())
+			r.Get("/blocklist", s.handleGetBlocklist)
+			r.Post("/blocklist", s.handleSetBlocklist)
+			r.Get("/flags", s.handleGetFlags)
+			r.Post("/flags", s.handleSetFlag)
+			r.Post("/import", s.handleImport)
+			r.Post("/words/reload", s.handleReloadWords)
+		})
+		r.With(s.requireAdmin()).Get("/users/{id}/stats", s.handleAdminUserStats)
+	})
+}
+
+// requireAdminToken enforces the shared admin token (X-Admin-Token == ADMIN_TOKEN).
+func (s *Server) requireAdminToken() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			want := os.Getenv("ADMIN_TOKEN")
+			got := r.Header.Get("X-Admin-Token")
+			if want == "" || subtle.ConstantTimeCompare([]byte(got), []byte(want)) != 1 {
+				writeError(w, http.StatusForbidden, "forbidden", "Forbidden")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requireAdmin is requireAuth plus users.is_admin: 403 for accounts without it.
+func (s *Server) requireAdmin() func(http.Handler) http.Handler {
+	auth := s.requireAuth()
+	return func(next http.Handler) http.Handler {
+		return auth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			me, _ := r.Context().Value(ctxUserKey{}).(*authUser)
+			var admin bool
+			if err := s.db.QueryRow(`SELECT is_admin FROM users WHERE id=?`, me.ID).Scan(&admin); err != nil {
+				writeError(w, http.StatusInternalServerError, "db_error", "")
+				return
+			}
+			if !admin {
+				writeError(w, http.StatusForbidden, "forbidden", "Forbidden")
+				return
+			}
+			next.ServeHTTP(w, r)
+		}))
+	}
+}
+
+// adminUserStatsRes is returned by GET /admin/users/{id}/stats.
+type adminUserStatsRes struct {
+	statsRes
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastActive *time.Time `json:"lastActive,omitempty"` // latest classic guess/start or daily guess; omitted if never played
+}
+
+// handleAdminUserStats returns /stats/me for the user in the path (404 if unknown).
+func (s *Server) handleAdminUserStats(w http.ResponseWriter, r *http.Request) {
+	u, err := s.findUserByID(chi.URLParam(r, "id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, "user_not_found", "")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	st, err := s.userStats(u.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	last, err := s.lastActive(u.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	_ = json.NewEncoder(w).Encode(adminUserStatsRes{statsRes: st, CreatedAt: u.CreatedAt, LastActive: last})
+}
+
+// lastActive returns the user's most recent classic game activity or daily
+// guess, or nil if they have neither. The two tables store different
+// timestamp precisions, so they are compared after parsing.
+func (s *Server) lastActive(userID string) (*time.Time, error) {
+	var game, dailySeen sql.NullString
+	if err := s.db.QueryRow(`SELECT MAX(COALESCE(last_activity, started_at)) FROM games WHERE user_id=?`, userID).Scan(&game); err != nil {
+		return nil, err
+	}
+	if err := s.db.QueryRow(`SELECT MAX(last_seen) FROM daily_sessions WHERE user_id=?`, userID).Scan(&dailySeen); err != nil {
+		return nil, err
+	}
+	var last *time.Time
+	for _, v := range []sql.NullString{game, dailySeen} {
+		if !v.Valid {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339Nano, v.String); err == nil && (last == nil || t.After(*last)) {
+			last = &t
+		}
+	}
+	return last, nil
+}
+
+// blocklistReq is the payload for POST /admin/blocklist.
+type blocklistReq struct {
+	Word    string `json:"word"`
+	Blocked bool   `json:"blocked"` // true to block, false to unblock
+}
+
+// handleGetBlocklist returns the current guess blocklist.
+func (s *Server) handleGetBlocklist(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]any{"words": game.BlockedGuesses()})
+}
+
+// handleSetBlocklist blocks or unblocks a single guess word.
+func (s *Server) handleSetBlocklist(w http.ResponseWriter, r *http.Request) {
+	var req blocklistReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_json", "")
+		return
+	}
+	word := strings.ToLower(strings.TrimSpace(req.Word))
+	if word == "" {
+		writeError(w, http.StatusBadRequest, "word_required", "")
+		return
+	}
+	if req.Blocked {
+		game.BlockGuess(word)
+	} else {
+		game.UnblockGuess(word)
+	}
+	log.Info().Str("word", word).Bool("blocked", req.Blocked).Msg("guess blocklist updated")
+	_ = json.NewEncoder(w).Encode(map[string]any{"words": game.BlockedGuesses()})
+}
+
+// flagReq is the payload for POST /admin/flags.
+type flagReq struct {
+	Name    featureflags.Flag `json:"name"`
+	Enabled *bool             `json:"enabled"` // null reverts to the env default
+}
+
+// handleGetFlags returns every feature flag's effective value.
+func (s *Server) handleGetFlags(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]any{"flags": s.flags.All()})
+}
+
+// handleSetFlag overrides (or resets) a single feature flag.
+func (s *Server) handleSetFlag(w http.ResponseWriter, r *http.Request) {
+	var req flagReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_json", "")
+		return
+	}
+	var err error
+	if req.Enabled == nil {
+		err = s.flags.Reset(req.Name)
+	} else {
+		err = s.flags.Set(req.Name, *req.Enabled)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "unknown_flag", "")
+		return
+	}
+	log.Info().Str("flag", string(req.Name)).Interface("enabled", req.Enabled).Msg("feature flag updated")
+	_ = json.NewEncoder(w).Encode(map[string]any{"flags": s.flags.All()})
+}
+
+// handleReloadWords re-reads the word lists and returns the new counts
+// (words.Report). A failed reload leaves the current lists in place.
+func (s *Server) handleReloadWords(w http.ResponseWriter, r *http.Request) {
+	rep, err := words.Reload()
+	if err != nil {
+		log.Warn().Err(err).Msg("words reload failed")
+		writeError(w, http.StatusInternalServerError, "reload_failed", err.Error())
+		return
+	}
+	_ = json.NewEncoder(w).Encode(rep)
+}
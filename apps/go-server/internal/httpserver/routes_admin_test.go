@@ -0,0 +1,61 @@
+package httpserver
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+func TestAdminUserStats(t *testing.T) {
+	ts := newTestServer(t, "ALLOW_FIXED_ANSWER", "true", "ADMIN_TOKEN", "admin-secret")
+	list := words.AnswersLen(5)
+
+	player := ts.client()
+	uid := player.signup("player")
+	id := player.newGame(newGameReq{Answer: list[0]})
+	player.guess(id, list[1])
+	player.guess(id, list[0])
+	var mine statsRes
+	player.call("GET", "/stats/me", nil, &mine)
+
+	admin := ts.client()
+	adminID := admin.signup("moderator")
+	path := "/admin/users/" + uid + "/stats"
+
+	if status, _ := ts.client().do("GET", path, nil); status != http.StatusUnauthorized {
+		t.Fatalf("anonymous: status %d, want 401", status)
+	}
+	status, raw := admin.do("GET", path, nil)
+	if status != http.StatusForbidden || errorCode(raw) != "forbidden" {
+		t.Fatalf("non-admin: status %d %s, want 403", status, raw)
+	}
+	// The operator token is not an admin account.
+	if status, _ := admin.do("GET", path, nil, "X-Admin-Token", "admin-secret"); status != http.StatusForbidden {
+		t.Fatalf("non-admin with the admin token: status %d, want 403", status)
+	}
+
+	if _, err := ts.db.Exec(`UPDATE users SET is_admin=1 WHERE id=?`, adminID); err != nil {
+		t.Fatal(err)
+	}
+	var res adminUserStatsRes
+	if status := admin.call("GET", path, nil, &res); status != http.StatusOK {
+		t.Fatalf("admin: status %d", status)
+	}
+	if res.ID != uid || res.GamesPlayed != mine.GamesPlayed || res.Wins != 1 || res.Distribution[1] != 1 {
+		t.Fatalf("admin stats = %+v, want the /stats/me shape %+v", res.statsRes, mine)
+	}
+	if res.CreatedAt.IsZero() || res.LastActive == nil {
+		t.Fatalf("createdAt %v lastActive %v, want both set", res.CreatedAt, res.LastActive)
+	}
+
+	// A user who never played has no lastActive.
+	res = adminUserStatsRes{}
+	if status := admin.call("GET", "/admin/users/"+adminID+"/stats", nil, &res); status != http.StatusOK || res.GamesPlayed != 0 || res.LastActive != nil {
+		t.Fatalf("idle user: status %d %+v", status, res)
+	}
+	status, raw = admin.do("GET", "/admin/users/no-such-user/stats", nil)
+	if status != http.StatusNotFound || errorCode(raw) != "user_not_found" {
+		t.Fatalf("unknown id: status %d %s, want 404", status, raw)
+	}
+}
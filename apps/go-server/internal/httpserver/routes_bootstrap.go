@@ -0,0 +1,189 @@
+// apps/go-server/internal/httpserver/routes_bootstrap.go
+//
+// GET /bootstrap → everything the web client needs on first load, in one
+// call (bootstrap flag, BOOTSTRAP_ENABLED=true; 404 while off).
+//   - user:   the signed-in user (as /auth/me), or null for guests
+//   - daily:  today's date, puzzle number, next rollover, and the caller's
+//     status (none | playing | won | lost) with the guess count so far
+//   - stats:  /stats/me for users; guests get the same shape from the
+//     classic games on their anon cookie (streak is always 0)
+//   - config: the flags and settings the client branches on
+//
+// Optional auth; never mints an anon cookie, so a first visit reports the
+// daily as "none" and empty stats. The answer is never included.
+
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/daily"
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+)
+
+// bootstrapFlags are the feature flags exposed to the client.
+var bootstrapFlags = []featureflags.Flag{
+	featureflags.GuestsAllowed,
+	featureflags.DailyEnabled,
+	featureflags.DailyRequireAuth,
+	featureflags.Maintenance,
+	featureflags.ShortLinks,
+	featureflags.CustomAllowed,
+	featureflags.StreakFreeze,
+	featureflags.DailyRevealTime,
+	featureflags.AccountVerify,
+	featureflags.Hints,
+}
+
+// statsRes is returned by /stats/me and embedded in /bootstrap.
+type statsRes struct {
+	ID           string `json:"id,omitempty"` // users only
+	GamesPlayed  int    `json:"gamesPlayed"`
+	Wins         int    `json:"wins"`
+	Streak       int    `json:"streak"`
+	Distribution []int  `json:"distribution"` // wins by guess count, index 0 = solved in 1
+}
+
+// bootstrapDaily is the daily block of /bootstrap.
+type bootstrapDaily struct {
+	Date    string    `json:"date"`
+	Puzzle  int       `json:"puzzle"`
+	NextAt  time.Time `json:"nextAt"` // next rollover, UTC
+	Status  string    `json:"status"` // none | playing | won | lost
+	GameID  string    `json:"gameId,omitempty"`
+	Guesses int       `json:"guesses"`
+}
+
+// bootstrapConfig is the config block of /bootstrap.
+type bootstrapConfig struct {
+	Flags           map[featureflags.Flag]bool `json:"flags"`
+	Rows            int                        `json:"rows"`
+	LoginIdentifier string                     `json:"loginIdentifier"` // username | email | either
+}
+
+// bootstrapRes is returned by /bootstrap.
+type bootstrapRes struct {
+	User   *authUser       `json:"user"`
+	Daily  bootstrapDaily  `json:"daily"`
+	Stats  statsRes        `json:"stats"`
+	Config bootstrapConfig `json:"config"`
+}
+
+// mountBootstrap registers GET /bootstrap.
+func (s *Server) mountBootstrap(dd *dailyServer) {
+	s.r.With(s.withOptionalAuth()).Get("/bootstrap", func(w http.ResponseWriter, r *http.Request) {
+		if !s.flags.Enabled(featureflags.Bootstrap) {
+			writeError(w, http.StatusNotFound, "not_found", "no route for "+r.URL.Path)
+			return
+		}
+		ctx := r.Context()
+		var res bootstrapRes
+		uid := ""
+		if me, _ := ctx.Value(ctxUserKey{}).(*authUser); me != nil {
+			res.User, uid = me, me.ID
+		}
+
+		var err error
+		if uid != "" {
+			res.Stats, err = s.userStats(uid)
+		} else if c, cerr := r.Cookie(anonCookieName); cerr == nil && c.Value != "" {
+			uid = c.Value
+			res.Stats, err = s.anonStats(uid)
+		} else {
+			res.Stats = statsRes{Distribution: make([]int, distributionBuckets)}
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "db_error", "")
+			return
+		}
+
+		if res.Daily, err = dd.status(ctx, uid); err != nil {
+			writeError(w, http.StatusInternalServerError, "db_error", "")
+			return
+		}
+
+		res.Config = bootstrapConfig{
+			Flags:           make(map[featureflags.Flag]bool, len(bootstrapFlags)),
+			Rows:            game.DefaultRows,
+			LoginIdentifier: loginIdentifier(),
+		}
+		for _, f := range bootstrapFlags {
+			res.Config.Flags[f] = s.flags.Enabled(f)
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	})
+}
+
+// userStats loads a user's lifetime stats.
+func (s *Server) userStats(userID string) (statsRes, error) {
+	u, err := s.findUserByID(userID)
+	if err != nil {
+		return statsRes{}, err
+	}
+	dist, err := s.guessDistribution(userID)
+	if err != nil {
+		return statsRes{}, err
+	}
+	return statsRes{ID: u.ID, GamesPlayed: u.GamesPlayed, Wins: u.Wins, Streak: u.Streak, Distribution: dist}, nil
+}
+
+// anonStats derives stats from the finished classic games on a guest's anon ID.
+func (s *Server) anonStats(anonID string) (statsRes, error) {
+	st := statsRes{Distribution: make([]int, distributionBuckets)}
+	rows, err := s.db.Query(`SELECT status, guesses FROM games
+	                          WHERE anonymous_id=? AND status IN ('won','lost')`, anonID)
+	if err != nil {
+		return st, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var status string
+		var guesses int
+		if err := rows.Scan(&status, &guesses); err != nil {
+			return st, err
+		}
+		st.GamesPlayed++
+		if status == "won" {
+			st.Wins++
+			if guesses >= 1 && guesses <= distributionBuckets {
+				st.Distribution[guesses-1]++
+			}
+		}
+	}
+	return st, rows.Err()
+}
+
+// status reports today's daily for uid ("" = unknown caller: status none).
+func (d *dailyServer) status(ctx context.Context, uid string) (bootstrapDaily, error) {
+	now := time.Now()
+	date := daily.DateKey(now, d.loc)
+	out := bootstrapDaily{
+		Date:   date,
+		Puzzle: daily.PuzzleNumber(date, d.epoch),
+		NextAt: daily.NextRollover(now, d.loc).UTC(),
+		Status: "none",
+	}
+	if uid == "" {
+		return out, nil
+	}
+	sess, ok, err := d.session(ctx, uid, date)
+	if err != nil || !ok {
+		return out, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out.GameID, out.Guesses = sess.GameID, sess.Guesses
+	switch {
+	case !sess.Finished:
+		out.Status = "playing"
+	case sess.Won:
+		out.Status = "won"
+	default:
+		out.Status = "lost"
+	}
+	return out, nil
+}
@@ -0,0 +1,84 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// bootstrap fetches /bootstrap, checks every section is present, and decodes it.
+func (c *testClient) bootstrap() (bootstrapRes, string) {
+	c.t.Helper()
+	status, raw := c.do("GET", "/bootstrap", nil)
+	if status != http.StatusOK {
+		c.t.Fatalf("/bootstrap: status %d %s", status, raw)
+	}
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &sections); err != nil {
+		c.t.Fatal(err)
+	}
+	for _, k := range []string{"user", "daily", "stats", "config"} {
+		if _, ok := sections[k]; !ok {
+			c.t.Fatalf("/bootstrap is missing %q: %s", k, raw)
+		}
+	}
+	var res bootstrapRes
+	if err := json.Unmarshal(raw, &res); err != nil {
+		c.t.Fatal(err)
+	}
+	return res, string(raw)
+}
+
+func TestBootstrapSignedIn(t *testing.T) {
+	ts := newTestServer(t, "BOOTSTRAP_ENABLED", "true", "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	uid := c.signup("starter")
+	answer := words.AnswersLen(5)[0]
+	c.guess(c.newGame(newGameReq{Answer: answer}), answer)
+	gameID, dailyAnswer := c.startDaily(ts)
+	c.dailyGuess(gameID, wrongGuesses(dailyAnswer, 1)[0])
+
+	res, raw := c.bootstrap()
+	if res.User == nil || res.User.ID != uid {
+		t.Fatalf("user = %+v, want %s", res.User, uid)
+	}
+	if res.Stats.ID != uid || res.Stats.GamesPlayed != 1 || res.Stats.Wins != 1 || res.Stats.Distribution[0] != 1 {
+		t.Fatalf("stats = %+v, want one win in one guess", res.Stats)
+	}
+	if d := res.Daily; d.Date != today() || d.Puzzle < 1 || d.Status != "playing" || d.GameID != gameID || d.Guesses != 1 || d.NextAt.IsZero() {
+		t.Fatalf("daily = %+v, want today in play after one guess", d)
+	}
+	if strings.Contains(raw, `"`+dailyAnswer+`"`) {
+		t.Fatalf("bootstrap reveals the daily answer: %s", raw)
+	}
+	if res.Config.Rows != game.DefaultRows || len(res.Config.Flags) != len(bootstrapFlags) || !res.Config.Flags[featureflags.DailyEnabled] {
+		t.Fatalf("config = %+v", res.Config)
+	}
+}
+
+func TestBootstrapGuest(t *testing.T) {
+	ts := newTestServer(t, "BOOTSTRAP_ENABLED", "true", "ALLOW_FIXED_ANSWER", "true")
+	guest := ts.client()
+
+	res, raw := guest.bootstrap()
+	if res.User != nil || res.Daily.Status != "none" || res.Stats.GamesPlayed != 0 || len(res.Stats.Distribution) == 0 {
+		t.Fatalf("first visit = %s, want no user, no daily and empty stats", raw)
+	}
+
+	// Classic games on the anon cookie show up as guest stats.
+	answer := words.AnswersLen(5)[0]
+	guest.guess(guest.newGame(newGameReq{Answer: answer}), answer)
+	if res, raw = guest.bootstrap(); res.User != nil || res.Stats.GamesPlayed != 1 || res.Stats.Wins != 1 || res.Stats.ID != "" {
+		t.Fatalf("guest after a win = %s, want anon stats with one win", raw)
+	}
+
+	_ = ts.flags.Set(featureflags.Bootstrap, false)
+	if status, _ := guest.do("GET", "/bootstrap", nil); status != http.StatusNotFound {
+		t.Fatalf("flag off: status %d, want 404", status)
+	}
+}
@@ -0,0 +1,190 @@
+// apps/go-server/internal/httpserver/routes_challenges.go
+//
+// Opt-in leaderboards for classic games started from a short link.
+//   - POST /game/{id}/submit              → enter a finished game on its challenge's board
+//   - GET  /challenges/{code}/leaderboard → ranked wins for a challenge (public)
+//
+// A challenge is identified by its short link slug (routes_links.go); every
+// game started from that link has the same answer, so results are comparable.
+// Each player (user or anon cookie) gets one entry per challenge: the first
+// game they submit. Wins rank by guesses, then duration, then submission time;
+// losses only count towards attempts.
+//
+// Config:
+//   - challenge_boards flag (CHALLENGE_LEADERBOARD_ENABLED=true) serves
+//     both routes; when off they 404. Games are tagged with their link either
+//     way, so results can be submitted once the flag is turned on.
+
+package httpserver
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/daily"
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+)
+
+// maxChallengeBoard bounds /challenges/{code}/leaderboard.
+const maxChallengeBoard = 50
+
+// mountChallengeRoutes registers submission on the play group (same auth and
+// rate limits as gameplay) and the public leaderboard.
+func (s *Server) mountChallengeRoutes(play chi.Router) {
+	play.With(s.requireChallenges).Post("/game/{id}/submit", s.handleSubmitChallenge)
+	s.r.With(s.requireChallenges).Get("/challenges/{code}/leaderboard", s.handleChallengeBoard)
+}
+
+// requireChallenges 404s the challenge routes while the flag is off.
+func (s *Server) requireChallenges(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.flags.Enabled(featureflags.ChallengeBoards) {
+			writeError(w, http.StatusNotFound, "not_found", "no route for "+r.URL.Path)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// submitRes is returned by POST /game/{id}/submit.
+type submitRes struct {
+	Code string `json:"code"`
+	Won  bool   `json:"won"`
+	Rank int    `json:"rank,omitempty"` // position among wins; omitted for a loss
+}
+
+// handleSubmitChallenge records a finished, link-started game owned by the
+// caller. 404 if the game isn't the caller's, 400 if it wasn't started from a
+// link, 409 while it is still in progress or if the caller already submitted
+// a result for this challenge.
+func (s *Server) handleSubmitChallenge(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	owned, err := s.ownsGame(r, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	if !owned {
+		writeError(w, http.StatusNotFound, "game_not_found", "")
+		return
+	}
+
+	var link, userID, anonID, finished sql.NullString
+	var status, started string
+	var guesses int
+	err = s.db.QueryRowContext(r.Context(),
+		`SELECT link, user_id, anonymous_id, status, guesses, started_at, finished_at FROM games WHERE id=?`, id,
+	).Scan(&link, &userID, &anonID, &status, &guesses, &started, &finished)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	if !link.Valid || link.String == "" {
+		writeError(w, http.StatusBadRequest, "not_a_challenge", "")
+		return
+	}
+	if status != "won" && status != "lost" {
+		writeError(w, http.StatusConflict, "game_not_finished", "")
+		return
+	}
+
+	player := anonID.String
+	if userID.Valid {
+		player = userID.String
+	}
+	duration := int64(0)
+	if t0, t1 := mustParse(started), mustParse(finished.String); !t0.IsZero() && !t1.IsZero() {
+		duration = t1.Sub(t0).Milliseconds()
+	}
+	won := status == "won"
+	res, err := s.db.ExecContext(r.Context(),
+		`INSERT OR IGNORE INTO challenge_results (slug, player_id, user_id, game_id, won, guesses, duration_ms, submitted_at)
+		 VALUES (?,?,?,?,?,?,?,?)`,
+		link.String, player, userID, id, won, guesses, duration, time.Now().UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		writeError(w, http.StatusConflict, "already_submitted", "")
+		return
+	}
+
+	out := submitRes{Code: link.String, Won: won}
+	if won {
+		// Same ordering as the board: fewer guesses, then faster, then earlier.
+		err = s.db.QueryRowContext(r.Context(),
+			`SELECT COUNT(*) + 1 FROM challenge_results o, challenge_results me
+			  WHERE me.slug=? AND me.player_id=? AND o.slug=me.slug AND o.won=1
+			    AND (o.guesses < me.guesses
+			     OR (o.guesses = me.guesses AND o.duration_ms < me.duration_ms)
+			     OR (o.guesses = me.guesses AND o.duration_ms = me.duration_ms AND o.submitted_at < me.submitted_at))`,
+			link.String, player,
+		).Scan(&out.Rank)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "db_error", "")
+			return
+		}
+	}
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+// challengeRow is one ranked entry on a challenge board.
+type challengeRow struct {
+	Rank       int    `json:"rank"`
+	Username   string `json:"username"` // daily.GuestLabel for guests and deleted accounts
+	Guesses    int    `json:"guesses"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// challengeBoardRes is returned by /challenges/{code}/leaderboard.
+type challengeBoardRes struct {
+	Code     string         `json:"code"`
+	Top      []challengeRow `json:"top"` // wins only
+	Attempts int            `json:"attempts"`
+	Wins     int            `json:"wins"`
+}
+
+// handleChallengeBoard returns the top maxChallengeBoard wins for a challenge,
+// plus how many results were submitted. Unknown codes yield an empty board.
+func (s *Server) handleChallengeBoard(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	out := challengeBoardRes{Code: code, Top: []challengeRow{}}
+	if err := s.db.QueryRowContext(r.Context(),
+		`SELECT COUNT(*), COALESCE(SUM(won), 0) FROM challenge_results WHERE slug=?`, code,
+	).Scan(&out.Attempts, &out.Wins); err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+
+	rows, err := s.db.QueryContext(r.Context(),
+		`SELECT COALESCE(u.username, ?), c.guesses, c.duration_ms
+		   FROM challenge_results c
+		   LEFT JOIN users u ON u.id = c.user_id
+		  WHERE c.slug=? AND c.won=1
+		  ORDER BY c.guesses ASC, c.duration_ms ASC, c.submitted_at ASC
+		  LIMIT ?`, daily.GuestLabel, code, maxChallengeBoard)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		row := challengeRow{Rank: len(out.Top) + 1}
+		if err := rows.Scan(&row.Username, &row.Guesses, &row.DurationMs); err != nil {
+			writeError(w, http.StatusInternalServerError, "db_error", "")
+			return
+		}
+		out.Top = append(out.Top, row)
+	}
+	if err := rows.Err(); err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	_ = json.NewEncoder(w).Encode(out)
+}
@@ -0,0 +1,79 @@
+package httpserver
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+func TestChallengeLeaderboard(t *testing.T) {
+	ts := newTestServer(t, "SHORT_LINKS_ENABLED", "true", "CHALLENGE_LEADERBOARD_ENABLED", "true", "ALLOW_FIXED_ANSWER", "true")
+	list := words.AnswersLen(5)
+	answer := list[0]
+	var link linkRes
+	if status := ts.client().call("POST", "/links", linkReq{Answer: answer}, &link); status != http.StatusCreated {
+		t.Fatalf("create link: status %d", status)
+	}
+	// play starts a game from the link, makes guesses, and returns its ID.
+	play := func(c *testClient, guesses ...string) string {
+		id := c.newGame(newGameReq{Link: link.Slug})
+		for _, w := range guesses {
+			c.guess(id, w)
+		}
+		return id
+	}
+	submit := func(c *testClient, id string) (int, submitRes) {
+		var res submitRes
+		return c.call("POST", "/game/"+id+"/submit", nil, &res), res
+	}
+
+	ada := ts.client()
+	ada.signup("ada")
+	adaGame := play(ada, list[1], answer)
+	if status, res := submit(ada, adaGame); status != http.StatusCreated || !res.Won || res.Rank != 1 || res.Code != link.Slug {
+		t.Fatalf("ada submit: status %d %+v", status, res)
+	}
+	bob := ts.client()
+	bob.signup("bob")
+	if status, res := submit(bob, play(bob, answer)); status != http.StatusCreated || res.Rank != 1 {
+		t.Fatalf("bob submit: status %d %+v, want rank 1 in fewer guesses", status, res)
+	}
+	guest := ts.client()
+	lost := play(guest, list[1], list[2], list[3], list[4], list[5], list[6])
+	if status, res := submit(guest, lost); status != http.StatusCreated || res.Won || res.Rank != 0 {
+		t.Fatalf("guest loss submit: status %d %+v", status, res)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		c      *testClient
+		id     string
+		status int
+	}{
+		{"resubmit", ada, adaGame, http.StatusConflict},
+		{"second game", ada, play(ada, answer), http.StatusConflict},
+		{"in progress", ada, play(ada, list[1]), http.StatusConflict},
+		{"not a challenge", ada, ada.newGame(newGameReq{Answer: answer}), http.StatusBadRequest},
+		{"someone else's game", bob, adaGame, http.StatusNotFound},
+	} {
+		if status, _ := submit(tc.c, tc.id); status != tc.status {
+			t.Errorf("%s: status %d, want %d", tc.name, status, tc.status)
+		}
+	}
+
+	var board challengeBoardRes
+	if status := ts.client().call("GET", "/challenges/"+link.Slug+"/leaderboard", nil, &board); status != http.StatusOK {
+		t.Fatalf("leaderboard: status %d", status)
+	}
+	if board.Attempts != 3 || board.Wins != 2 || len(board.Top) != 2 {
+		t.Fatalf("board = %+v, want 3 attempts and 2 ranked wins", board)
+	}
+	if board.Top[0].Username != "bob" || board.Top[0].Guesses != 1 || board.Top[1].Username != "ada" || board.Top[1].Rank != 2 {
+		t.Fatalf("board top = %+v, want bob then ada", board.Top)
+	}
+	ts.client().call("GET", "/challenges/unknown/leaderboard", nil, &board)
+	if len(board.Top) != 0 || board.Attempts != 0 {
+		t.Fatalf("unknown code board = %+v, want empty", board)
+	}
+}
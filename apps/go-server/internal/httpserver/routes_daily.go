@@ -1,107 +1,470 @@
 // apps/go-server/internal/httpserver/routes_daily.go
 //
-// HTTP routes for the "Daily Challenge" mode.
-// Exposes three endpoints under /daily:
-//   - POST /daily/new         → start a daily game (creates or reuses session)
-//   - POST /daily/guess       → submit a guess for today’s daily game
-//   - GET  /daily/leaderboard → fetch top 20 results for today (or a given date)
+// HTTP routes for the "Daily Challenge" mode, mounted under /daily:
+//   - POST /daily/new          → start a daily game (creates or reuses session)
+//   - POST /daily/guess        → submit a guess for today’s daily game
+//   - GET  /daily/leaderboard  → fetch top 20 results for today (or a given date)
+//   - GET  /daily/today        → today's puzzle and whether the caller played it
+//   - GET  /daily/reveal-time  → when today's puzzle rolls over
+//   - GET  /daily/recap        → a day's results summary
+//   - GET  /daily/verify       → audit material for a past date's word index
+//   - GET  /daily/share        → rebuild the emoji grid for a won daily
+//   - GET  /daily/mine         → caller's own daily results
+//   - GET  /daily/rank-history → caller's daily rank per day played (auth)
+//   - GET  /daily/streak       → caller's streaks and streak freezes (auth)
+//   - GET/POST /daily/preferences → caller's daily difficulty (auth)
 //
-// Each user can play once per day (enforced by DB + in-memory session).
-// Sessions are held in memory for active play and persisted to DB on win.
-// Deterministic word selection is based on date + salt.
+// Each user can play once per day (enforced by DB + session).
+// Sessions are cached in memory and written through to daily_sessions;
+// finished games are recorded in daily_results.
+// Deterministic word selection is based on date + salt, pinned per date.
+// Optional behaviour is flag-gated; see the handlers and dailyServer fields.
 
 package httpserver
 
 import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
 
+	"github.com/robalobadob/wordle/apps/go-server/internal/analysis"
 	"github.com/robalobadob/wordle/apps/go-server/internal/daily"
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+	"github.com/robalobadob/wordle/apps/go-server/internal/metrics"
+	"github.com/robalobadob/wordle/apps/go-server/internal/webhook"
 	"github.com/robalobadob/wordle/apps/go-server/internal/words"
 )
 
 // dailyServer wraps dependencies for /daily endpoints.
 type dailyServer struct {
-	srv      *Server
-	store    *daily.Store
-	salt     string
-	sessions map[string]*dailySession // active sessions keyed by userID|date
-	mu       sync.Mutex               // guards sessions
+	srv         *Server
+	store       *daily.Store
+	salt        daily.Salt               // active salt (DAILY_SALT, DAILY_SALT_VERSION)
+	epoch       string                   // date key of puzzle #1 (DAILY_EPOCH)
+	loc         *time.Location           // daily rollover zone (DAILY_TIMEZONE)
+	grace       int                      // days a won daily stays shareable (DAILY_SHARE_GRACE_DAYS)
+	idleCap     time.Duration            // max credited gap between guesses (DAILY_IDLE_CAP; 0 = wall clock)
+	samples     int                      // "words you could have tried" after a loss (DAILY_LOSS_SAMPLES; 0 = off)
+	freezeEvery int                      // wins per earned streak freeze (DAILY_FREEZE_EVERY)
+	freezeMax   int                      // most freezes held at once (DAILY_FREEZE_MAX)
+	fpMode      string                   // guest fingerprint check: off | advisory | strict (DAILY_FINGERPRINT)
+	fpSources   []string                 // fingerprint inputs: ip, ua (DAILY_FINGERPRINT_SOURCES)
+	openers     []string                 // solver par openers, in order (DAILY_PAR_OPENERS)
+	noRepeat    int                      // days an answer is kept from coming back (DAILY_NO_REPEAT_DAYS; 0 = off)
+	live        daily.Tally              // today's finished attempts/wins for /daily/today
+	sessions    map[string]*dailySession // cache of daily_sessions, keyed by userID|date
+	parDate     string                   // last date ensurePar ran for
+	pools       map[string][]string      // effective answer pool per date key; see pool
+	poolsKey    [2]uint64                // words.Generation and flags version pools were built under
+	mu          sync.Mutex               // guards sessions, parDate, and pools
 }
 
-// dailySession holds transient in-memory state for an in-progress daily game.
+// dailySession is the cached state of a daily game (see daily.Session for
+// the stored form).
 type dailySession struct {
 	GameID    string
 	UserID    string
 	Date      string
 	WordIndex int
+	SaltVer   int // salt version WordIndex was pinned under
 	Answer    string
 	Start     time.Time
+	LastSeen  time.Time // start or last guess; gaps are measured from here
+	ActiveMs  int64     // sum of per-guess gaps, each capped at idleCap
 	Guesses   int
+	Words     []string // guessed words in order (persisted as the board on win)
 	Finished  bool
+	Won       bool
+
+	Difficulty  string // easy | normal | hard, fixed when the session starts
+	Fingerprint string // guest device fingerprint; "" for registered players or when off
+
+	PracticeCode string // short link for the answer, made once the session is finished
 }
 
-// mountDaily registers all /daily routes.
-func (s *Server) mountDaily(r chi.Router) {
+// mountDaily registers all /daily routes and returns the daily server for
+// routes that report daily state elsewhere (/bootstrap).
+func (s *Server) mountDaily(r chi.Router) *dailyServer {
+	loc, err := daily.Location(getEnv("DAILY_TIMEZONE", ""))
+	if err != nil {
+		log.Warn().Err(err).Msg("daily: invalid DAILY_TIMEZONE; using UTC")
+	}
 	dd := &dailyServer{
-		srv:      s,
-		store:    daily.NewStore(s.db),
-		salt:     getEnv("DAILY_SALT", "local_dev_salt"),
-		sessions: make(map[string]*dailySession),
+		srv:         s,
+		store:       daily.NewStore(s.db, loc),
+		loc:         loc,
+		salt:        daily.Salt{Version: envInt("DAILY_SALT_VERSION", 1), Secret: getEnv("DAILY_SALT", "local_dev_salt")},
+		epoch:       getEnv("DAILY_EPOCH", "2025-01-01"),
+		grace:       envInt("DAILY_SHARE_GRACE_DAYS", 7),
+		idleCap:     envDuration("DAILY_IDLE_CAP", 2*time.Minute),
+		samples:     envInt("DAILY_LOSS_SAMPLES", 0),
+		freezeEvery: envInt("DAILY_FREEZE_EVERY", 7),
+		freezeMax:   envInt("DAILY_FREEZE_MAX", 2),
+		fpMode:      strings.ToLower(getEnv("DAILY_FINGERPRINT", fingerprintOff)),
+		fpSources:   strings.Split(strings.ToLower(getEnv("DAILY_FINGERPRINT_SOURCES", "ip,ua")), ","),
+		openers:     parOpeners(getEnv("DAILY_PAR_OPENERS", "crane")),
+		noRepeat:    envInt("DAILY_NO_REPEAT_DAYS", 14),
+		sessions:    make(map[string]*dailySession),
 	}
+	// Registered on the root router so it skips the play group's auth and
+	// rate limit; the static path wins over the /daily mount.
+	s.r.With(dd.requireEnabled).Get("/daily/reveal-time", dd.handleRevealTime)
 	r.Route("/daily", func(r chi.Router) {
+		r.Use(dd.requireEnabled)
+		r.Use(dd.requireAuthIfConfigured())
 		r.Post("/new", dd.handleNew)
 		r.Post("/guess", dd.handleGuess)
 		r.Get("/leaderboard", dd.handleLeaderboard)
+		r.Get("/recap", dd.handleRecap)
+		r.Get("/verify", dd.handleVerify)
+		r.Get("/today", dd.handleToday)
+		r.Get("/mine", dd.handleMine)
+		r.With(s.requireAuth()).Get("/rank-history", dd.handleRankHistory)
+		r.With(s.requireAuth()).Get("/streak", dd.handleStreak)
+		r.Get("/share", dd.handleShare)
+		r.With(s.requireAuth()).Get("/preferences", dd.handleGetPreferences)
+		r.With(s.requireAuth()).Post("/preferences", dd.handleSetPreferences)
+	})
+	dd.startDailyRetention()
+	dd.startSessionPrune()
+	s.metrics.GaugeFunc("wordle_daily_sessions", "Daily sessions cached in memory, by state.", dd.sessionGauge)
+
+	// Seed the live tally so a restart doesn't zero today's banner.
+	today := dd.today()
+	if attempts, wins, err := dd.store.Participation(context.Background(), today); err != nil {
+		log.Warn().Err(err).Msg("daily: seeding live tally")
+	} else {
+		dd.live.Reset(today, attempts, wins)
+	}
+	return dd
+}
+
+// requireEnabled answers 503 for every /daily route while daily_enabled is off.
+func (d *dailyServer) requireEnabled(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !d.srv.flags.Enabled(featureflags.DailyEnabled) {
+			writeError(w, http.StatusServiceUnavailable, "daily_disabled", "daily disabled")
+			return
+		}
+		next.ServeHTTP(w, r)
 	})
 }
 
-// dateKeyNow returns today's date key, deterministic word index, and answer.
-func (d *dailyServer) dateKeyNow() (date string, idx int, answer string) {
-	now := time.Now().UTC()
-	date = daily.DateKey(now)
-	answers := words.Answers()
+// requireAuthIfConfigured applies requireAuth to /daily routes while the
+// daily_require_auth flag is on; otherwise the play group's auth applies.
+func (d *dailyServer) requireAuthIfConfigured() func(http.Handler) http.Handler {
+	required := d.srv.requireAuth()
+	return func(next http.Handler) http.Handler {
+		member := required(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if d.srv.flags.Enabled(featureflags.DailyRequireAuth) {
+				member.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// sessionGauge reports the cached sessions for the metrics endpoint.
+func (d *dailyServer) sessionGauge() []metrics.Sample {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	playing := 0
+	for _, sess := range d.sessions {
+		if !sess.Finished {
+			playing++
+		}
+	}
+	return []metrics.Sample{
+		{Labels: map[string]string{"state": "playing"}, Value: float64(playing)},
+		{Labels: map[string]string{"state": "finished"}, Value: float64(len(d.sessions) - playing)},
+	}
+}
+
+// pruneSessions drops in-memory sessions for dates before `before` ("YYYY-MM-DD").
+// Returns the number of sessions removed.
+func (d *dailyServer) pruneSessions(before string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := 0
+	for k, sess := range d.sessions {
+		if sess.Date < before {
+			delete(d.sessions, k)
+			n++
+		}
+	}
+	return n
+}
+
+// session returns the caller's session for date from the cache, loading it
+// from daily_sessions on a miss. Reports false if there is none.
+func (d *dailyServer) session(ctx context.Context, uid, date string) (*dailySession, bool, error) {
+	key := uid + "|" + date
+	d.mu.Lock()
+	sess, ok := d.sessions[key]
+	d.mu.Unlock()
+	if ok {
+		return sess, true, nil
+	}
+
+	rec, ok, err := d.store.LoadSession(ctx, uid, date)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	day, _ := time.Parse("2006-01-02", date)
+	answer := d.answerAt(day, rec.WordIndex)
+	if answer == "" {
+		log.Warn().Str("date", date).Int("index", rec.WordIndex).Msg("daily: stored session's word index out of range")
+		return nil, false, nil
+	}
+	sess = &dailySession{
+		GameID:       rec.GameID,
+		UserID:       uid,
+		Date:         date,
+		WordIndex:    rec.WordIndex,
+		SaltVer:      rec.SaltVersion,
+		Answer:       answer,
+		Start:        rec.Start,
+		LastSeen:     rec.LastSeen,
+		ActiveMs:     rec.ActiveMs,
+		Guesses:      len(rec.Words),
+		Words:        rec.Words,
+		Finished:     rec.Finished,
+		Won:          rec.Won,
+		Difficulty:   rec.Difficulty,
+		Fingerprint:  rec.Fingerprint,
+		PracticeCode: rec.PracticeCode,
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if cached, ok := d.sessions[key]; ok {
+		return cached, true, nil // another request loaded it first
+	}
+	d.sessions[key] = sess
+	return sess, true, nil
+}
+
+// saveSession writes the session through to daily_sessions.
+func (d *dailyServer) saveSession(ctx context.Context, sess *dailySession) error {
+	d.mu.Lock()
+	rec := sess.record()
+	d.mu.Unlock()
+	return d.store.SaveSession(ctx, rec)
+}
+
+// record returns the stored form of sess. Caller must hold dailyServer.mu.
+func (sess *dailySession) record() daily.Session {
+	return daily.Session{
+		UserID:       sess.UserID,
+		Date:         sess.Date,
+		GameID:       sess.GameID,
+		WordIndex:    sess.WordIndex,
+		SaltVersion:  sess.SaltVer,
+		Words:        append([]string(nil), sess.Words...),
+		Start:        sess.Start,
+		LastSeen:     sess.LastSeen,
+		ActiveMs:     sess.ActiveMs,
+		Finished:     sess.Finished,
+		Won:          sess.Won,
+		Difficulty:   sess.Difficulty,
+		Fingerprint:  sess.Fingerprint,
+		PracticeCode: sess.PracticeCode,
+	}
+}
+
+// today returns today's date key in the daily zone.
+func (d *dailyServer) today() string {
+	return daily.DateKey(time.Now(), d.loc)
+}
+
+// puzzleToday returns today's date key, word index, salt version, and answer.
+// The index is computed with the active salt (skipping recent answers, see
+// freshIndex) and pinned on first use; an existing pin always wins so past and
+// in-progress days survive a salt rotation.
+func (d *dailyServer) puzzleToday(ctx context.Context) (date string, idx, version int, answer string, err error) {
+	now := time.Now()
+	date = daily.DateKey(now, d.loc)
+	day, _ := time.Parse("2006-01-02", date) // theme windows follow the local date
+	answers := d.pool(day)
 	if len(answers) == 0 {
-		return date, 0, ""
+		return date, 0, d.salt.Version, "", nil
+	}
+	idx, version, err = d.store.PinnedWordIndex(ctx, date)
+	if errors.Is(err, sql.ErrNoRows) {
+		if idx, err = d.freshIndex(ctx, now, day, answers); err == nil {
+			idx, version, err = d.store.PinWordIndex(ctx, date, idx, d.salt.Version)
+		}
+	}
+	if err != nil {
+		return date, 0, 0, "", err
+	}
+	if idx < 0 || idx >= len(answers) {
+		// The answer list shrank since the pin; fall back to the live mapping.
+		log.Warn().Str("date", date).Int("index", idx).Msg("daily: pinned index out of range")
+		idx, version = d.salt.WordIndex(now, d.loc, len(answers)), d.salt.Version
 	}
-	idx = daily.WordIndex(now, d.salt, len(answers))
-	return date, idx, answers[idx]
+	d.ensurePar(date, answers, answers[idx])
+	return date, idx, version, answers[idx], nil
+}
+
+// freshIndex picks the index for a date that has no pin yet: the salt's
+// index, moved past any answer pinned for one of the previous noRepeat days.
+// Recent answers are compared as words, since theme windows change the list.
+func (d *dailyServer) freshIndex(ctx context.Context, now, day time.Time, answers []string) (int, error) {
+	if d.noRepeat <= 0 {
+		return d.salt.WordIndex(now, d.loc, len(answers)), nil
+	}
+	from := day.AddDate(0, 0, -d.noRepeat).Format("2006-01-02")
+	pins, err := d.store.PinnedBetween(ctx, from, day.Format("2006-01-02"))
+	if err != nil {
+		return 0, err
+	}
+	recent := make(map[string]bool, len(pins))
+	for date, idx := range pins {
+		if past, err := time.Parse("2006-01-02", date); err == nil {
+			if w := d.answerAt(past, idx); w != "" {
+				recent[w] = true
+			}
+		}
+	}
+	return d.salt.PickIndex(now, d.loc, len(answers), func(idx int) bool {
+		return recent[answers[idx]]
+	}), nil
+}
+
+// ensurePar computes and stores a date's solver par in the background while
+// the daily_par flag is on. It runs once per date per process; a par that is
+// already stored is kept, so changing DAILY_PAR_OPENERS only affects dates
+// that have no par yet.
+func (d *dailyServer) ensurePar(date string, pool []string, answer string) {
+	if !d.srv.flags.Enabled(featureflags.DailyPar) {
+		return
+	}
+	d.mu.Lock()
+	if d.parDate == date {
+		d.mu.Unlock()
+		return
+	}
+	d.parDate = date
+	d.mu.Unlock()
+	go func() {
+		ctx := d.srv.bg
+		if _, ok, err := d.store.Par(ctx, date); err != nil || ok {
+			return
+		}
+		par := analysis.SolverPar(pool, d.openers, strings.ToLower(answer))
+		if err := d.store.SetPar(ctx, date, par); err != nil {
+			log.Warn().Err(err).Str("date", date).Msg("daily: storing par")
+		}
+	}()
+}
+
+// parOpeners parses DAILY_PAR_OPENERS (comma-separated, lowercased).
+func parOpeners(s string) []string {
+	var out []string
+	for _, w := range strings.Split(s, ",") {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			out = append(out, w)
+		}
+	}
+	return out
 }
 
 // userIDWithAnon returns the authenticated user ID if logged in,
 // otherwise ensures an anonymous ID via Server.ensureAnonID.
+// Reports false when guests are disabled (or the daily is registered-only)
+// and the caller is not logged in.
 func (d *dailyServer) userIDWithAnon(w http.ResponseWriter, r *http.Request) (string, bool) {
 	if me, _ := r.Context().Value(ctxUserKey{}).(*authUser); me != nil {
 		return me.ID, true
 	}
+	if !d.srv.flags.Enabled(featureflags.GuestsAllowed) || d.srv.flags.Enabled(featureflags.DailyRequireAuth) {
+		return "", false
+	}
 	return d.srv.ensureAnonID(w, r), true
 }
 
+// Guest fingerprint modes (DAILY_FINGERPRINT). When a guest starts a session
+// and another player already finished today from the same fingerprint,
+// advisory answers with "flagged": true and strict refuses with 429.
+// Registered players are never checked.
+const (
+	fingerprintOff      = "off"
+	fingerprintAdvisory = "advisory"
+	fingerprintStrict   = "strict"
+)
+
+// fingerprint returns the caller's soft device fingerprint: hex SHA-256 over
+// the daily salt and the configured sources. "" when the check is off or no
+// source is configured.
+func (d *dailyServer) fingerprint(r *http.Request) string {
+	if d.fpMode != fingerprintAdvisory && d.fpMode != fingerprintStrict {
+		return ""
+	}
+	h := sha256.New()
+	h.Write([]byte(d.salt.Secret))
+	used := false
+	for _, src := range d.fpSources {
+		switch strings.TrimSpace(src) {
+		case "ip":
+			h.Write([]byte("|ip:" + clientIP(r)))
+		case "ua":
+			h.Write([]byte("|ua:" + r.UserAgent()))
+		default:
+			continue
+		}
+		used = true
+	}
+	if !used {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // -----------------------------------------------------------------------------
 // /daily/new
 
 // newRes is returned by /daily/new.
 type newRes struct {
-	GameID string `json:"gameId"`
-	Date   string `json:"date"`
-	Played bool   `json:"played"`
+	GameID     string `json:"gameId"`
+	Date       string `json:"date"`
+	Played     bool   `json:"played"`
+	Difficulty string `json:"difficulty,omitempty"`
+	Flagged    bool   `json:"flagged,omitempty"` // advisory fingerprint match (see DAILY_FINGERPRINT)
 }
 
 // handleNew creates or reuses a daily session for the current date.
 // - If user already has a DB row for today → return Played=true.
-// - Otherwise create/reuse an in-memory session and return GameID.
+// - Otherwise reuse the stored session or create (and store) one, and return GameID.
+// - New sessions pick up the user's stored difficulty preference.
+// - New guest sessions are fingerprint-checked when DAILY_FINGERPRINT is on.
 func (d *dailyServer) handleNew(w http.ResponseWriter, r *http.Request) {
 	uid, ok := d.userIDWithAnon(w, r)
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, "unauthorized", "unauthorized")
+		return
+	}
+	date, idx, saltVer, answer, err := d.puzzleToday(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
 		return
 	}
-	date, idx, answer := d.dateKeyNow()
 
 	// Check if already played (persisted in DB).
 	if played, err := d.store.AlreadyPlayed(r.Context(), uid, date); err == nil && played {
@@ -109,26 +472,71 @@ func (d *dailyServer) handleNew(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Reuse or create session in memory.
-	key := uid + "|" + date
-	d.mu.Lock()
-	if sess, ok := d.sessions[key]; ok {
-		d.mu.Unlock()
-		_ = json.NewEncoder(w).Encode(newRes{GameID: sess.GameID, Date: date, Played: false})
+	// Reuse the session (cached or stored) if there is one.
+	if sess, ok, err := d.session(r.Context(), uid, date); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
+		return
+	} else if ok {
+		_ = json.NewEncoder(w).Encode(newRes{GameID: sess.GameID, Date: date, Played: false, Difficulty: sess.Difficulty})
+		return
+	}
+
+	difficulty, err := d.store.DifficultyFor(r.Context(), uid)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
 		return
 	}
-	sess := &dailySession{
-		GameID:    genID(),
-		UserID:    uid,
-		Date:      date,
-		WordIndex: idx,
-		Answer:    strings.ToLower(answer),
-		Start:     time.Now(),
+
+	fp, flagged := "", false
+	if me, _ := r.Context().Value(ctxUserKey{}).(*authUser); me == nil {
+		fp = d.fingerprint(r)
+	}
+	if fp != "" {
+		seen, err := d.store.FingerprintPlayed(r.Context(), date, fp, uid)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "server_error", "server error")
+			return
+		}
+		if seen && d.fpMode == fingerprintStrict {
+			writeError(w, http.StatusTooManyRequests, "already_played", "daily already played on this device")
+			return
+		}
+		if seen {
+			log.Info().Str("date", date).Str("anon", uid).Msg("daily: guest fingerprint already played")
+			flagged = true
+		}
+	}
+
+	key := uid + "|" + date
+	d.mu.Lock()
+	sess, ok := d.sessions[key]
+	if !ok {
+		sess = &dailySession{
+			GameID:      genID(),
+			UserID:      uid,
+			Date:        date,
+			WordIndex:   idx,
+			SaltVer:     saltVer,
+			Answer:      strings.ToLower(answer),
+			Start:       time.Now(),
+			LastSeen:    time.Now(),
+			Difficulty:  difficulty,
+			Fingerprint: fp,
+		}
+		d.sessions[key] = sess
 	}
-	d.sessions[key] = sess
 	d.mu.Unlock()
+	if !ok {
+		if err := d.saveSession(r.Context(), sess); err != nil {
+			d.mu.Lock()
+			delete(d.sessions, key)
+			d.mu.Unlock()
+			writeError(w, http.StatusInternalServerError, "server_error", "server error")
+			return
+		}
+	}
 
-	_ = json.NewEncoder(w).Encode(newRes{GameID: sess.GameID, Date: date, Played: false})
+	_ = json.NewEncoder(w).Encode(newRes{GameID: sess.GameID, Date: date, Played: false, Difficulty: sess.Difficulty, Flagged: flagged})
 }
 
 // -----------------------------------------------------------------------------
@@ -142,79 +550,325 @@ type dailyGuessReq struct {
 
 // dailyGuessRes is the response payload for /daily/guess.
 type dailyGuessRes struct {
-	Marks   []int  `json:"marks"`  // per-letter: 0=miss, 1=present, 2=hit
-	State   string `json:"state"`  // in_progress | won | locked
-	Guesses int    `json:"guesses"`
+	Marks     any      `json:"marks"` // per-letter: 0=miss, 1=present, 2=hit; see negotiateMarks
+	State     string   `json:"state"` // in_progress | won | lost | locked
+	Guesses   int      `json:"guesses"`
+	Samples   []string `json:"samples,omitempty"`   // lost/locked after a loss: words still consistent with the guesses
+	Evaluated string   `json:"evaluated,omitempty"` // the guess as scored (evaluated_guess flag); not on locked
+
+	GuessNumber int `json:"guessNumber,omitempty"` // 1-based number of the guess just scored; not on locked
+	Remaining   int `json:"remaining"`             // game.DefaultRows - guesses; may be > 0 after a win
+
+	Answer string `json:"answer,omitempty"` // lost, or locked after a loss; a win already shows it
+
+	Reveal   *dailyReveal `json:"reveal,omitempty"`       // finished sessions only (daily_reveal flag)
+	Practice string       `json:"practiceCode,omitempty"` // finished sessions only (daily_practice flag)
+	Tags     []string     `json:"tags,omitempty"`         // finished sessions only: the answer's category tags
+
+	Hint *dailyHint `json:"hint,omitempty"` // easy difficulty, in progress only
+}
+
+// dailyHint reveals one letter of the answer and its 0-based position.
+type dailyHint struct {
+	Position int    `json:"position"`
+	Letter   string `json:"letter"`
+}
+
+// dailyReveal lets a client verify a finished daily after the salt is published:
+// hex-decode Proof, check it equals HMAC-SHA256(salt, Date), and take its first
+// 8 bytes (big-endian) mod AnswerCount; the result is WordIndex, the position
+// of the answer in the date's answer list, or an index a few steps after it
+// (wrapping) when that answer had been used within DAILY_NO_REPEAT_DAYS.
+type dailyReveal struct {
+	Date        string `json:"date"`
+	WordIndex   int    `json:"wordIndex"`
+	AnswerCount int    `json:"answerCount"`
+	SaltVersion int    `json:"saltVersion"`
+	Proof       string `json:"proof,omitempty"` // omitted when the index was pinned under an older salt
 }
 
 // handleGuess validates and applies a guess for today's daily session.
 // - Ensures valid GameID and word.
-// - Rejects if no session or session finished.
+// - 404 if the user has no session today; 409 if the GameID doesn't match it.
+// - Returns "locked" if the session is finished.
 // - Validates against allowed word list.
 // - Scores guess using words.Score.
-// - Updates session state; persists result to DB if won.
+// - Updates session state; persists the result once won or lost (game.DefaultRows misses).
 func (d *dailyServer) handleGuess(w http.ResponseWriter, r *http.Request) {
 	uid, ok := d.userIDWithAnon(w, r)
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		writeError(w, http.StatusUnauthorized, "unauthorized", "unauthorized")
 		return
 	}
 
 	var p dailyGuessReq
 	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
-		http.Error(w, "bad request", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "bad_json", "bad request")
 		return
 	}
-	p.Word = strings.ToLower(strings.TrimSpace(p.Word))
+	p.Word = game.NormalizeGuess(p.Word)
 	if p.GameID == "" || len(p.Word) != 5 {
-		http.Error(w, "invalid", http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "invalid_guess", "invalid")
 		return
 	}
 
-	date, _, _ := d.dateKeyNow()
+	date := d.today()
 
 	// Find session.
-	key := uid + "|" + date
-	d.mu.Lock()
-	sess, ok := d.sessions[key]
-	d.mu.Unlock()
-	if !ok || sess.GameID != p.GameID {
-		http.Error(w, "no session", http.StatusConflict)
+	sess, ok, err := d.session(r.Context(), uid, date)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
 		return
 	}
-	if sess.Finished {
-		_ = json.NewEncoder(w).Encode(dailyGuessRes{Marks: []int{}, State: "locked", Guesses: sess.Guesses})
+	if !ok {
+		// No session for today: client should call /daily/new.
+		writeError(w, http.StatusNotFound, "no_session", "no session")
+		return
+	}
+	if sess.GameID != p.GameID {
+		// Session exists but the client holds a stale/foreign game ID: refresh.
+		writeError(w, http.StatusConflict, "game_id_mismatch", "game id mismatch")
+		return
+	}
+	enc := negotiateMarks(w, r)
+	d.mu.Lock()
+	finished := sess.Finished
+	d.mu.Unlock()
+	if finished {
+		d.writeLocked(w, r, sess, enc)
 		return
 	}
 
-	// Validate word.
+	// Validate word (every difficulty uses the allowed list).
 	if _, ok := words.Allowed()[p.Word]; !ok {
-		http.Error(w, "word not allowed", http.StatusBadRequest)
+		d.srv.writeLocalized(w, r, http.StatusBadRequest, "word_not_allowed", "word not allowed")
+		return
+	}
+	if sess.Difficulty == daily.DifficultyHard {
+		d.mu.Lock()
+		prior := append([]string(nil), sess.Words...)
+		d.mu.Unlock()
+		if err := words.CheckHardMode(sess.Answer, prior, p.Word); err != nil {
+			d.srv.writeLocalized(w, r, http.StatusBadRequest, "hard_mode", err.Error())
+			return
+		}
+	}
+	if game.IsGuessBlocked(p.Word) {
+		d.srv.writeLocalized(w, r, http.StatusBadRequest, "guess_blocked", game.ErrGuessBlocked.Error())
 		return
 	}
 
 	// Score guess.
 	marks := words.Score(p.Word, sess.Answer)
 
-	// Update in-memory session.
+	// Update in-memory session. Finished and Guesses are re-checked under the
+	// lock so concurrent guesses can't push a session past game.DefaultRows.
 	d.mu.Lock()
+	if sess.Finished || sess.Guesses >= game.DefaultRows {
+		d.mu.Unlock()
+		d.writeLocked(w, r, sess, enc)
+		return
+	}
+	sess.recordActivity(time.Now(), d.idleCap)
 	sess.Guesses++
+	sess.Words = append(sess.Words, p.Word)
 	won := allHits(marks)
-	if won {
-		sess.Finished = true
+	lost := !won && sess.Guesses >= game.DefaultRows
+	if won || lost {
+		sess.Finished, sess.Won = true, won
+	}
+	n := sess.Guesses
+	var hint *dailyHint
+	if sess.Difficulty == daily.DifficultyEasy {
+		hint = easyHint(sess.Answer, sess.Words)
 	}
 	d.mu.Unlock()
+	if err := d.saveSession(r.Context(), sess); err != nil {
+		log.Warn().Err(err).Str("date", date).Msg("daily: saving session failed")
+	}
 
 	// Persist and return.
-	if won {
-		elapsed := int(time.Since(sess.Start).Milliseconds())
-		_ = d.store.InsertResult(r.Context(), daily.Result{
+	if won || lost {
+		result := "won"
+		if lost {
+			result = "lost"
+		}
+		elapsed := int(sess.ActiveMs)
+		if d.idleCap <= 0 {
+			elapsed = int(time.Since(sess.Start).Milliseconds())
+		}
+		err := d.store.InsertResult(r.Context(), daily.Result{
 			UserID: uid, Date: date, WordIndex: sess.WordIndex, Guesses: sess.Guesses, ElapsedMs: elapsed,
+			Board: sess.Words, Difficulty: sess.Difficulty, Won: won, SaltVersion: sess.SaltVer,
+			Fingerprint: sess.Fingerprint,
 		})
-		_ = json.NewEncoder(w).Encode(dailyGuessRes{Marks: marks, State: "won", Guesses: sess.Guesses})
+		if err == nil {
+			d.live.Record(date, won)
+		}
+		ev := webhook.Event{Type: webhook.DailyCompleted, GameID: sess.GameID, Result: result, Guesses: sess.Guesses, Date: date, At: time.Now().UTC()}
+		if me, _ := r.Context().Value(ctxUserKey{}).(*authUser); me != nil {
+			ev.UserID = me.ID
+		}
+		d.srv.notify(ev)
+		if won && ev.UserID != "" {
+			d.earnFreeze(r.Context(), ev.UserID)
+		}
+		res := dailyGuessRes{Marks: enc.daily(marks), State: result, Guesses: sess.Guesses, GuessNumber: sess.Guesses, Remaining: game.DefaultRows - sess.Guesses, Reveal: d.reveal(sess), Practice: d.practiceCode(r.Context(), sess), Tags: words.Tags(sess.Answer)}
+		if lost {
+			res.Samples = d.lossSamples(sess)
+			res.Answer = sess.Answer
+		}
+		res.Evaluated = d.evaluated(p.Word)
+		_ = json.NewEncoder(w).Encode(res)
 		return
 	}
-	_ = json.NewEncoder(w).Encode(dailyGuessRes{Marks: marks, State: "in_progress", Guesses: sess.Guesses})
+	_ = json.NewEncoder(w).Encode(dailyGuessRes{Marks: enc.daily(marks), State: "in_progress", Guesses: n, GuessNumber: n, Remaining: game.DefaultRows - n, Evaluated: d.evaluated(p.Word), Hint: hint})
+}
+
+// writeLocked answers a guess on a finished session: no marks, the final
+// count, and (after a loss) the answer and samples.
+func (d *dailyServer) writeLocked(w http.ResponseWriter, r *http.Request, sess *dailySession, enc markEncoding) {
+	res := dailyGuessRes{Marks: enc.daily([]int{}), State: "locked", Guesses: sess.Guesses, Remaining: game.DefaultRows - sess.Guesses, Reveal: d.reveal(sess), Practice: d.practiceCode(r.Context(), sess), Tags: words.Tags(sess.Answer)}
+	if !sess.Won {
+		res.Samples = d.lossSamples(sess)
+		res.Answer = sess.Answer
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// easyHint returns the leftmost answer letter no guess has hit yet, or nil
+// when every position has been hit.
+func easyHint(answer string, guesses []string) *dailyHint {
+	for i := 0; i < len(answer); i++ {
+		hit := false
+		for _, g := range guesses {
+			if i < len(g) && g[i] == answer[i] {
+				hit = true
+				break
+			}
+		}
+		if !hit {
+			return &dailyHint{Position: i, Letter: answer[i : i+1]}
+		}
+	}
+	return nil
+}
+
+// evaluated returns the scored guess for the response, or "" while the
+// evaluated_guess flag is off.
+func (d *dailyServer) evaluated(word string) string {
+	if !d.srv.flags.Enabled(featureflags.EvaluatedGuess) {
+		return ""
+	}
+	return word
+}
+
+// lossSamples returns up to d.samples words from the session date's answer
+// pool (themes included, as for the puzzle itself) that were still consistent
+// with the session's guesses ("words you could have tried").
+// Only called for finished, lost sessions; the answer itself is never included.
+func (d *dailyServer) lossSamples(sess *dailySession) []string {
+	if d.samples <= 0 {
+		return nil
+	}
+	day, err := time.Parse("2006-01-02", sess.Date)
+	if err != nil {
+		return nil
+	}
+	d.mu.Lock()
+	guesses := append([]string(nil), sess.Words...)
+	d.mu.Unlock()
+	return words.SampleConsistent(d.pool(day), sess.Answer, guesses, d.samples, sess.GameID)
+}
+
+// reveal returns the verification fields for a finished session, or nil when
+// the daily_reveal flag is off. The proof is only included when the session's
+// index was pinned under the active salt, since older secrets aren't kept.
+func (d *dailyServer) reveal(sess *dailySession) *dailyReveal {
+	if !d.srv.flags.Enabled(featureflags.DailyReveal) {
+		return nil
+	}
+	day, err := time.Parse("2006-01-02", sess.Date)
+	if err != nil {
+		return nil
+	}
+	rv := &dailyReveal{
+		Date:        sess.Date,
+		WordIndex:   sess.WordIndex,
+		AnswerCount: len(d.pool(day)),
+		SaltVersion: sess.SaltVer,
+	}
+	if sess.SaltVer == d.salt.Version {
+		rv.Proof = d.salt.Proof(day, nil) // day is parsed from the date key
+	}
+	return rv
+}
+
+// practiceCode returns the session's practice link, creating it on first use.
+// "" unless the session is finished and daily_practice and short_links are on;
+// a failure to create the link is logged and also yields "".
+// Friends redeem it with POST /game/new {"link": code}, an ordinary classic
+// game; /daily never accepts links, so it can't earn daily credit.
+func (d *dailyServer) practiceCode(ctx context.Context, sess *dailySession) string {
+	if !d.srv.flags.Enabled(featureflags.DailyPractice) || !d.srv.flags.Enabled(featureflags.ShortLinks) {
+		return ""
+	}
+	d.mu.Lock()
+	finished, code, answer, userID := sess.Finished, sess.PracticeCode, sess.Answer, sess.UserID
+	d.mu.Unlock()
+	if !finished {
+		return ""
+	}
+	if code != "" {
+		return code
+	}
+	if !words.IsAllowed(answer) {
+		return "" // not playable as a classic game with the configured word lists
+	}
+	// Created outside the lock: it's a store round-trip. Concurrent first
+	// requests may each create a link; the first one stored wins.
+	slug, _, err := d.srv.createLink(ctx, game.ModeNormal, answer, nil, userID)
+	if err != nil {
+		log.Warn().Err(err).Str("date", sess.Date).Msg("daily: practice link failed")
+		return ""
+	}
+	d.mu.Lock()
+	if sess.PracticeCode != "" {
+		code = sess.PracticeCode
+		d.mu.Unlock()
+		return code
+	}
+	sess.PracticeCode = slug
+	d.mu.Unlock()
+	if err := d.saveSession(ctx, sess); err != nil {
+		log.Warn().Err(err).Str("date", sess.Date).Msg("daily: saving practice code failed")
+	}
+	return slug
+}
+
+// recordActivity credits the time since the last guess (or start) to ActiveMs,
+// capping each gap at idleCap so an idle tab doesn't inflate leaderboard time.
+// Caller must hold dailyServer.mu.
+func (sess *dailySession) recordActivity(now time.Time, idleCap time.Duration) {
+	gap := now.Sub(sess.LastSeen)
+	if gap < 0 {
+		gap = 0
+	}
+	if idleCap > 0 && gap > idleCap {
+		gap = idleCap
+	}
+	sess.ActiveMs += gap.Milliseconds()
+	sess.LastSeen = now
+}
+
+// lettersOnly reports whether s consists only of lowercase a–z.
+func lettersOnly(s string) bool {
+	for _, r := range s {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
 }
 
 // allHits reports true if every mark == 2 (hit).
@@ -232,20 +886,621 @@ func allHits(m []int) bool {
 
 // lbRes is returned by /daily/leaderboard.
 type lbRes struct {
-	Date string        `json:"date"`
-	Top  []daily.LBRow `json:"top"`
+	Date       string        `json:"date"`
+	Difficulty string        `json:"difficulty,omitempty"`
+	Top        []daily.LBRow `json:"top"`      // wins only
+	Attempts   int           `json:"attempts"` // everyone who finished the day, won or lost
+	Wins       int           `json:"wins"`
 }
 
 // handleLeaderboard returns the leaderboard for the given date (default today).
+// Optional ?difficulty=easy|normal|hard segments the board by difficulty.
 func (d *dailyServer) handleLeaderboard(w http.ResponseWriter, r *http.Request) {
 	date := r.URL.Query().Get("date")
 	if date == "" {
-		date, _, _ = d.dateKeyNow()
+		date = d.today()
 	}
-	rows, err := d.store.Leaderboard(r.Context(), date, 20)
+	difficulty := r.URL.Query().Get("difficulty")
+	if difficulty != "" && !daily.ValidDifficulty(difficulty) {
+		writeError(w, http.StatusBadRequest, "invalid_difficulty", "invalid difficulty")
+		return
+	}
+	rows, err := d.store.Leaderboard(r.Context(), date, difficulty, 20)
 	if err != nil {
-		http.Error(w, "server error", http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
 		return
 	}
-	_ = json.NewEncoder(w).Encode(lbRes{Date: date, Top: rows})
+	attempts, wins, err := d.store.Participation(r.Context(), date)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
+		return
+	}
+	_ = json.NewEncoder(w).Encode(lbRes{Date: date, Difficulty: difficulty, Top: rows, Attempts: attempts, Wins: wins})
+}
+
+// -----------------------------------------------------------------------------
+// /daily/today
+
+// todayRes is returned by /daily/today.
+type todayRes struct {
+	Date      string `json:"date"`
+	WordIndex int    `json:"wordIndex"` // today's pinned index into the answer list
+	Puzzle    int    `json:"puzzle"`    // 1-based puzzle number (as in share headers)
+	Played    bool   `json:"played"`    // the caller has a stored result for today
+	*todayLive
+}
+
+// todayLive is the live tally part of /daily/today (daily_live flag only).
+type todayLive struct {
+	Attempts  int     `json:"attempts"` // finished today, wins and losses
+	Wins      int     `json:"wins"`
+	SolveRate float64 `json:"solveRate"` // wins / attempts (0 before anyone finishes)
+	Playing   int     `json:"playing"`   // sessions started today and not yet finished
+}
+
+// handleToday reports today's date key, word index and puzzle number and
+// whether the caller already played it, without creating a session or minting
+// an anon cookie. The index is pinned as on /daily/new; the answer is never
+// included. With the daily_live flag on it adds the live solve rate from the
+// in-memory tally, which is seeded from daily_results at startup and updated
+// as results are stored. Counts are per instance.
+func (d *dailyServer) handleToday(w http.ResponseWriter, r *http.Request) {
+	date, idx, _, _, err := d.puzzleToday(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
+		return
+	}
+	res := todayRes{Date: date, WordIndex: idx, Puzzle: daily.PuzzleNumber(date, d.epoch)}
+
+	uid := ""
+	if me, _ := r.Context().Value(ctxUserKey{}).(*authUser); me != nil {
+		uid = me.ID
+	} else if c, err := r.Cookie(anonCookieName); err == nil {
+		uid = c.Value
+	}
+	if uid != "" {
+		played, err := d.store.AlreadyPlayed(r.Context(), uid, date)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "server_error", "server error")
+			return
+		}
+		res.Played = played
+	}
+
+	if d.srv.flags.Enabled(featureflags.DailyLive) {
+		live := &todayLive{}
+		live.Attempts, live.Wins = d.live.Snapshot(date)
+		if live.Attempts > 0 {
+			live.SolveRate = float64(live.Wins) / float64(live.Attempts)
+		}
+		d.mu.Lock()
+		for _, sess := range d.sessions {
+			if sess.Date == date && !sess.Finished {
+				live.Playing++
+			}
+		}
+		d.mu.Unlock()
+		res.todayLive = live
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// -----------------------------------------------------------------------------
+// /daily/reveal-time
+
+// revealTimeRes is returned by /daily/reveal-time.
+type revealTimeRes struct {
+	Date        string    `json:"date"`        // today's date key
+	Next        string    `json:"next"`        // the date key that starts at RevealAt
+	RevealAt    time.Time `json:"revealAt"`    // next rollover, UTC
+	Local       string    `json:"local"`       // RevealAt in DAILY_TIMEZONE (RFC 3339 with offset)
+	Timezone    string    `json:"timezone"`    // IANA name of the rollover zone
+	SecondsLeft int64     `json:"secondsLeft"` // whole seconds until RevealAt
+}
+
+// handleRevealTime reports the next daily rollover (local midnight in
+// DAILY_TIMEZONE). It touches no storage.
+func (d *dailyServer) handleRevealTime(w http.ResponseWriter, r *http.Request) {
+	if !d.srv.flags.Enabled(featureflags.DailyRevealTime) {
+		writeError(w, http.StatusNotFound, "not_found", "not found")
+		return
+	}
+	now := time.Now()
+	at := daily.NextRollover(now, d.loc)
+	zone := "UTC"
+	if d.loc != nil {
+		zone = d.loc.String()
+	}
+	_ = json.NewEncoder(w).Encode(revealTimeRes{
+		Date:        daily.DateKey(now, d.loc),
+		Next:        daily.DateKey(at, d.loc),
+		RevealAt:    at.UTC(),
+		Local:       at.Format(time.RFC3339),
+		Timezone:    zone,
+		SecondsLeft: int64(at.Sub(now) / time.Second),
+	})
+}
+
+// -----------------------------------------------------------------------------
+// /daily/recap
+
+// recapRes is returned by /daily/recap.
+type recapRes struct {
+	Date         string       `json:"date"`
+	Puzzle       int          `json:"puzzle"`
+	Answer       string       `json:"answer,omitempty"`     // past dates only
+	Difficulty   *float64     `json:"difficulty,omitempty"` // answer difficulty 0–100; past dates only
+	Par          *int         `json:"par,omitempty"`        // solver par (daily_par flag); past dates only
+	Attempts     int          `json:"attempts"`
+	Wins         int          `json:"wins"`
+	SolveRate    float64      `json:"solveRate"`    // wins / attempts (0 when nobody played)
+	Distribution map[int]int  `json:"distribution"` // guesses → winners
+	Fastest      *daily.LBRow `json:"fastest,omitempty"`
+}
+
+// handleRecap summarises a day's results (?date=, default today).
+// The answer and its difficulty are only included once the day is over, and
+// only for dates that were actually served. Future dates are rejected.
+func (d *dailyServer) handleRecap(w http.ResponseWriter, r *http.Request) {
+	if !d.srv.flags.Enabled(featureflags.DailyRecap) {
+		writeError(w, http.StatusNotFound, "not_found", "not found")
+		return
+	}
+	today := d.today()
+	date := r.URL.Query().Get("date")
+	if date == "" {
+		date = today
+	}
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil || date > today {
+		writeError(w, http.StatusBadRequest, "invalid_date", "invalid date")
+		return
+	}
+
+	ctx := r.Context()
+	res := recapRes{Date: date, Puzzle: daily.PuzzleNumber(date, d.epoch)}
+	if res.Attempts, res.Wins, err = d.store.Participation(ctx, date); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
+		return
+	}
+	if res.Attempts > 0 {
+		res.SolveRate = float64(res.Wins) / float64(res.Attempts)
+	}
+	if res.Distribution, err = d.store.GuessDistribution(ctx, date); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
+		return
+	}
+	top, err := d.store.Leaderboard(ctx, date, "", 1)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
+		return
+	}
+	if len(top) > 0 {
+		res.Fastest = &top[0]
+	}
+
+	if date < today {
+		idx, _, err := d.store.PinnedWordIndex(ctx, date)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusInternalServerError, "server_error", "server error")
+			return
+		}
+		if err == nil {
+			if answer := d.answerAt(day, idx); answer != "" {
+				score := d.srv.scorer().Difficulty(answer)
+				res.Answer, res.Difficulty = answer, &score
+				if d.srv.flags.Enabled(featureflags.DailyPar) {
+					res.Par = d.parFor(ctx, date, day, answer)
+				}
+			}
+		}
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// dailyVerifyRes is returned by GET /daily/verify. Proof and WordIndex relate as in
+// dailyReveal; SaltCommitment is SHA-256(salt), so once the salt is published
+// it can be matched and every proof recomputed.
+type dailyVerifyRes struct {
+	Date           string `json:"date"`
+	WordIndex      int    `json:"wordIndex"`
+	AnswerCount    int    `json:"answerCount"`
+	SaltVersion    int    `json:"saltVersion"`
+	Proof          string `json:"proof,omitempty"`          // omitted when the index was pinned under an older salt
+	SaltCommitment string `json:"saltCommitment,omitempty"` // likewise
+}
+
+// handleVerify returns the pinned word index of a past date (?date=, required)
+// with the material to audit it. 400 for today or later, 404 for a date that
+// was never served.
+func (d *dailyServer) handleVerify(w http.ResponseWriter, r *http.Request) {
+	if !d.srv.flags.Enabled(featureflags.DailyVerify) {
+		writeError(w, http.StatusNotFound, "not_found", "not found")
+		return
+	}
+	date := r.URL.Query().Get("date")
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil || date >= d.today() {
+		writeError(w, http.StatusBadRequest, "invalid_date", "invalid date")
+		return
+	}
+	idx, version, err := d.store.PinnedWordIndex(r.Context(), date)
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, "date_not_served", "date not served")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
+		return
+	}
+	res := dailyVerifyRes{
+		Date:        date,
+		WordIndex:   idx,
+		AnswerCount: len(d.pool(day)),
+		SaltVersion: version,
+	}
+	if version == d.salt.Version {
+		res.Proof = d.salt.Proof(day, nil) // day is parsed from the date key
+		res.SaltCommitment = d.salt.Commitment()
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// parFor returns a past date's stored par, computing and storing it when the
+// date was served before daily_par was on. Nil on a store error.
+func (d *dailyServer) parFor(ctx context.Context, date string, day time.Time, answer string) *int {
+	par, ok, err := d.store.Par(ctx, date)
+	if err != nil {
+		log.Warn().Err(err).Str("date", date).Msg("daily: loading par")
+		return nil
+	}
+	if !ok {
+		par = analysis.SolverPar(d.pool(day), d.openers, answer)
+		if err := d.store.SetPar(ctx, date, par); err != nil {
+			log.Warn().Err(err).Str("date", date).Msg("daily: storing par")
+		}
+	}
+	return &par
+}
+
+// -----------------------------------------------------------------------------
+// /daily/rank-history
+
+// maxRankHistoryDays caps the ?days= window for /daily/rank-history.
+const maxRankHistoryDays = 90
+
+// rankPoint is the caller's position on one day's leaderboard.
+type rankPoint struct {
+	Date string `json:"date"`
+	Rank int    `json:"rank"`
+}
+
+// mineRow is one entry in /daily/mine.
+type mineRow struct {
+	Date       string `json:"date"`
+	Guesses    int    `json:"guesses"`
+	ElapsedMs  int    `json:"elapsedMs"`
+	Won        bool   `json:"won"`
+	Difficulty string `json:"difficulty"`
+}
+
+// handleMine lists the caller's daily results, newest first, optionally
+// limited to ?from= and/or ?to= (inclusive, YYYY-MM-DD).
+func (d *dailyServer) handleMine(w http.ResponseWriter, r *http.Request) {
+	uid, ok := d.userIDWithAnon(w, r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "unauthorized")
+		return
+	}
+	q := r.URL.Query()
+	from, to := q.Get("from"), q.Get("to")
+	for _, v := range []string{from, to} {
+		if _, err := time.Parse("2006-01-02", v); v != "" && err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_date", "invalid date")
+			return
+		}
+	}
+	results, err := d.store.Results(r.Context(), uid, from, to)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
+		return
+	}
+	out := make([]mineRow, 0, len(results))
+	for _, res := range results {
+		out = append(out, mineRow{Date: res.Date, Guesses: res.Guesses, ElapsedMs: res.ElapsedMs, Won: res.Won, Difficulty: res.Difficulty})
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"results": out})
+}
+
+// rankHistoryRes is returned by /daily/rank-history.
+type rankHistoryRes struct {
+	Days    int         `json:"days"`
+	History []rankPoint `json:"history"` // days not played or lost are omitted
+}
+
+// handleRankHistory returns the caller's daily rank for each day played in the
+// last ?days= days (default 30, max 90), oldest first.
+func (d *dailyServer) handleRankHistory(w http.ResponseWriter, r *http.Request) {
+	me, _ := r.Context().Value(ctxUserKey{}).(*authUser)
+	if me == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "unauthorized")
+		return
+	}
+	days := 30
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "invalid_days", "invalid days")
+			return
+		}
+		days = min(n, maxRankHistoryDays)
+	}
+
+	since := daily.DateKey(time.Now().AddDate(0, 0, -(days-1)), d.loc)
+	dates, err := d.store.PlayedDates(r.Context(), me.ID, since)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
+		return
+	}
+	out := rankHistoryRes{Days: days, History: []rankPoint{}}
+	for _, date := range dates {
+		rank, err := d.store.RankFor(r.Context(), me.ID, date)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue // lost that day: not ranked
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "server_error", "server error")
+			return
+		}
+		out.History = append(out.History, rankPoint{Date: date, Rank: rank})
+	}
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+// -----------------------------------------------------------------------------
+// /daily/streak
+
+// streakRes is returned by /daily/streak.
+type streakRes struct {
+	Streak      int      `json:"streak"`
+	PlayStreak  int      `json:"playStreak"`  // consecutive days played, won or lost (daily.Store.CurrentStreak)
+	Freezes     int      `json:"freezes"`     // unspent
+	FrozenDates []string `json:"frozenDates"` // missed days bridged by a freeze, oldest first
+}
+
+// streak computes the user's current win streak. With the streak_freeze flag
+// on, any freezes it needs to bridge missed days are spent (and persisted)
+// first; with it off, freezes are neither spent nor honoured.
+func (d *dailyServer) streak(ctx context.Context, uid string) (streakRes, error) {
+	days, err := d.store.StreakHistory(ctx, uid)
+	if err != nil {
+		return streakRes{}, err
+	}
+	if !d.srv.flags.Enabled(featureflags.StreakFreeze) {
+		n, _ := daily.Streak(days, nil, d.today(), 0)
+		return streakRes{Streak: n, FrozenDates: []string{}}, nil
+	}
+
+	balance, frozen, err := d.store.Freezes(ctx, uid)
+	if err != nil {
+		return streakRes{}, err
+	}
+	n, spend := daily.Streak(days, frozen, d.today(), balance)
+	if len(spend) > 0 {
+		if err := d.store.SpendFreezes(ctx, uid, spend); err != nil {
+			return streakRes{}, err
+		}
+		balance -= len(spend)
+		for _, date := range spend {
+			frozen[date] = true
+		}
+	}
+
+	out := streakRes{Streak: n, Freezes: balance, FrozenDates: []string{}}
+	for date := range frozen {
+		out.FrozenDates = append(out.FrozenDates, date)
+	}
+	sort.Strings(out.FrozenDates)
+	return out, nil
+}
+
+// earnFreeze grants a freeze when a win lands the user's streak on a multiple
+// of freezeEvery. Failures are logged; they never fail the guess.
+func (d *dailyServer) earnFreeze(ctx context.Context, uid string) {
+	if !d.srv.flags.Enabled(featureflags.StreakFreeze) || d.freezeEvery <= 0 {
+		return
+	}
+	st, err := d.streak(ctx, uid)
+	if err != nil {
+		log.Warn().Err(err).Str("user", uid).Msg("daily: streak lookup failed")
+		return
+	}
+	if st.Streak == 0 || st.Streak%d.freezeEvery != 0 {
+		return
+	}
+	if _, err := d.store.AddFreeze(ctx, uid, d.freezeMax); err != nil {
+		log.Warn().Err(err).Str("user", uid).Msg("daily: granting streak freeze failed")
+	}
+}
+
+// handleStreak returns the caller's current streak and freeze balance.
+func (d *dailyServer) handleStreak(w http.ResponseWriter, r *http.Request) {
+	me, _ := r.Context().Value(ctxUserKey{}).(*authUser)
+	if me == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "unauthorized")
+		return
+	}
+	out, err := d.streak(r.Context(), me.ID)
+	if err == nil {
+		out.PlayStreak, err = d.store.CurrentStreak(r.Context(), me.ID)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
+		return
+	}
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+// -----------------------------------------------------------------------------
+// /daily/preferences
+
+// prefsReq/Res are the payloads for /daily/preferences.
+type prefsReq struct {
+	Difficulty string `json:"difficulty"`
+}
+type prefsRes struct {
+	Difficulty string `json:"difficulty"`
+}
+
+// handleGetPreferences returns the caller's stored daily difficulty.
+func (d *dailyServer) handleGetPreferences(w http.ResponseWriter, r *http.Request) {
+	me, _ := r.Context().Value(ctxUserKey{}).(*authUser)
+	if me == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "unauthorized")
+		return
+	}
+	diff, err := d.store.DifficultyFor(r.Context(), me.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
+		return
+	}
+	_ = json.NewEncoder(w).Encode(prefsRes{Difficulty: diff})
+}
+
+// handleSetPreferences updates the caller's daily difficulty.
+// Takes effect from the next daily session; today's in-progress session keeps its level.
+func (d *dailyServer) handleSetPreferences(w http.ResponseWriter, r *http.Request) {
+	me, _ := r.Context().Value(ctxUserKey{}).(*authUser)
+	if me == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "unauthorized")
+		return
+	}
+	var p prefsReq
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_json", "bad request")
+		return
+	}
+	p.Difficulty = strings.ToLower(strings.TrimSpace(p.Difficulty))
+	if !daily.ValidDifficulty(p.Difficulty) {
+		writeError(w, http.StatusBadRequest, "invalid_difficulty", "invalid difficulty")
+		return
+	}
+	if err := d.store.SetDifficulty(r.Context(), me.ID, p.Difficulty); err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
+		return
+	}
+	_ = json.NewEncoder(w).Encode(prefsRes{Difficulty: p.Difficulty})
+}
+
+// -----------------------------------------------------------------------------
+// /daily/share
+
+// shareRes is returned by /daily/share.
+type shareRes struct {
+	Date    string `json:"date"`
+	Puzzle  int    `json:"puzzle"`
+	Guesses int    `json:"guesses"`
+	Share   string `json:"share"` // header line + emoji grid, no letters
+}
+
+// handleShare rebuilds the share grid for the caller's won daily (default today).
+// - Only dates within the configured grace window are re-opened.
+// - Marks are recomputed from the stored board; the answer never leaves the server.
+func (d *dailyServer) handleShare(w http.ResponseWriter, r *http.Request) {
+	uid, ok := d.userIDWithAnon(w, r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "unauthorized")
+		return
+	}
+	today := d.today()
+	date := r.URL.Query().Get("date")
+	if date == "" {
+		date = today
+	}
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_date", "invalid date")
+		return
+	}
+	todayT, _ := time.Parse("2006-01-02", today)
+	if age := int(todayT.Sub(day).Hours() / 24); age < 0 || age > d.grace {
+		writeError(w, http.StatusGone, "share_window_closed", "share window closed")
+		return
+	}
+
+	res, err := d.store.GetResult(r.Context(), uid, date)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && len(res.Board) == 0) {
+		writeError(w, http.StatusNotFound, "no_completed_daily", "no completed daily")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
+		return
+	}
+	answer := d.answerAt(day, res.WordIndex)
+	if answer == "" {
+		writeError(w, http.StatusInternalServerError, "server_error", "server error")
+		return
+	}
+
+	puzzle := daily.PuzzleNumber(res.Date, d.epoch)
+	score := strconv.Itoa(res.Guesses)
+	if !res.Won {
+		score = "X" // out of guesses, as in the original game
+	}
+	lines := []string{fmt.Sprintf("Wordle Daily #%d %s/%d", puzzle, score, game.DefaultRows)}
+	lines = append(lines, "")
+	for _, g := range res.Board {
+		lines = append(lines, words.EmojiRow(words.Score(g, answer)))
+	}
+	_ = json.NewEncoder(w).Encode(shareRes{
+		Date: res.Date, Puzzle: puzzle, Guesses: res.Guesses, Share: strings.Join(lines, "\n"),
+	})
+}
+
+// answerAt returns the lowercase answer for a word index stored on the given day,
+// or "" if out of range. Uses the pool that was in effect that day (themes included).
+func (d *dailyServer) answerAt(day time.Time, idx int) string {
+	answers := d.pool(day)
+	if idx < 0 || idx >= len(answers) {
+		return ""
+	}
+	return answers[idx]
+}
+
+// maxPools bounds the pool cache; past dates (no-repeat lookups, history)
+// are cheap to rebuild, so the cache is simply cleared when it fills up.
+const maxPools = 64
+
+// pool returns the effective daily answer pool for day (the theme window
+// applied, lowercased), built once per date and reused until the word lists
+// are reloaded or a feature flag changes. Word indices are positions in this
+// slice, so it must stay the same for a date between those events.
+func (d *dailyServer) pool(day time.Time) []string {
+	date := day.Format("2006-01-02")
+	key := [2]uint64{words.Generation(), d.srv.flags.Version()}
+	d.mu.Lock()
+	if d.poolsKey != key || len(d.pools) >= maxPools {
+		d.pools, d.poolsKey = nil, key
+	}
+	answers, ok := d.pools[date]
+	d.mu.Unlock()
+	if ok {
+		return answers
+	}
+
+	src := words.DailyAnswers(day)
+	answers = make([]string, len(src))
+	for i, w := range src {
+		answers[i] = strings.ToLower(w)
+	}
+	d.mu.Lock()
+	if d.poolsKey == key {
+		if d.pools == nil {
+			d.pools = make(map[string][]string)
+		}
+		d.pools[date] = answers
+	}
+	d.mu.Unlock()
+	return answers
 }
@@ -0,0 +1,997 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/daily"
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+	"github.com/robalobadob/wordle/apps/go-server/internal/store"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// today is the daily date key under the default (UTC) rollover.
+func today() string { return daily.DateKey(time.Now(), time.UTC) }
+
+// insertDaily stores a finished daily result directly.
+func (ts *testServer) insertDaily(r daily.Result) {
+	ts.t.Helper()
+	if r.Difficulty == "" {
+		r.Difficulty = daily.DifficultyNormal
+	}
+	if err := daily.NewStore(ts.db, time.UTC).InsertResult(context.Background(), r); err != nil {
+		ts.t.Fatalf("insert daily result: %v", err)
+	}
+}
+
+// startDaily calls /daily/new and returns the game ID and today's answer
+// (read back from the stored session).
+func (c *testClient) startDaily(ts *testServer) (gameID, answer string) {
+	c.t.Helper()
+	var res struct {
+		GameID string `json:"gameId"`
+	}
+	if status := c.call("POST", "/daily/new", nil, &res); status != http.StatusOK || res.GameID == "" {
+		c.t.Fatalf("/daily/new: status %d, game %q", status, res.GameID)
+	}
+	var idx int
+	var date string
+	if err := ts.db.QueryRow(`SELECT word_index, date FROM daily_sessions WHERE game_id=?`, res.GameID).Scan(&idx, &date); err != nil {
+		c.t.Fatalf("reading session: %v", err)
+	}
+	day, _ := time.Parse("2006-01-02", date)
+	return res.GameID, words.DailyAnswers(day)[idx]
+}
+
+// dailyGuess submits word and decodes the response.
+func (c *testClient) dailyGuess(gameID, word string) (int, dailyGuessRes) {
+	c.t.Helper()
+	var res dailyGuessRes
+	status := c.call("POST", "/daily/guess", map[string]string{"gameId": gameID, "word": word}, &res)
+	return status, res
+}
+
+// restart starts a second server on ts's database, standing in for a
+// restarted instance (fresh memory, fresh dailyServer).
+func (ts *testServer) restart() *testServer {
+	ts.t.Helper()
+	restarted := New(store.NewMemoryStore(), ts.db)
+	hs := httptest.NewServer(restarted.Router())
+	ts.t.Cleanup(func() {
+		hs.Close()
+		restarted.Close()
+	})
+	return &testServer{Server: restarted, t: ts.t, url: hs.URL}
+}
+
+// wrongGuesses returns n allowed words that differ from answer.
+func wrongGuesses(answer string, n int) []string {
+	var out []string
+	for _, w := range words.Answers() {
+		if w != answer && len(out) < n {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+func TestDailyShareFromStoredWin(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client()
+	uid := c.signup("sharer")
+	answers := words.Answers()
+	idx := 3
+	answer := answers[idx]
+	board := append(wrongGuesses(answer, 2), answer)
+	ts.insertDaily(daily.Result{UserID: uid, Date: today(), WordIndex: idx, Guesses: 3, ElapsedMs: 5000, Board: board, Won: true})
+
+	var res shareRes
+	if status := c.call("GET", "/daily/share?date="+today(), nil, &res); status != http.StatusOK {
+		t.Fatalf("share: status %d", status)
+	}
+	lines := strings.Split(res.Share, "\n")
+	if !strings.HasPrefix(lines[0], "Wordle Daily #") || !strings.HasSuffix(lines[0], " 3/6") {
+		t.Fatalf("header = %q", lines[0])
+	}
+	if len(lines) != 2+len(board) {
+		t.Fatalf("share has %d lines, want header, blank, and %d rows:\n%s", len(lines), len(board), res.Share)
+	}
+	for i, g := range board {
+		if want := words.EmojiRow(words.Score(g, answer)); lines[2+i] != want {
+			t.Errorf("row %d = %q, want %q", i, lines[2+i], want)
+		}
+	}
+	if lines[len(lines)-1] != strings.Repeat("🟩", 5) {
+		t.Errorf("last row = %q, want all hits", lines[len(lines)-1])
+	}
+	if strings.Contains(strings.ToLower(res.Share), answer) {
+		t.Error("share text reveals the answer")
+	}
+
+	// Nothing stored for another player.
+	other := ts.client()
+	other.signup("nobody")
+	if status, raw := other.do("GET", "/daily/share", nil); status != http.StatusNotFound {
+		t.Fatalf("share without a result: status %d %s", status, raw)
+	}
+}
+
+// writeTheme points THEME_ANSWERS_FILE at a file holding list, active today.
+func writeTheme(t *testing.T, list string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "theme.txt")
+	if err := os.WriteFile(path, []byte(list), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	today := time.Now().UTC().Format("2006-01-02")
+	t.Setenv("THEME_ANSWERS_FILE", path)
+	t.Setenv("THEME_START", today)
+	t.Setenv("THEME_END", today)
+}
+
+func TestDailyPoolCachedUntilReloadOrFlagChange(t *testing.T) {
+	// Registered first so it runs after the env is restored.
+	t.Cleanup(func() { _, _ = words.Reload() })
+	writeTheme(t, "crane\nslate\n")
+	if _, err := words.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	d := &dailyServer{srv: &Server{flags: featureflags.New()}}
+	day, _ := time.Parse("2006-01-02", time.Now().UTC().Format("2006-01-02"))
+
+	first := d.pool(day)
+	if len(first) != 2 || first[0] != "crane" {
+		t.Fatalf("pool = %v, want the themed list", first)
+	}
+	if again := d.pool(day); &again[0] != &first[0] {
+		t.Fatal("pool rebuilt within the same day")
+	}
+
+	// A changed theme file is only picked up by a reload.
+	writeTheme(t, "trace\n")
+	if got := d.pool(day); &got[0] != &first[0] {
+		t.Fatal("pool rebuilt without a reload")
+	}
+	if _, err := words.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	reloaded := d.pool(day)
+	if len(reloaded) != 1 || reloaded[0] != "trace" {
+		t.Fatalf("pool after reload = %v, want [trace]", reloaded)
+	}
+
+	if err := d.srv.flags.Set(featureflags.DailyPar, true); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.pool(day); &got[0] == &reloaded[0] {
+		t.Fatal("pool not rebuilt after a flag change")
+	}
+}
+
+func TestDailyGuessMissingVsMismatchedSession(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client()
+	c.signup("stale")
+	guess := words.Answers()[0]
+
+	status, raw := c.do("POST", "/daily/guess", map[string]string{"gameId": "nope", "word": guess})
+	if status != http.StatusNotFound || errorCode(raw) != "no_session" {
+		t.Fatalf("guess before /daily/new: %d %s, want 404 no_session", status, raw)
+	}
+
+	gameID, _ := c.startDaily(ts)
+	status, raw = c.do("POST", "/daily/guess", map[string]string{"gameId": gameID + "x", "word": guess})
+	if status != http.StatusConflict || errorCode(raw) != "game_id_mismatch" {
+		t.Fatalf("guess with a stale game id: %d %s, want 409 game_id_mismatch", status, raw)
+	}
+	if status, _ := c.dailyGuess(gameID, guess); status != http.StatusOK {
+		t.Fatalf("guess with the right game id: status %d", status)
+	}
+}
+
+// setDifficulty stores the caller's daily difficulty preference.
+func (c *testClient) setDifficulty(difficulty string) {
+	c.t.Helper()
+	if status, raw := c.do("POST", "/daily/preferences", prefsReq{Difficulty: difficulty}); status != http.StatusOK {
+		c.t.Fatalf("set difficulty %s: %d %s", difficulty, status, raw)
+	}
+}
+
+func TestDailyHardPreference(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client()
+	uid := c.signup("hardliner")
+	c.setDifficulty("HARD")
+	var prefs prefsRes
+	if c.call("GET", "/daily/preferences", nil, &prefs); prefs.Difficulty != daily.DifficultyHard {
+		t.Fatalf("preference = %q, want hard", prefs.Difficulty)
+	}
+
+	var started newRes
+	c.call("POST", "/daily/new", nil, &started)
+	if started.Difficulty != daily.DifficultyHard {
+		t.Fatalf("new session difficulty = %q, want hard", started.Difficulty)
+	}
+	gameID, answer := c.startDaily(ts)
+
+	// A first guess that reveals something, then one that ignores it.
+	var first, cheat string
+	for _, g := range words.Answers() {
+		if g == answer {
+			continue
+		}
+		if first == "" {
+			if strings.ContainsAny(g, answer) {
+				first = g
+			}
+		} else if words.CheckHardMode(answer, []string{first}, g) != nil {
+			cheat = g
+			break
+		}
+	}
+	if first == "" || cheat == "" {
+		t.Skip("no suitable guesses in the daily list")
+	}
+	c.dailyGuess(gameID, first)
+	if status, raw := c.do("POST", "/daily/guess", map[string]string{"gameId": gameID, "word": cheat}); status != http.StatusBadRequest || errorCode(raw) != "hard_mode" {
+		t.Fatalf("guess ignoring a hint: %d %s, want 400 hard_mode", status, raw)
+	}
+	if _, res := c.dailyGuess(gameID, answer); res.State != "won" || res.Guesses != 2 {
+		t.Fatalf("winning guess: state %q after %d guesses, want won after 2", res.State, res.Guesses)
+	}
+	stored, err := daily.NewStore(ts.db, time.UTC).GetResult(context.Background(), uid, today())
+	if err != nil || stored.Difficulty != daily.DifficultyHard {
+		t.Fatalf("stored result = %+v, %v; want difficulty hard", stored, err)
+	}
+}
+
+func TestDailyEasyPreference(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client()
+	c.signup("easygoer")
+	c.setDifficulty("easy")
+	if status, raw := c.do("POST", "/daily/preferences", prefsReq{Difficulty: "nightmare"}); status != http.StatusBadRequest {
+		t.Fatalf("unknown difficulty: %d %s, want 400", status, raw)
+	}
+	gameID, answer := c.startDaily(ts)
+
+	if status, raw := c.do("POST", "/daily/guess", map[string]string{"gameId": gameID, "word": "qzxvj"}); status != http.StatusBadRequest || errorCode(raw) != "word_not_allowed" {
+		t.Fatalf("easy guess off the allowed list: %d %s, want 400 word_not_allowed", status, raw)
+	}
+	miss := wrongGuesses(answer, 1)[0]
+	_, res := c.dailyGuess(gameID, miss)
+	want := easyHint(answer, []string{miss})
+	if res.Hint == nil || want == nil || *res.Hint != *want {
+		t.Fatalf("hint = %+v, want %+v", res.Hint, want)
+	}
+	if answer[res.Hint.Position:res.Hint.Position+1] != res.Hint.Letter || miss[res.Hint.Position] == answer[res.Hint.Position] {
+		t.Fatalf("hint %+v is not an unhit answer letter", res.Hint)
+	}
+}
+
+func TestRecordActivityCapsIdleGaps(t *testing.T) {
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		idleCap time.Duration
+		want    int64
+	}{
+		{2 * time.Minute, (30*time.Second + 2*time.Minute + 10*time.Second).Milliseconds()},
+		{0, (30*time.Second + 3*time.Hour + 10*time.Second).Milliseconds()},
+	} {
+		sess := &dailySession{Start: start, LastSeen: start}
+		now := start
+		for _, gap := range []time.Duration{30 * time.Second, 3 * time.Hour, 10 * time.Second} {
+			now = now.Add(gap)
+			sess.recordActivity(now, tc.idleCap)
+		}
+		if sess.ActiveMs != tc.want {
+			t.Errorf("idleCap %v: ActiveMs = %d, want %d", tc.idleCap, sess.ActiveMs, tc.want)
+		}
+		if !sess.LastSeen.Equal(now) {
+			t.Errorf("idleCap %v: LastSeen = %v, want %v", tc.idleCap, sess.LastSeen, now)
+		}
+	}
+
+	// A clock step backwards credits nothing.
+	sess := &dailySession{LastSeen: start}
+	sess.recordActivity(start.Add(-time.Minute), time.Minute)
+	if sess.ActiveMs != 0 {
+		t.Fatalf("backwards step credited %dms", sess.ActiveMs)
+	}
+}
+
+func TestDailyRankHistory(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client()
+	uid := c.signup("climber")
+	day := func(ago int) string { return daily.DateKey(time.Now().AddDate(0, 0, -ago), time.UTC) }
+	for _, r := range []daily.Result{
+		{UserID: uid, Date: day(40), Guesses: 3, ElapsedMs: 1000, Won: true}, // outside the window
+		{UserID: "rival-1", Date: day(40), Guesses: 3, ElapsedMs: 500, Won: true},
+
+		{UserID: uid, Date: day(5), Guesses: 4, ElapsedMs: 5000, Won: true},
+		{UserID: "rival-1", Date: day(5), Guesses: 4, ElapsedMs: 3000, Won: true},
+		{UserID: "rival-2", Date: day(5), Guesses: 3, ElapsedMs: 5000, Won: true}, // same time, fewer guesses
+		{UserID: "rival-3", Date: day(5), Guesses: 6, ElapsedMs: 100, Won: false}, // losses don't rank
+
+		{UserID: uid, Date: day(2), Guesses: 6, ElapsedMs: 9000, Won: false},
+		{UserID: "rival-1", Date: day(2), Guesses: 2, ElapsedMs: 2000, Won: true},
+
+		{UserID: uid, Date: day(0), Guesses: 2, ElapsedMs: 1000, Won: true},
+		{UserID: "rival-1", Date: day(0), Guesses: 2, ElapsedMs: 2000, Won: true},
+	} {
+		ts.insertDaily(r)
+	}
+
+	var res rankHistoryRes
+	if status := c.call("GET", "/daily/rank-history?days=30", nil, &res); status != http.StatusOK {
+		t.Fatalf("rank-history: status %d", status)
+	}
+	want := []rankPoint{{Date: day(5), Rank: 3}, {Date: day(0), Rank: 1}}
+	if len(res.History) != len(want) {
+		t.Fatalf("history = %+v, want %+v", res.History, want)
+	}
+	for i := range want {
+		if res.History[i] != want[i] {
+			t.Fatalf("history = %+v, want %+v", res.History, want)
+		}
+	}
+
+	if status, _ := c.do("GET", "/daily/rank-history?days=0", nil); status != http.StatusBadRequest {
+		t.Fatalf("days=0: status %d, want 400", status)
+	}
+	c.call("GET", "/daily/rank-history?days=1000", nil, &res)
+	if res.Days != maxRankHistoryDays {
+		t.Fatalf("days=1000 served %d days, want the cap %d", res.Days, maxRankHistoryDays)
+	}
+	if status, _ := ts.client().do("GET", "/daily/rank-history", nil); status != http.StatusUnauthorized {
+		t.Fatalf("guest: status %d, want 401", status)
+	}
+}
+
+func TestSaltRotationKeepsPinnedDates(t *testing.T) {
+	ts := newTestServer(t)
+	d := ts.testDaily()
+	n := len(words.Answers())
+	now := time.Now()
+	d.salt = daily.Salt{Version: 1, Secret: "first"}
+	// A second secret that maps today elsewhere, so a leak would show.
+	second := daily.Salt{Version: 2}
+	for i := 0; second.Secret == "" || second.WordIndex(now, time.UTC, n) == d.salt.WordIndex(now, time.UTC, n); i++ {
+		second.Secret = "second-" + strconv.Itoa(i)
+	}
+
+	ctx := context.Background()
+	date, idx, version, answer, err := d.puzzleToday(ctx)
+	if err != nil || version != 1 {
+		t.Fatalf("puzzleToday = %d v%d, %v; want salt version 1", idx, version, err)
+	}
+
+	d.salt = second
+	_, idx2, version2, answer2, err := d.puzzleToday(ctx)
+	if err != nil || idx2 != idx || version2 != 1 || answer2 != answer {
+		t.Fatalf("after rotation: %d v%d %q, %v; want the pinned %d v1 %q", idx2, version2, answer2, err, idx, answer)
+	}
+
+	// An unpinned date takes the new salt.
+	const later = "2099-01-01"
+	day, _ := time.Parse("2006-01-02", later)
+	want := second.WordIndex(day, nil, n)
+	if got, v, err := d.store.PinWordIndex(ctx, later, want, second.Version); err != nil || got != want || v != 2 {
+		t.Fatalf("pin %s = %d v%d, %v; want %d v2", later, got, v, err, want)
+	}
+	if got, v, _ := d.store.PinWordIndex(ctx, date, 0, 3); got != idx || v != 1 {
+		t.Fatalf("re-pinning %s = %d v%d; want the first pin %d v1", date, got, v, idx)
+	}
+}
+
+func TestDailyLossSamples(t *testing.T) {
+	ts := newTestServer(t, "DAILY_LOSS_SAMPLES", "3")
+	c := ts.client()
+	c.signup("learner")
+	gameID, answer := c.startDaily(ts)
+
+	// Misses that still leave other words consistent, so there is something to sample.
+	pool := words.DailyAnswers(time.Now().UTC())
+	var misses []string
+	for _, w := range pool {
+		if w != answer && len(misses) < game.DefaultRows && len(words.Consistent(pool, answer, append(misses, w))) > 0 {
+			misses = append(misses, w)
+		}
+	}
+	var res dailyGuessRes
+	for i, w := range misses {
+		_, res = c.dailyGuess(gameID, w)
+		if i < len(misses)-1 && (res.Samples != nil || res.Answer != "") {
+			t.Fatalf("guess %d in progress revealed samples %v / answer %q", i+1, res.Samples, res.Answer)
+		}
+	}
+	if res.State != "lost" || res.Answer != answer {
+		t.Fatalf("final guess: state %q answer %q", res.State, res.Answer)
+	}
+	if len(res.Samples) == 0 || len(res.Samples) > 3 {
+		t.Fatalf("samples = %v, want 1–3 words", res.Samples)
+	}
+	for _, w := range res.Samples {
+		if w == answer || !slices.Contains(pool, w) {
+			t.Fatalf("sample %q is the answer or outside the day's pool", w)
+		}
+		for _, g := range misses {
+			if !slices.Equal(words.Score(g, w), words.Score(g, answer)) {
+				t.Fatalf("sample %q is not consistent with guess %q", w, g)
+			}
+		}
+	}
+
+	_, locked := c.dailyGuess(gameID, misses[0])
+	if locked.State != "locked" || !slices.Equal(locked.Samples, res.Samples) {
+		t.Fatalf("locked: state %q samples %v, want locked with %v", locked.State, locked.Samples, res.Samples)
+	}
+}
+
+func TestDailyRevealReconstructsAnswer(t *testing.T) {
+	const salt = "reveal-salt"
+	ts := newTestServer(t, "DAILY_REVEAL_ENABLED", "true", "DAILY_SALT", salt)
+	c := ts.client()
+	c.signup("auditor")
+	gameID, answer := c.startDaily(ts)
+
+	_, res := c.dailyGuess(gameID, wrongGuesses(answer, 1)[0])
+	if res.Reveal != nil {
+		t.Fatalf("reveal %+v on an unfinished session", res.Reveal)
+	}
+	_, res = c.dailyGuess(gameID, answer)
+	rv := res.Reveal
+	if res.State != "won" || rv == nil || rv.Proof == "" {
+		t.Fatalf("winning guess: state %q reveal %+v", res.State, rv)
+	}
+
+	// What a client does once the salt is published.
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(rv.Date))
+	if want := hex.EncodeToString(mac.Sum(nil)); rv.Proof != want {
+		t.Fatalf("proof = %s, want HMAC(salt, %s) = %s", rv.Proof, rv.Date, want)
+	}
+	proof, _ := hex.DecodeString(rv.Proof)
+	if idx := int(binary.BigEndian.Uint64(proof[:8]) % uint64(rv.AnswerCount)); idx != rv.WordIndex {
+		t.Fatalf("proof gives index %d, reveal says %d", idx, rv.WordIndex)
+	}
+	day, _ := time.Parse("2006-01-02", rv.Date)
+	pool := words.DailyAnswers(day)
+	if rv.AnswerCount != len(pool) || pool[rv.WordIndex] != answer {
+		t.Fatalf("reveal %+v does not point at the scored answer %q", rv, answer)
+	}
+}
+
+func TestDailyRecap(t *testing.T) {
+	ts := newTestServer(t, "DAILY_RECAP_ENABLED", "true")
+	c := ts.client()
+	d := ts.testDaily()
+	ctx := context.Background()
+	yesterday := daily.DateKey(time.Now().AddDate(0, 0, -1), time.UTC)
+	for _, date := range []string{yesterday, today()} {
+		if _, _, err := d.store.PinWordIndex(ctx, date, 3, 1); err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range []daily.Result{
+			{UserID: "u1", Date: date, Guesses: 3, ElapsedMs: 9000, Won: true},
+			{UserID: "u2", Date: date, Guesses: 3, ElapsedMs: 4000, Won: true},
+			{UserID: "u3", Date: date, Guesses: 5, ElapsedMs: 2000, Won: true},
+			{UserID: "u4", Date: date, Guesses: 6, ElapsedMs: 1000, Won: false},
+		} {
+			ts.insertDaily(r)
+		}
+	}
+
+	var res recapRes
+	if status := c.call("GET", "/daily/recap?date="+yesterday, nil, &res); status != http.StatusOK {
+		t.Fatalf("recap: status %d", status)
+	}
+	day, _ := time.Parse("2006-01-02", yesterday)
+	if want := d.answerAt(day, 3); res.Answer != want || want == "" || res.Difficulty == nil {
+		t.Fatalf("answer %q difficulty %v, want %q and a score", res.Answer, res.Difficulty, want)
+	}
+	if res.Attempts != 4 || res.Wins != 3 || res.SolveRate != 0.75 {
+		t.Fatalf("attempts %d wins %d rate %v, want 4, 3, 0.75", res.Attempts, res.Wins, res.SolveRate)
+	}
+	if len(res.Distribution) != 2 || res.Distribution[3] != 2 || res.Distribution[5] != 1 {
+		t.Fatalf("distribution = %v, want map[3:2 5:1]", res.Distribution)
+	}
+	if res.Fastest == nil || res.Fastest.UserID != "u3" {
+		t.Fatalf("fastest = %+v, want u3 (losses don't count)", res.Fastest)
+	}
+
+	res = recapRes{}
+	if status := c.call("GET", "/daily/recap", nil, &res); status != http.StatusOK || res.Date != today() {
+		t.Fatalf("today's recap: status %d date %q", status, res.Date)
+	}
+	if res.Answer != "" || res.Difficulty != nil || res.Attempts != 4 {
+		t.Fatalf("today's recap = %+v, want stats without the answer", res)
+	}
+
+	tomorrow := daily.DateKey(time.Now().AddDate(0, 0, 1), time.UTC)
+	if status, _ := c.do("GET", "/daily/recap?date="+tomorrow, nil); status != http.StatusBadRequest {
+		t.Fatalf("future date: status %d, want 400", status)
+	}
+	_ = ts.flags.Set(featureflags.DailyRecap, false)
+	if status, _ := c.do("GET", "/daily/recap", nil); status != http.StatusNotFound {
+		t.Fatalf("flag off: status %d, want 404", status)
+	}
+}
+
+func TestDailySeventhGuessLocked(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client()
+	uid := c.signup("sixer")
+	gameID, answer := c.startDaily(ts)
+	misses := wrongGuesses(answer, game.DefaultRows)
+	for i, w := range misses {
+		status, res := c.dailyGuess(gameID, w)
+		want := "in_progress"
+		if i == len(misses)-1 {
+			want = "lost"
+		}
+		if status != http.StatusOK || res.State != want || res.Remaining != game.DefaultRows-i-1 {
+			t.Fatalf("guess %d: status %d state %q remaining %d, want %q", i+1, status, res.State, res.Remaining, want)
+		}
+	}
+
+	status, res := c.dailyGuess(gameID, answer)
+	if status != http.StatusOK || res.State != "locked" || res.Guesses != game.DefaultRows {
+		t.Fatalf("seventh guess: status %d state %q guesses %d, want locked at %d", status, res.State, res.Guesses, game.DefaultRows)
+	}
+	r, err := daily.NewStore(ts.db, time.UTC).GetResult(context.Background(), uid, today())
+	if err != nil || r.Won || r.Guesses != game.DefaultRows {
+		t.Fatalf("stored result = %+v, %v; want a loss in %d", r, err, game.DefaultRows)
+	}
+	var lb lbRes
+	c.call("GET", "/daily/leaderboard", nil, &lb)
+	if len(lb.Top) != 0 || lb.Attempts != 1 || lb.Wins != 0 {
+		t.Fatalf("leaderboard = %+v, want one attempt and the loss left off", lb)
+	}
+}
+
+func TestDailyConcurrentGuessesStopAtLimit(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client()
+	gameID, answer := c.startDaily(ts)
+	misses := wrongGuesses(answer, 12)
+
+	var wg sync.WaitGroup
+	scored := make(chan int, len(misses))
+	for _, w := range misses {
+		wg.Add(1)
+		go func(w string) {
+			defer wg.Done()
+			if _, res := c.dailyGuess(gameID, w); res.State != "locked" {
+				scored <- res.GuessNumber
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(scored)
+	var got []int
+	for n := range scored {
+		got = append(got, n)
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, []int{1, 2, 3, 4, 5, 6}) {
+		t.Fatalf("scored guess numbers = %v, want exactly 1..%d", got, game.DefaultRows)
+	}
+}
+
+func TestDailyGuestFingerprint(t *testing.T) {
+	for _, mode := range []string{"advisory", "strict"} {
+		ts := newTestServer(t, "DAILY_FINGERPRINT", mode)
+		first := ts.client()
+		gameID, answer := first.startDaily(ts)
+		if _, res := first.dailyGuess(gameID, answer); res.State != "won" {
+			t.Fatalf("%s: first guest state %q, want won", mode, res.State)
+		}
+
+		// Same IP and (default) User-Agent as the first guest.
+		var res newRes
+		status := ts.client().call("POST", "/daily/new", nil, &res)
+		switch mode {
+		case "advisory":
+			if status != http.StatusOK || !res.Flagged || res.GameID == "" {
+				t.Fatalf("advisory: status %d %+v, want a flagged session", status, res)
+			}
+		case "strict":
+			if status != http.StatusTooManyRequests {
+				t.Fatalf("strict: status %d, want 429", status)
+			}
+		}
+
+		res = newRes{}
+		if status := ts.client().call("POST", "/daily/new", nil, &res, "User-Agent", "another-browser"); status != http.StatusOK || res.Flagged {
+			t.Fatalf("%s: other device: status %d %+v, want unflagged", mode, status, res)
+		}
+		user := ts.client()
+		user.signup("member")
+		res = newRes{}
+		if status := user.call("POST", "/daily/new", nil, &res); status != http.StatusOK || res.Flagged {
+			t.Fatalf("%s: registered player: status %d %+v, want unchecked", mode, status, res)
+		}
+	}
+}
+
+func TestDailyPracticeCode(t *testing.T) {
+	// Registered first so it runs after the env is restored.
+	t.Cleanup(func() { _, _ = words.Reload() })
+	ts := newTestServer(t, "DAILY_PRACTICE_LINKS_ENABLED", "true", "SHORT_LINKS_ENABLED", "true")
+	c := ts.client()
+	gameID, answer := c.startDaily(ts)
+	miss := wrongGuesses(answer, 1)[0]
+
+	// The daily list is embedded separately; make its answer a classic word
+	// too, or no practice link can be made for it.
+	list := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(list, []byte(answer+"\n"+miss+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("WORDS_ALLOWED_FILE", list)
+	if _, err := words.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if _, res := c.dailyGuess(gameID, miss); res.State != "in_progress" || res.Practice != "" {
+		t.Fatalf("in progress: state %q practice %q, want no code yet", res.State, res.Practice)
+	}
+	_, won := c.dailyGuess(gameID, answer)
+	if won.State != "won" || won.Practice == "" {
+		t.Fatalf("won: state %q practice %q, want a code", won.State, won.Practice)
+	}
+	if _, locked := c.dailyGuess(gameID, answer); locked.State != "locked" || locked.Practice != won.Practice {
+		t.Fatalf("locked: state %q practice %q, want the same code %q", locked.State, locked.Practice, won.Practice)
+	}
+
+	// A friend plays the code as a classic game; it earns no daily credit.
+	friend := ts.client()
+	uid := friend.signup("friend")
+	id := friend.newGame(newGameReq{Link: won.Practice})
+	if status, res := friend.guess(id, answer); status != http.StatusOK || res.State != "won" {
+		t.Fatalf("practice game: status %d state %q, want won", status, res.State)
+	}
+	if _, err := daily.NewStore(ts.db, time.UTC).GetResult(context.Background(), uid, today()); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("practice win stored a daily result: %v", err)
+	}
+	var fresh newRes
+	if friend.call("POST", "/daily/new", nil, &fresh); fresh.Played || fresh.GameID == "" {
+		t.Fatalf("friend's daily after practice = %+v, want still playable", fresh)
+	}
+	if status, _ := friend.do("POST", "/daily/guess", map[string]string{"gameId": id, "word": answer}); status == http.StatusOK {
+		t.Fatal("practice game ID accepted by /daily/guess")
+	}
+}
+
+func TestDailyLiveSolveRate(t *testing.T) {
+	ts := newTestServer(t, "DAILY_LIVE_RATE_ENABLED", "true")
+	// liveRes decodes /daily/today; todayRes embeds an unexported pointer.
+	type liveRes struct {
+		todayLive
+		Played bool `json:"played"`
+	}
+	today := func(c *testClient) liveRes {
+		t.Helper()
+		var res liveRes
+		if status := c.call("GET", "/daily/today", nil, &res); status != http.StatusOK {
+			t.Fatalf("/daily/today: status %d", status)
+		}
+		return res
+	}
+
+	winner, loser := ts.client(), ts.client()
+	winID, answer := winner.startDaily(ts)
+	loseID, _ := loser.startDaily(ts)
+	if res := today(winner); res.Attempts != 0 || res.Playing != 2 || res.SolveRate != 0 {
+		t.Fatalf("before any finish = %+v", res.todayLive)
+	}
+	winner.dailyGuess(winID, answer)
+	if res := today(winner); res.Attempts != 1 || res.Wins != 1 || res.SolveRate != 1 || res.Playing != 1 || !res.Played {
+		t.Fatalf("after a win = %+v played %v", res.todayLive, res.Played)
+	}
+	for _, w := range wrongGuesses(answer, game.DefaultRows) {
+		loser.dailyGuess(loseID, w)
+	}
+	if res := today(loser); res.Attempts != 2 || res.Wins != 1 || res.SolveRate != 0.5 || res.Playing != 0 {
+		t.Fatalf("after a loss = %+v", res.todayLive)
+	}
+
+	// A restarted instance seeds the tally from daily_results.
+	c := ts.client()
+	c.url = ts.restart().url
+	if res := today(c); res.Attempts != 2 || res.Wins != 1 {
+		t.Fatalf("after restart = %+v, want 2 attempts and 1 win", res.todayLive)
+	}
+
+	_ = ts.flags.Set(featureflags.DailyLive, false)
+	if _, raw := winner.do("GET", "/daily/today", nil); strings.Contains(string(raw), "solveRate") {
+		t.Fatalf("flag off: %s, want no live counts", raw)
+	}
+}
+
+func TestDailyMine(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client()
+	uid := c.signup("historian")
+	for _, r := range []daily.Result{
+		{UserID: uid, Date: "2025-03-02", Guesses: 4, ElapsedMs: 4000, Won: true},
+		{UserID: uid, Date: "2025-03-04", Guesses: 6, ElapsedMs: 6000, Won: false},
+		{UserID: uid, Date: "2025-03-03", Guesses: 2, ElapsedMs: 2000, Won: true},
+		{UserID: "someone-else", Date: "2025-03-03", Guesses: 1, ElapsedMs: 100, Won: true},
+	} {
+		ts.insertDaily(r)
+	}
+	mine := func(query string) []mineRow {
+		t.Helper()
+		var res struct {
+			Results []mineRow `json:"results"`
+		}
+		if status := c.call("GET", "/daily/mine"+query, nil, &res); status != http.StatusOK {
+			t.Fatalf("/daily/mine%s: status %d", query, status)
+		}
+		return res.Results
+	}
+	dates := func(rows []mineRow) []string {
+		var out []string
+		for _, r := range rows {
+			out = append(out, r.Date)
+		}
+		return out
+	}
+
+	all := mine("")
+	if got := dates(all); !slices.Equal(got, []string{"2025-03-04", "2025-03-03", "2025-03-02"}) {
+		t.Fatalf("dates = %v, want newest first", got)
+	}
+	if all[0].Won || all[0].Guesses != 6 || all[1].ElapsedMs != 2000 || all[1].Difficulty != daily.DifficultyNormal {
+		t.Fatalf("rows = %+v", all)
+	}
+	if got := dates(mine("?from=2025-03-03&to=2025-03-03")); !slices.Equal(got, []string{"2025-03-03"}) {
+		t.Fatalf("from=to range = %v, want the one day", got)
+	}
+	if got := dates(mine("?to=2025-03-03")); !slices.Equal(got, []string{"2025-03-03", "2025-03-02"}) {
+		t.Fatalf("to range = %v", got)
+	}
+	if status, _ := c.do("GET", "/daily/mine?from=March", nil); status != http.StatusBadRequest {
+		t.Fatalf("bad date: status %d, want 400", status)
+	}
+	if rows := mine("?from=2030-01-01"); rows == nil || len(rows) != 0 {
+		t.Fatalf("empty range = %#v, want []", rows)
+	}
+}
+
+func TestDailyPlayStreak(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client()
+	uid := c.signup("regular")
+	now := time.Now().UTC()
+	for _, back := range []int{1, 2, 4} { // today still open; three days ago missed
+		date := daily.DateKey(now.AddDate(0, 0, -back), time.UTC)
+		ts.insertDaily(daily.Result{UserID: uid, Date: date, Guesses: 6, Won: back != 2})
+	}
+
+	var res streakRes
+	if status := c.call("GET", "/daily/streak", nil, &res); status != http.StatusOK {
+		t.Fatalf("streak: status %d", status)
+	}
+	if res.PlayStreak != 2 || res.Streak != 1 {
+		t.Fatalf("streak = %+v, want playStreak 2 (losses count) and win streak 1", res)
+	}
+	if status, _ := ts.client().do("GET", "/daily/streak", nil); status != http.StatusUnauthorized {
+		t.Fatalf("anonymous streak: status %d, want 401", status)
+	}
+}
+
+func TestDailySessionSurvivesRestart(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client()
+	c.signup("resumer")
+	id, answer := c.startDaily(ts)
+	wrong := wrongGuesses(answer, 2)
+	for _, w := range wrong {
+		if status, res := c.dailyGuess(id, w); status != http.StatusOK || res.State != "in_progress" {
+			t.Fatalf("guess %s: status %d, state %q", w, status, res.State)
+		}
+	}
+
+	c.url = ts.restart().url
+	var res struct {
+		GameID string `json:"gameId"`
+	}
+	if status := c.call("POST", "/daily/new", nil, &res); status != http.StatusOK || res.GameID != id {
+		t.Fatalf("/daily/new after restart: status %d, game %q; want the session %q resumed", status, res.GameID, id)
+	}
+	status, g := c.dailyGuess(id, answer)
+	if status != http.StatusOK || g.State != "won" || g.Guesses != len(wrong)+1 {
+		t.Fatalf("guess after restart: status %d, %+v; want a win on guess %d", status, g, len(wrong)+1)
+	}
+	if n := ts.countRows("daily_results"); n != 1 {
+		t.Fatalf("daily_results has %d rows, want 1", n)
+	}
+}
+
+func TestDailyRevealTime(t *testing.T) {
+	ts := newTestServer(t, "DAILY_REVEAL_TIME_ENABLED", "true", "DAILY_TIMEZONE", "Asia/Tokyo")
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := time.Now()
+	var res revealTimeRes
+	if status := ts.client().call("GET", "/daily/reveal-time", nil, &res); status != http.StatusOK {
+		t.Fatalf("reveal-time without auth: status %d", status)
+	}
+	local := res.RevealAt.In(tokyo)
+	if h, m, s := local.Clock(); h != 0 || m != 0 || s != 0 || local.Nanosecond() != 0 {
+		t.Fatalf("revealAt %v is %v in Tokyo, want local midnight", res.RevealAt, local)
+	}
+	if !res.RevealAt.After(before) || res.RevealAt.Sub(before) > 24*time.Hour {
+		t.Fatalf("revealAt %v is not the next boundary after %v", res.RevealAt, before)
+	}
+	if want := daily.DateKey(before, tokyo); res.Date != want || res.Next != local.Format("2006-01-02") {
+		t.Fatalf("date %s → next %s, want %s → %s", res.Date, res.Next, want, local.Format("2006-01-02"))
+	}
+	if res.Timezone != "Asia/Tokyo" || !strings.HasSuffix(res.Local, "+09:00") {
+		t.Fatalf("timezone %q local %q, want Asia/Tokyo at +09:00", res.Timezone, res.Local)
+	}
+	if res.SecondsLeft <= 0 || res.SecondsLeft > 24*60*60 {
+		t.Fatalf("secondsLeft = %d", res.SecondsLeft)
+	}
+
+	_ = ts.flags.Set(featureflags.DailyRevealTime, false)
+	if status, _ := ts.client().do("GET", "/daily/reveal-time", nil); status != http.StatusNotFound {
+		t.Fatalf("flag off: status %d, want 404", status)
+	}
+}
+
+func TestDailyRecapPar(t *testing.T) {
+	ts := newTestServer(t, "DAILY_RECAP_ENABLED", "true", "DAILY_PAR_ENABLED", "true")
+	c := ts.client()
+	d := ts.testDaily()
+	ctx := context.Background()
+	yesterday := daily.DateKey(time.Now().AddDate(0, 0, -1), time.UTC)
+	for _, date := range []string{yesterday, today()} {
+		if _, _, err := d.store.PinWordIndex(ctx, date, 3, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var res recapRes
+	if status := c.call("GET", "/daily/recap?date="+yesterday, nil, &res); status != http.StatusOK || res.Par == nil || *res.Par < 1 {
+		t.Fatalf("past recap: status %d, par %v; want a par", status, res.Par)
+	}
+	stored, ok, err := d.store.Par(ctx, yesterday)
+	if err != nil || !ok || stored != *res.Par {
+		t.Fatalf("stored par = %d, %v, %v; want %d", stored, ok, err, *res.Par)
+	}
+	again := recapRes{}
+	if c.call("GET", "/daily/recap?date="+yesterday, nil, &again); again.Par == nil || *again.Par != *res.Par {
+		t.Fatalf("second recap par = %v, want %d", again.Par, *res.Par)
+	}
+
+	if _, raw := c.do("GET", "/daily/recap", nil); strings.Contains(string(raw), `"par"`) {
+		t.Fatalf("today's recap exposes par: %s", raw)
+	}
+	_ = ts.flags.Set(featureflags.DailyPar, false)
+	if _, raw := c.do("GET", "/daily/recap?date="+yesterday, nil); strings.Contains(string(raw), `"par"`) {
+		t.Fatalf("flag off: recap still has par: %s", raw)
+	}
+}
+
+func TestDailyToday(t *testing.T) {
+	ts := newTestServer(t)
+	var res struct {
+		Date      string `json:"date"`
+		WordIndex int    `json:"wordIndex"`
+		Puzzle    int    `json:"puzzle"`
+		Played    bool   `json:"played"`
+	}
+	guest := ts.client()
+	status, raw := guest.do("GET", "/daily/today", nil)
+	if status != http.StatusOK {
+		t.Fatalf("guest: status %d", status)
+	}
+	if err := json.Unmarshal(raw, &res); err != nil {
+		t.Fatal(err)
+	}
+	want := daily.DateKey(time.Now().UTC(), nil)
+	if res.Date != want || res.Puzzle != daily.PuzzleNumber(want, "2025-01-01") || res.Played {
+		t.Fatalf("guest today = %+v, want %s, puzzle %d, not played", res, want, daily.PuzzleNumber(want, "2025-01-01"))
+	}
+	if n := ts.countRows("daily_sessions"); n != 0 {
+		t.Fatalf("/daily/today created %d sessions", n)
+	}
+
+	// The index is the one /daily/new then plays; the answer itself stays out.
+	if _, answer := guest.startDaily(ts); words.DailyAnswers(time.Now().UTC())[res.WordIndex] != answer || strings.Contains(string(raw), answer) {
+		t.Fatalf("wordIndex %d for answer %q in %s", res.WordIndex, answer, raw)
+	}
+
+	c := ts.client()
+	uid := c.signup("checker")
+	ts.insertDaily(daily.Result{UserID: uid, Date: today(), Guesses: 4, Won: true})
+	if c.call("GET", "/daily/today", nil, &res); !res.Played || res.Date != want {
+		t.Fatalf("after playing = %+v, want played", res)
+	}
+}
+
+func TestDailyVerify(t *testing.T) {
+	const salt = "verify-salt"
+	ts := newTestServer(t, "DAILY_VERIFY_ENABLED", "true", "DAILY_SALT", salt)
+	c := ts.client()
+	d := ts.testDaily()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	yesterday := daily.DateKey(now.AddDate(0, 0, -1), time.UTC)
+	older := daily.DateKey(now.AddDate(0, 0, -2), time.UTC)
+	if _, _, err := d.store.PinWordIndex(ctx, yesterday, 3, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := d.store.PinWordIndex(ctx, older, 5, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	var first, second dailyVerifyRes
+	if status := c.call("GET", "/daily/verify?date="+yesterday, nil, &first); status != http.StatusOK {
+		t.Fatalf("verify: status %d", status)
+	}
+	c.call("GET", "/daily/verify?date="+yesterday, nil, &second)
+	if first != second || first.WordIndex != 3 || first.SaltVersion != 1 || first.AnswerCount == 0 {
+		t.Fatalf("verify = %+v then %+v, want the pinned index 3 twice", first, second)
+	}
+	day, _ := time.Parse("2006-01-02", yesterday)
+	if first.Proof != daily.Proof(day, nil, salt) || first.SaltCommitment != daily.Commitment(salt) {
+		t.Fatalf("proof %s commitment %s don't match the salt", first.Proof, first.SaltCommitment)
+	}
+	proof, err := hex.DecodeString(first.Proof)
+	if err != nil || len(proof) < 8 {
+		t.Fatalf("proof %q: %v", first.Proof, err)
+	}
+	if got := int(binary.BigEndian.Uint64(proof[:8]) % uint64(first.AnswerCount)); got != daily.WordIndex(day, nil, salt, first.AnswerCount) {
+		t.Fatalf("proof gives index %d, WordIndex %d", got, daily.WordIndex(day, nil, salt, first.AnswerCount))
+	}
+	if strings.Contains(first.Proof+first.SaltCommitment, salt) {
+		t.Fatal("response leaks the salt")
+	}
+
+	// A date pinned under an older salt keeps its index but has no proof.
+	var old dailyVerifyRes
+	if c.call("GET", "/daily/verify?date="+older, nil, &old); old.WordIndex != 5 || old.SaltVersion != 0 || old.Proof != "" || old.SaltCommitment != "" {
+		t.Fatalf("older salt: %+v", old)
+	}
+
+	for date, want := range map[string]int{
+		today():      http.StatusBadRequest,
+		"2025-13-01": http.StatusBadRequest,
+		"":           http.StatusBadRequest,
+		daily.DateKey(now.AddDate(0, 0, -30), time.UTC): http.StatusNotFound,
+	} {
+		if status, _ := c.do("GET", "/daily/verify?date="+date, nil); status != want {
+			t.Errorf("date %q: status %d, want %d", date, status, want)
+		}
+	}
+
+	_ = ts.flags.Set(featureflags.DailyVerify, false)
+	if status, _ := c.do("GET", "/daily/verify?date="+yesterday, nil); status != http.StatusNotFound {
+		t.Fatalf("flag off: status %d, want 404", status)
+	}
+}
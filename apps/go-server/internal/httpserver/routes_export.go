@@ -0,0 +1,183 @@
+// apps/go-server/internal/httpserver/routes_export.go
+//
+// Personal data export (data portability).
+//   - GET /auth/me/export → JSON archive of the caller's profile, stats, classic
+//     game history (guesses, plus marks when the answer was persisted), and
+//     daily results, served as a downloadable attachment
+//
+// Notes:
+//   - The password hash is never included.
+//   - Games and daily results are streamed row by row, so large histories are
+//     not buffered in memory.
+//   - Disabled (404) when the data_export flag (ACCOUNT_EXPORT_ENABLED) is off.
+
+package httpserver
+
+import (
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// exportGame is one classic game in the archive.
+type exportGame struct {
+	ID         string        `json:"id"`
+	Status     string        `json:"status"`
+	StartedAt  string        `json:"startedAt"`
+	FinishedAt string        `json:"finishedAt,omitempty"`
+	Answer     string        `json:"answer,omitempty"` // only when persisted and the game is over
+	Guesses    []exportGuess `json:"guesses"`
+}
+
+// exportGuess is one guess; Marks is set when the answer is known.
+type exportGuess struct {
+	Guess string `json:"guess"`
+	At    string `json:"at"`
+	Marks []int  `json:"marks,omitempty"` // 0=miss, 1=present, 2=hit
+}
+
+// exportDaily is one daily result in the archive.
+type exportDaily struct {
+	Date       string   `json:"date"`
+	Won        bool     `json:"won"`
+	Guesses    int      `json:"guesses"`
+	ElapsedMs  int      `json:"elapsedMs"`
+	Difficulty string   `json:"difficulty"`
+	Board      []string `json:"board,omitempty"`
+	CreatedAt  string   `json:"createdAt"`
+}
+
+// handleExport streams the caller's data archive.
+func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
+	if !s.flags.Enabled(featureflags.DataExport) {
+		writeError(w, http.StatusNotFound, "not_found", "no route for "+r.URL.Path)
+		return
+	}
+	me, _ := r.Context().Value(ctxUserKey{}).(*authUser)
+	if me == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
+		return
+	}
+	u, err := s.findUserByID(me.ID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "not_found", "")
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="wordle-export-`+u.Username+`.json"`)
+	enc := json.NewEncoder(w)
+	_, _ = io.WriteString(w, `{"exportedAt":`)
+	_ = enc.Encode(time.Now().UTC().Format(time.RFC3339))
+	_, _ = io.WriteString(w, `,"profile":`)
+	profile := map[string]any{"id": u.ID, "username": u.Username, "createdAt": u.CreatedAt.UTC().Format(time.RFC3339)}
+	if u.Email != "" {
+		profile["email"] = u.Email
+	}
+	_ = enc.Encode(profile)
+	_, _ = io.WriteString(w, `,"stats":`)
+	_ = enc.Encode(map[string]int{"gamesPlayed": u.GamesPlayed, "wins": u.Wins, "streak": u.Streak})
+
+	// Headers are already sent, so failures below can only be logged and the
+	// archive truncated; clients detect that as invalid JSON.
+	_, _ = io.WriteString(w, `,"games":[`)
+	if err := s.exportGames(w, u.ID); err != nil {
+		log.Warn().Err(err).Str("user", u.ID).Msg("export games")
+		return
+	}
+	_, _ = io.WriteString(w, `],"dailyResults":[`)
+	if err := s.exportDaily(w, u.ID); err != nil {
+		log.Warn().Err(err).Str("user", u.ID).Msg("export daily")
+		return
+	}
+	_, _ = io.WriteString(w, "]}\n")
+}
+
+// exportGames writes the user's games as comma-separated JSON objects.
+func (s *Server) exportGames(w io.Writer, userID string) error {
+	rows, err := s.db.Query(`SELECT id, status, started_at, COALESCE(finished_at,''), answer
+	                           FROM games WHERE user_id=? ORDER BY started_at`, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	enc := json.NewEncoder(w)
+	for n := 0; rows.Next(); n++ {
+		var g exportGame
+		if err := rows.Scan(&g.ID, &g.Status, &g.StartedAt, &g.FinishedAt, &g.Answer); err != nil {
+			return err
+		}
+		if g.Status == "playing" {
+			g.Answer = "" // never reveal the answer of a game still in progress
+		}
+		if g.Guesses, err = s.exportGuesses(g.ID, g.Answer); err != nil {
+			return err
+		}
+		if n > 0 {
+			_, _ = io.WriteString(w, ",")
+		}
+		if err := enc.Encode(g); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// exportGuesses loads a game's guesses, scoring them when answer is known.
+func (s *Server) exportGuesses(gameID, answer string) ([]exportGuess, error) {
+	rows, err := s.db.Query(`SELECT guess, created_at FROM game_guesses WHERE game_id=? ORDER BY seq`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []exportGuess{}
+	for rows.Next() {
+		var g exportGuess
+		if err := rows.Scan(&g.Guess, &g.At); err != nil {
+			return nil, err
+		}
+		if answer != "" && len(g.Guess) == len(answer) {
+			g.Marks = words.Score(g.Guess, answer)
+		}
+		out = append(out, g)
+	}
+	return out, rows.Err()
+}
+
+// exportDaily writes the user's daily results as comma-separated JSON objects.
+func (s *Server) exportDaily(w io.Writer, userID string) error {
+	rows, err := s.db.Query(`SELECT date, won, guesses, elapsed_ms, difficulty, board, created_at
+	                           FROM daily_results WHERE user_id=? ORDER BY date`, userID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	enc := json.NewEncoder(w)
+	for n := 0; rows.Next(); n++ {
+		var d exportDaily
+		var board sql.NullString
+		var created time.Time
+		if err := rows.Scan(&d.Date, &d.Won, &d.Guesses, &d.ElapsedMs, &d.Difficulty, &board, &created); err != nil {
+			return err
+		}
+		d.CreatedAt = created.UTC().Format(time.RFC3339)
+		if board.Valid && board.String != "" {
+			_ = json.Unmarshal([]byte(board.String), &d.Board)
+		}
+		if n > 0 {
+			_, _ = io.WriteString(w, ",")
+		}
+		if err := enc.Encode(d); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
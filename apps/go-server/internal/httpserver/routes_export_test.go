@@ -0,0 +1,79 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/daily"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+func TestExportArchive(t *testing.T) {
+	ts := newTestServer(t, "PERSIST_GAME_RESULTS", "true", "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	uid := c.signup("porter")
+	list := words.AnswersLen(5)
+	won := c.newGame(newGameReq{Answer: list[0]})
+	c.guess(won, list[1])
+	c.guess(won, list[0])
+	playing := c.newGame(newGameReq{Answer: list[0]})
+	c.guess(playing, list[2])
+	ts.insertDaily(daily.Result{UserID: uid, Date: "2025-02-03", Guesses: 3, ElapsedMs: 4000, Board: []string{list[1], list[2], list[3]}, Won: true})
+
+	resp, err := c.hc.Get(ts.url + "/auth/me/export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Disposition"), "attachment;") {
+		t.Fatalf("export: status %d, Content-Disposition %q", resp.StatusCode, resp.Header.Get("Content-Disposition"))
+	}
+	if strings.Contains(strings.ToLower(string(raw)), "password") || strings.Contains(string(raw), "$2a$") {
+		t.Fatalf("archive leaks the password hash:\n%s", raw)
+	}
+
+	var archive struct {
+		ExportedAt   string         `json:"exportedAt"`
+		Profile      map[string]any `json:"profile"`
+		Stats        map[string]int `json:"stats"`
+		Games        []exportGame   `json:"games"`
+		DailyResults []exportDaily  `json:"dailyResults"`
+	}
+	if err := json.Unmarshal(raw, &archive); err != nil {
+		t.Fatalf("archive is not JSON: %v\n%s", err, raw)
+	}
+	if archive.ExportedAt == "" || archive.Profile["id"] != uid || archive.Profile["username"] != "porter" {
+		t.Fatalf("header/profile = %q %v", archive.ExportedAt, archive.Profile)
+	}
+	if archive.Stats["gamesPlayed"] != 1 || archive.Stats["wins"] != 1 {
+		t.Fatalf("stats = %v, want one game played and won", archive.Stats)
+	}
+	if len(archive.Games) != 2 {
+		t.Fatalf("games = %+v, want 2", archive.Games)
+	}
+	for _, g := range archive.Games {
+		switch g.ID {
+		case won:
+			if g.Answer != list[0] || len(g.Guesses) != 2 || len(g.Guesses[1].Marks) != 5 || g.Guesses[0].At == "" {
+				t.Errorf("won game = %+v, want the answer and two scored guesses", g)
+			}
+		case playing:
+			if g.Answer != "" || len(g.Guesses) != 1 || g.Guesses[0].Marks != nil {
+				t.Errorf("game in progress = %+v, want guesses without answer or marks", g)
+			}
+		default:
+			t.Errorf("unexpected game %+v", g)
+		}
+	}
+	if len(archive.DailyResults) != 1 || archive.DailyResults[0].Date != "2025-02-03" || len(archive.DailyResults[0].Board) != 3 {
+		t.Fatalf("daily results = %+v", archive.DailyResults)
+	}
+
+	if status, _ := ts.client().do("GET", "/auth/me/export", nil); status != http.StatusUnauthorized {
+		t.Fatalf("guest export: status %d, want 401", status)
+	}
+}
@@ -0,0 +1,304 @@
+// apps/go-server/internal/httpserver/routes_games.go
+//
+// Read-only endpoints for individual classic games.
+//   - GET /games/{id}/history → the game's guesses in order (replay)
+//   - GET /games/{id}/detail  → the board: guesses with recomputed marks and
+//     the row limit, plus the answer once the game is finished
+//   - GET /game/{id}/share    → emoji share grid once the game is finished
+//     (?contrast=high for the color-blind palette)
+//   - GET /game/{id}/review   → A–F grade per guess once the game is finished
+//     (game_review flag; see words.ReviewGuesses)
+//   - GET /game/verify?token= → decoded result of a signed result token
+//     (public; see routes_results.go)
+//   - POST /game/{id}/hint    → suggested next guess for a game in progress
+//     (hints flag; see analysis.Hint). Registered with the gameplay routes in
+//     server.go so it shares their maintenance gate and rate limit.
+//
+// Access: optional auth; a game is visible to its owner only, i.e. the
+// logged-in user it belongs to or the guest holding its anon cookie.
+//
+// Guesses come from game_guesses (written by POST /game/guess), so games
+// played before that table existed simply have an empty board. Marks need the
+// answer: it is taken from the live game if still in the store, otherwise from
+// the finish record (only written with PERSIST_GAME_RESULTS=true); when
+// neither is available the guesses are returned without marks.
+//
+// Config:
+//   - history_timestamps flag (HISTORY_TIMESTAMPS=true) includes each guess's
+//     UTC RFC3339 timestamp.
+//   - game_review flag (GAME_REVIEW_ENABLED=true) serves /game/{id}/review;
+//     when off it 404s.
+//   - hints flag (HINTS_ENABLED=true) serves /game/{id}/hint; when off it 404s.
+
+package httpserver
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/analysis"
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// mountGameRoutes registers per-game read endpoints.
+func (s *Server) mountGameRoutes() {
+	s.r.With(s.withOptionalAuth()).Get("/games/{id}/history", s.handleGameHistory)
+	s.r.With(s.withOptionalAuth()).Get("/games/{id}/detail", s.handleGameDetail)
+	s.r.With(s.withOptionalAuth()).Get("/game/{id}/share", s.handleGameShare)
+	s.r.With(s.withOptionalAuth()).Get("/game/{id}/review", s.handleGameReview)
+	s.r.Get("/game/verify", s.handleVerifyResult)
+}
+
+// ownsGame reports whether the caller (user or anon cookie) owns game id.
+func (s *Server) ownsGame(r *http.Request, id string) (bool, error) {
+	var userID, anonID sql.NullString
+	err := s.db.QueryRow(`SELECT user_id, anonymous_id FROM games WHERE id=?`, id).Scan(&userID, &anonID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if me, _ := r.Context().Value(ctxUserKey{}).(*authUser); me != nil && userID.Valid && userID.String == me.ID {
+		return true, nil
+	}
+	if c, err := r.Cookie(anonCookieName); err == nil && anonID.Valid && c.Value != "" && anonID.String == c.Value {
+		return true, nil
+	}
+	return false, nil
+}
+
+// historyGuess is a single entry in /games/{id}/history.
+type historyGuess struct {
+	Seq   int    `json:"seq"`
+	Guess string `json:"guess"`
+	At    string `json:"at,omitempty"` // only with HISTORY_TIMESTAMPS=true
+}
+
+// handleGameHistory returns the recorded guesses for a game the caller owns.
+func (s *Server) handleGameHistory(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	ok, err := s.ownsGame(r, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "")
+		return
+	}
+
+	rows, err := s.db.Query(`SELECT seq, guess, created_at FROM game_guesses WHERE game_id=? ORDER BY seq`, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	defer rows.Close()
+
+	withTimes := s.flags.Enabled(featureflags.HistoryTimestamps)
+	out := []historyGuess{}
+	for rows.Next() {
+		var h historyGuess
+		var at string
+		if err := rows.Scan(&h.Seq, &h.Guess, &at); err != nil {
+			writeError(w, http.StatusInternalServerError, "db_error", "")
+			return
+		}
+		if withTimes {
+			h.At = at
+		}
+		out = append(out, h)
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "guesses": out})
+}
+
+// detailGuess is a single row of the board in /games/{id}/detail.
+type detailGuess struct {
+	Seq   int    `json:"seq"`
+	Guess string `json:"guess"`
+	Marks []int  `json:"marks,omitempty"` // 0=miss, 1=present, 2=hit; omitted when the answer is unknown
+}
+
+// detailRes is returned by /games/{id}/detail.
+type detailRes struct {
+	ID      string        `json:"id"`
+	Status  string        `json:"status"`           // playing | won | lost | abandoned
+	Rows    int           `json:"rows,omitempty"`   // max guesses; omitted once the game has left the store
+	Answer  string        `json:"answer,omitempty"` // finished games only
+	Tags    []string      `json:"tags,omitempty"`   // finished games only: the answer's category tags
+	Guesses []detailGuess `json:"guesses"`
+}
+
+// handleGameDetail returns the stored board of a game the caller owns.
+func (s *Server) handleGameDetail(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	ok, err := s.ownsGame(r, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "")
+		return
+	}
+
+	res := detailRes{ID: id, Guesses: []detailGuess{}}
+	var answer string
+	if err := s.db.QueryRow(`SELECT status, answer FROM games WHERE id=?`, id).Scan(&res.Status, &answer); err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	scored := true
+	if g, err := s.store.Get(r.Context(), id); err == nil {
+		answer, scored = g.Answer, g.Mode != game.ModeJotto
+		res.Rows = g.Rows
+	}
+	if res.Status == "won" || res.Status == "lost" {
+		res.Answer = answer
+		res.Tags = words.Tags(answer)
+	}
+
+	rows, err := s.db.Query(`SELECT seq, guess FROM game_guesses WHERE game_id=? ORDER BY seq`, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var d detailGuess
+		if err := rows.Scan(&d.Seq, &d.Guess); err != nil {
+			writeError(w, http.StatusInternalServerError, "db_error", "")
+			return
+		}
+		if scored && answer != "" && len(d.Guess) == len(answer) {
+			d.Marks = words.Score(d.Guess, answer)
+		}
+		res.Guesses = append(res.Guesses, d)
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// handleGameShare returns the share grid for a finished game the caller owns.
+// Games still in play answer 409 so the grid can't leak progress mid-game.
+func (s *Server) handleGameShare(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	ok, err := s.ownsGame(r, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "")
+		return
+	}
+	g, err := s.store.Get(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "not_found", "")
+		return
+	}
+	if !g.Finished {
+		writeError(w, http.StatusConflict, "game_not_finished", "")
+		return
+	}
+	palette := game.PaletteClassic
+	if r.URL.Query().Get("contrast") == "high" {
+		palette = game.PaletteHighContrast
+	}
+	_ = json.NewEncoder(w).Encode(map[string]string{"share": game.ShareGridWith(g, palette)})
+}
+
+// handleGameReview grades each guess of a finished game by how well it
+// narrowed the possible answers. Candidates come from the classic answer list
+// of the game's length, or the game's custom allowed list. Jotto games have no
+// per-letter marks to narrow by, so they can't be reviewed.
+func (s *Server) handleGameReview(w http.ResponseWriter, r *http.Request) {
+	if !s.flags.Enabled(featureflags.GameReview) {
+		writeError(w, http.StatusNotFound, "not_found", "no route for "+r.URL.Path)
+		return
+	}
+	id := chi.URLParam(r, "id")
+	ok, err := s.ownsGame(r, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "")
+		return
+	}
+	g, err := s.store.Get(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "not_found", "")
+		return
+	}
+	if !g.Finished {
+		writeError(w, http.StatusConflict, "game_not_finished", "")
+		return
+	}
+	if g.Mode == game.ModeJotto {
+		writeError(w, http.StatusConflict, "review_unavailable", "")
+		return
+	}
+	pool := g.Allowed
+	if pool == nil {
+		pool = words.AnswersLen(g.Cols)
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"guesses": words.ReviewGuesses(pool, g.Answer, g.Guesses)})
+}
+
+// hintRes is the body of POST /game/{id}/hint.
+type hintRes struct {
+	Suggestion string `json:"suggestion"`
+	Remaining  int    `json:"remaining"` // answers still possible, the real one included
+}
+
+// handleGameHint suggests the next guess for an unfinished game the caller
+// owns. Candidates and guesses both come from the game's answer pool (the
+// classic answer list of its length, or its custom allowed list). Jotto games
+// have no per-letter marks to narrow by, so they get no hints. A request
+// cancelled while the hint is computed gets 503.
+func (s *Server) handleGameHint(w http.ResponseWriter, r *http.Request) {
+	if !s.flags.Enabled(featureflags.Hints) {
+		writeError(w, http.StatusNotFound, "not_found", "no route for "+r.URL.Path)
+		return
+	}
+	id := chi.URLParam(r, "id")
+	ok, err := s.ownsGame(r, id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "")
+		return
+	}
+	g, err := s.store.Get(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "not_found", "")
+		return
+	}
+	if g.Finished {
+		writeError(w, http.StatusConflict, "game_finished", "")
+		return
+	}
+	if g.Mode == game.ModeJotto {
+		writeError(w, http.StatusConflict, "hint_unavailable", "")
+		return
+	}
+	pool := g.Allowed
+	if pool == nil {
+		pool = words.AnswersLen(g.Cols)
+	}
+	cands := analysis.Candidates(pool, g.Answer, g.Guesses)
+	suggestion, err := analysis.Hint(r.Context(), pool, cands)
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, "hint_cancelled", "")
+		return
+	}
+	_ = json.NewEncoder(w).Encode(hintRes{Suggestion: suggestion, Remaining: len(cands)})
+}
@@ -0,0 +1,205 @@
+package httpserver
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+func TestGameHistoryTimestamps(t *testing.T) {
+	ts := newTestServer(t, "HISTORY_TIMESTAMPS", "true", "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("historian")
+	list := words.AnswersLen(5)
+	id := c.newGame(newGameReq{Answer: list[0]})
+	played := []string{list[1], list[2], list[0]}
+	for _, w := range played {
+		c.guess(id, w)
+	}
+
+	var res struct {
+		Guesses []historyGuess `json:"guesses"`
+	}
+	if status := c.call("GET", "/games/"+id+"/history", nil, &res); status != http.StatusOK {
+		t.Fatalf("history: status %d", status)
+	}
+	if len(res.Guesses) != len(played) {
+		t.Fatalf("history has %d guesses, want %d", len(res.Guesses), len(played))
+	}
+	var prev time.Time
+	for i, h := range res.Guesses {
+		if h.Seq != i+1 || h.Guess != played[i] {
+			t.Fatalf("entry %d = %+v, want seq %d %q", i, h, i+1, played[i])
+		}
+		at, err := time.Parse(time.RFC3339, h.At)
+		if err != nil || at.Location() != time.UTC {
+			t.Fatalf("entry %d timestamp %q is not UTC RFC3339: %v", i, h.At, err)
+		}
+		if at.Before(prev) {
+			t.Fatalf("entry %d at %s is before the previous guess at %s", i, at, prev)
+		}
+		prev = at
+	}
+
+	_ = ts.flags.Set(featureflags.HistoryTimestamps, false)
+	var off struct {
+		Guesses []historyGuess `json:"guesses"`
+	}
+	c.call("GET", "/games/"+id+"/history", nil, &off)
+	if len(off.Guesses) == 0 || off.Guesses[0].At != "" {
+		t.Fatalf("history with the flag off = %+v, want guesses without timestamps", off.Guesses)
+	}
+
+	if status, _ := ts.client().do("GET", "/games/"+id+"/history", nil); status != http.StatusNotFound {
+		t.Fatalf("history for someone else's game: status %d, want 404", status)
+	}
+}
+
+func TestGameShare(t *testing.T) {
+	ts := newTestServer(t, "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("sharer")
+	list := words.AnswersLen(5)
+	id := c.newGame(newGameReq{Answer: list[0]})
+
+	if status, raw := c.do("GET", "/game/"+id+"/share", nil); status != http.StatusConflict {
+		t.Fatalf("share in play: status %d %s, want 409", status, raw)
+	}
+	c.guess(id, list[0])
+
+	var res struct {
+		Share string `json:"share"`
+	}
+	if status := c.call("GET", "/game/"+id+"/share", nil, &res); status != http.StatusOK {
+		t.Fatalf("share: status %d", status)
+	}
+	if want := "Wordle 1/6\n\n🟩🟩🟩🟩🟩"; res.Share != want {
+		t.Fatalf("share = %q, want %q", res.Share, want)
+	}
+	if status := c.call("GET", "/game/"+id+"/share?contrast=high", nil, &res); status != http.StatusOK || res.Share != "Wordle 1/6\n\n🟧🟧🟧🟧🟧" {
+		t.Fatalf("high contrast share: status %d %q", status, res.Share)
+	}
+
+	other := ts.client()
+	other.signup("snoop")
+	if status, _ := other.do("GET", "/game/"+id+"/share", nil); status != http.StatusNotFound {
+		t.Fatalf("share by another user: status %d, want 404", status)
+	}
+}
+
+func TestGameReview(t *testing.T) {
+	ts := newTestServer(t, "GAME_REVIEW_ENABLED", "true", "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("learner")
+	list := words.AnswersLen(5)
+	id := c.newGame(newGameReq{Answer: list[0]})
+	c.guess(id, list[1])
+
+	if status, raw := c.do("GET", "/game/"+id+"/review", nil); status != http.StatusConflict || errorCode(raw) != "game_not_finished" {
+		t.Fatalf("review in play: status %d %s, want 409 game_not_finished", status, raw)
+	}
+	c.guess(id, list[0])
+
+	var res struct {
+		Guesses []words.GuessReview `json:"guesses"`
+	}
+	if status := c.call("GET", "/game/"+id+"/review", nil, &res); status != http.StatusOK {
+		t.Fatalf("review: status %d", status)
+	}
+	if len(res.Guesses) != 2 || res.Guesses[1].Guess != list[0] || res.Guesses[1].Candidates != 1 {
+		t.Fatalf("review = %+v, want two guesses, the answer pinned down before the last", res.Guesses)
+	}
+	for _, g := range res.Guesses {
+		if g.Grade < "A" || g.Grade > "F" {
+			t.Fatalf("grade %q for %s, want A–F", g.Grade, g.Guess)
+		}
+	}
+
+	other := ts.client()
+	other.signup("peeker")
+	if status, _ := other.do("GET", "/game/"+id+"/review", nil); status != http.StatusNotFound {
+		t.Fatalf("review by another user: status %d, want 404", status)
+	}
+
+	_ = ts.flags.Set(featureflags.GameReview, false)
+	if status, _ := c.do("GET", "/game/"+id+"/review", nil); status != http.StatusNotFound {
+		t.Fatalf("review with flag off: status %d, want 404", status)
+	}
+}
+
+func TestAnswerTagsOnlyOnceFinished(t *testing.T) {
+	// Registered first so it runs after the env is restored.
+	t.Cleanup(func() { _, _ = words.Reload() })
+	list := words.AnswersLen(5)
+	path := filepath.Join(t.TempDir(), "tags.txt")
+	if err := os.WriteFile(path, []byte(list[0]+": bird, garden\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("WORDS_TAGS_FILE", path)
+	if _, err := words.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := newTestServer(t, "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("birder")
+	id := c.newGame(newGameReq{Answer: list[0]})
+
+	if _, raw := c.do("POST", "/game/guess", guessReq{GameID: id, Guess: list[1]}); strings.Contains(string(raw), `"tags"`) {
+		t.Fatalf("guess in play exposes tags: %s", raw)
+	}
+	if _, raw := c.do("GET", "/games/"+id+"/detail", nil); strings.Contains(string(raw), `"tags"`) {
+		t.Fatalf("detail in play exposes tags: %s", raw)
+	}
+
+	want := []string{"bird", "garden"}
+	if _, res := c.guess(id, list[0]); !reflect.DeepEqual(res.Tags, want) {
+		t.Fatalf("winning guess tags = %q, want %q", res.Tags, want)
+	}
+	var detail detailRes
+	if status := c.call("GET", "/games/"+id+"/detail", nil, &detail); status != http.StatusOK || !reflect.DeepEqual(detail.Tags, want) {
+		t.Fatalf("finished detail: status %d, tags %q; want %q", status, detail.Tags, want)
+	}
+}
+
+func TestGameRows(t *testing.T) {
+	ts := newTestServer(t, "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("short_board")
+	list := words.AnswersLen(5)
+
+	var created newGameRes
+	if status := c.call("POST", "/game/new", newGameReq{Answer: list[0], Rows: 3}, &created); status != http.StatusOK || created.Rows != 3 {
+		t.Fatalf("new 3-row game: status %d rows %d", status, created.Rows)
+	}
+	for i, w := range list[1:4] {
+		status, res := c.guess(created.GameID, w)
+		if status != http.StatusOK || res.Remaining != 2-i {
+			t.Fatalf("guess %d: status %d remaining %d", i+1, status, res.Remaining)
+		}
+		if i == 2 && (res.State != "lost" || res.Answer != list[0]) {
+			t.Fatalf("third miss: state %q answer %q, want lost and the answer", res.State, res.Answer)
+		}
+	}
+	if status, _ := c.guess(created.GameID, list[0]); status == http.StatusOK {
+		t.Fatal("a fourth guess was accepted")
+	}
+	var detail detailRes
+	if c.call("GET", "/games/"+created.GameID+"/detail", nil, &detail); detail.Rows != 3 || detail.Status != "lost" {
+		t.Fatalf("detail rows %d status %q, want 3 and lost", detail.Rows, detail.Status)
+	}
+
+	for rows, want := range map[int]int{0: game.DefaultRows, 50: game.MaxRows} {
+		if c.call("POST", "/game/new", newGameReq{Rows: rows}, &created); created.Rows != want {
+			t.Errorf("rows %d: got %d, want %d", rows, created.Rows, want)
+		}
+	}
+}
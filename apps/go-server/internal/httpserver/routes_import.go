@@ -0,0 +1,252 @@
+// apps/go-server/internal/httpserver/routes_import.go
+//
+// Bulk import for migrating from another Wordle backend.
+//   - POST /admin/import → insert users (pre-hashed bcrypt passwords) with their
+//     classic games and daily results; returns per-record success/failure counts
+//
+// Behaviour:
+//   - Users are written in batches (IMPORT_BATCH_SIZE, default 100), one
+//     transaction per batch.
+//   - Existing rows are never overwritten: a duplicate username, game ID, or
+//     (user, date) daily result is reported as a failure.
+//   - A user that fails to import skips its games and daily results (counted
+//     as failures too).
+//   - Body size is capped by IMPORT_MAX_BYTES (default 10 MiB; see bodylimit.go).
+
+package httpserver
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/daily"
+)
+
+// importDoc is the payload for POST /admin/import.
+type importDoc struct {
+	Users []importUser `json:"users"`
+}
+
+type importUser struct {
+	ID           string        `json:"id"` // optional; generated when empty
+	Username     string        `json:"username"`
+	PasswordHash string        `json:"passwordHash"` // bcrypt
+	CreatedAt    string        `json:"createdAt"`    // RFC3339; defaults to now
+	GamesPlayed  int           `json:"gamesPlayed"`
+	Wins         int           `json:"wins"`
+	Streak       int           `json:"streak"`
+	Games        []importGame  `json:"games"`
+	DailyResults []importDaily `json:"dailyResults"`
+}
+
+type importGame struct {
+	ID         string `json:"id"` // optional; generated when empty
+	Answer     string `json:"answer"`
+	StartedAt  string `json:"startedAt"` // RFC3339
+	FinishedAt string `json:"finishedAt"`
+	Status     string `json:"status"` // playing | won | lost | abandoned
+	Guesses    int    `json:"guesses"`
+}
+
+type importDaily struct {
+	Date       string   `json:"date"` // YYYY-MM-DD
+	WordIndex  int      `json:"wordIndex"`
+	Guesses    int      `json:"guesses"`
+	ElapsedMs  int      `json:"elapsedMs"`
+	Difficulty string   `json:"difficulty"`
+	Won        *bool    `json:"won"` // default true
+	Board      []string `json:"board"`
+}
+
+// importCounts tallies one record type.
+type importCounts struct {
+	Imported int `json:"imported"`
+	Failed   int `json:"failed"`
+}
+
+// importError describes one rejected record.
+type importError struct {
+	Record string `json:"record"` // e.g. "users[3]" or "users[3].games[0]"
+	Error  string `json:"error"`
+}
+
+// importReport is returned by POST /admin/import.
+type importReport struct {
+	Users  importCounts  `json:"users"`
+	Games  importCounts  `json:"games"`
+	Daily  importCounts  `json:"daily"`
+	Errors []importError `json:"errors"`
+}
+
+// handleImport validates and inserts an import document.
+func (s *Server) handleImport(w http.ResponseWriter, r *http.Request) {
+	var doc importDoc
+	if err := json.NewDecoder(r.Body).Decode(&doc); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_json", "")
+		return
+	}
+	if len(doc.Users) == 0 {
+		writeError(w, http.StatusBadRequest, "no_users", "")
+		return
+	}
+
+	rep := importReport{Errors: []importError{}}
+	batch := max(envInt("IMPORT_BATCH_SIZE", 100), 1)
+	for start := 0; start < len(doc.Users); start += batch {
+		end := min(start+batch, len(doc.Users))
+		if err := s.importBatch(doc.Users[start:end], start, &rep); err != nil {
+			log.Error().Err(err).Int("from", start).Msg("import batch")
+			writeError(w, http.StatusInternalServerError, "db_error", "")
+			return
+		}
+	}
+	log.Info().Int("users", rep.Users.Imported).Int("games", rep.Games.Imported).
+		Int("daily", rep.Daily.Imported).Int("errors", len(rep.Errors)).Msg("import finished")
+	_ = json.NewEncoder(w).Encode(rep)
+}
+
+// importBatch inserts users[offset:] in one transaction, recording per-record outcomes.
+// Only transaction-level failures are returned as errors.
+func (s *Server) importBatch(users []importUser, offset int, rep *importReport) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	fail := func(record string, err error) {
+		rep.Errors = append(rep.Errors, importError{Record: record, Error: err.Error()})
+	}
+	for i, u := range users {
+		rec := fmt.Sprintf("users[%d]", offset+i)
+		id, err := importUserRow(tx, u)
+		if err != nil {
+			fail(rec, err)
+			rep.Users.Failed++
+			rep.Games.Failed += len(u.Games)
+			rep.Daily.Failed += len(u.DailyResults)
+			continue
+		}
+		rep.Users.Imported++
+
+		for j, g := range u.Games {
+			if err := importGameRow(tx, id, g); err != nil {
+				fail(fmt.Sprintf("%s.games[%d]", rec, j), err)
+				rep.Games.Failed++
+				continue
+			}
+			rep.Games.Imported++
+		}
+		for j, d := range u.DailyResults {
+			if err := importDailyRow(tx, id, d); err != nil {
+				fail(fmt.Sprintf("%s.dailyResults[%d]", rec, j), err)
+				rep.Daily.Failed++
+				continue
+			}
+			rep.Daily.Imported++
+		}
+	}
+	return tx.Commit()
+}
+
+// importUserRow validates and inserts one user, returning its ID.
+func importUserRow(tx *sql.Tx, u importUser) (string, error) {
+	u.Username = normalizeUsername(u.Username)
+	if err := validateUsername(u.Username); err != nil {
+		return "", err
+	}
+	if _, err := bcrypt.Cost([]byte(u.PasswordHash)); err != nil {
+		return "", errors.New("passwordHash: not a bcrypt hash")
+	}
+	var exists int
+	_ = tx.QueryRow(`SELECT 1 FROM users WHERE username_key=?`, usernameKey(u.Username)).Scan(&exists)
+	if exists == 1 {
+		return "", errors.New("username taken")
+	}
+	created, err := importTime(u.CreatedAt, true)
+	if err != nil {
+		return "", fmt.Errorf("createdAt: %w", err)
+	}
+	if u.ID == "" {
+		u.ID = genID()
+	}
+	if _, err := tx.Exec(`INSERT INTO users (id, username, username_key, password_hash, created_at, games_played, wins, streak)
+	                      VALUES (?,?,?,?,?,?,?,?)`,
+		u.ID, u.Username, usernameKey(u.Username), u.PasswordHash, created, u.GamesPlayed, u.Wins, u.Streak); err != nil {
+		return "", err
+	}
+	return u.ID, nil
+}
+
+// importGameRow validates and inserts one classic game for userID.
+func importGameRow(tx *sql.Tx, userID string, g importGame) error {
+	switch g.Status {
+	case "playing", "won", "lost", "abandoned":
+	default:
+		return errors.New("status: must be playing, won, lost, or abandoned")
+	}
+	started, err := importTime(g.StartedAt, false)
+	if err != nil {
+		return fmt.Errorf("startedAt: %w", err)
+	}
+	var finished any
+	if g.FinishedAt != "" {
+		f, err := importTime(g.FinishedAt, false)
+		if err != nil {
+			return fmt.Errorf("finishedAt: %w", err)
+		}
+		finished = f
+	}
+	if g.ID == "" {
+		g.ID = genID()
+	}
+	_, err = tx.Exec(`INSERT INTO games (id, user_id, answer, started_at, finished_at, status, guesses)
+	                  VALUES (?,?,?,?,?,?,?)`,
+		g.ID, userID, strings.ToLower(g.Answer), started, finished, g.Status, g.Guesses)
+	return err
+}
+
+// importDailyRow validates and inserts one daily result for userID.
+func importDailyRow(tx *sql.Tx, userID string, d importDaily) error {
+	if _, err := time.Parse("2006-01-02", d.Date); err != nil {
+		return errors.New("date: want YYYY-MM-DD")
+	}
+	if d.Difficulty == "" {
+		d.Difficulty = daily.DifficultyNormal
+	}
+	if !daily.ValidDifficulty(d.Difficulty) {
+		return errors.New("difficulty: must be easy, normal, or hard")
+	}
+	won := d.Won == nil || *d.Won
+	board, err := json.Marshal(d.Board)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO daily_results (user_id, date, word_index, guesses, elapsed_ms, board, difficulty, won)
+	                  VALUES (?,?,?,?,?,?,?,?)`,
+		userID, d.Date, d.WordIndex, d.Guesses, d.ElapsedMs, string(board), d.Difficulty, won)
+	return err
+}
+
+// importTime normalizes an RFC3339 timestamp to UTC; empty means now when allowed.
+func importTime(v string, emptyIsNow bool) (string, error) {
+	if v == "" {
+		if emptyIsNow {
+			return time.Now().UTC().Format(time.RFC3339), nil
+		}
+		return "", errors.New("required")
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return "", errors.New("want RFC3339")
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
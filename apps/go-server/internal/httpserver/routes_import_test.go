@@ -0,0 +1,67 @@
+package httpserver
+
+import (
+	"net/http"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestImport(t *testing.T) {
+	ts := newTestServer(t, "ADMIN_TOKEN", "admin-secret", "IMPORT_BATCH_SIZE", "2")
+	existing := ts.client()
+	existing.signup("taken")
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	lost := false
+	doc := importDoc{Users: []importUser{
+		{
+			Username: "migrant", PasswordHash: string(hash), GamesPlayed: 2, Wins: 1,
+			Games: []importGame{
+				{ID: "old-1", Answer: "crane", StartedAt: "2024-01-01T10:00:00Z", FinishedAt: "2024-01-01T10:05:00Z", Status: "won", Guesses: 4},
+				{ID: "old-2", Answer: "slate", StartedAt: "2024-01-02T10:00:00Z", FinishedAt: "2024-01-02T10:05:00Z", Status: "lost", Guesses: 6},
+			},
+			DailyResults: []importDaily{
+				{Date: "2024-01-01", WordIndex: 3, Guesses: 4, ElapsedMs: 60000},
+				{Date: "2024-01-02", WordIndex: 4, Guesses: 6, ElapsedMs: 90000, Won: &lost},
+			},
+		},
+		{Username: "taken", PasswordHash: string(hash), Games: []importGame{{ID: "old-3", Answer: "crane", Status: "won", Guesses: 2}}},
+		{Username: "plaintext", PasswordHash: "password123"},
+	}}
+
+	if status, _ := existing.do("POST", "/admin/import", doc); status != http.StatusUnauthorized && status != http.StatusForbidden {
+		t.Fatalf("import without the admin token: status %d", status)
+	}
+	var rep importReport
+	if status := ts.client().call("POST", "/admin/import", doc, &rep, "X-Admin-Token", "admin-secret"); status != http.StatusOK {
+		t.Fatalf("import: status %d", status)
+	}
+	if rep.Users != (importCounts{Imported: 1, Failed: 2}) || rep.Games != (importCounts{Imported: 2, Failed: 1}) || rep.Daily != (importCounts{Imported: 2}) {
+		t.Fatalf("report = %+v", rep)
+	}
+	var dup bool
+	for _, e := range rep.Errors {
+		dup = dup || e.Record == "users[1]"
+	}
+	if !dup {
+		t.Fatalf("errors %+v don't report the duplicate username", rep.Errors)
+	}
+
+	// The existing account is untouched and the migrant can log in.
+	var takenGames int
+	if err := ts.db.QueryRow(`SELECT COUNT(*) FROM games g JOIN users u ON u.id=g.user_id WHERE u.username='taken'`).Scan(&takenGames); err != nil || takenGames != 0 {
+		t.Fatalf("games on the existing account: %d, %v", takenGames, err)
+	}
+	if ts.countRows("daily_results") != 2 {
+		t.Fatalf("%d daily results, want 2", ts.countRows("daily_results"))
+	}
+	if status, raw := ts.client().do("POST", "/auth/login", map[string]string{"username": "migrant", "password": "password123"}); status != http.StatusOK {
+		t.Fatalf("login as imported user: %d %s", status, raw)
+	}
+
+	// Importing again overwrites nothing.
+	ts.client().call("POST", "/admin/import", doc, &rep, "X-Admin-Token", "admin-secret")
+	if rep.Users.Imported != 0 || rep.Users.Failed != 3 {
+		t.Fatalf("re-import report = %+v, want every user rejected", rep.Users)
+	}
+}
@@ -0,0 +1,252 @@
+// apps/go-server/internal/httpserver/routes_links.go
+//
+// Short links for sharing a classic challenge between players (guests included).
+//   - POST /links        → store {mode, answer, allowed} and return {slug, expiresAt}
+//   - GET  /links/{slug} → the challenge's public fields {slug, mode, length, customAllowed, expiresAt}
+//   - POST /game/new {"link": slug} starts a game from the link (see handleNewGame)
+//
+// The answer stays server-side: resolving a link never returns it. Omitting
+// "answer" picks a random one when the link is created, so everyone who opens
+// the link plays the same word.
+//
+// "allowed" (custom_allowed flag) replaces the global guess list for games
+// started from the link, for themed puzzles. Every word must be alphabetic and
+// the same length; the answer must be one of them, and is picked from them
+// when omitted. /game/new accepts the same field for a custom game.
+//
+// Config:
+//   - short_links flag (SHORT_LINKS_ENABLED=true) serves /links and accepts
+//     "link" on /game/new; when off the routes 404.
+//   - SHORT_LINK_TTL              lifetime of a link (default 168h)
+//   - SHORT_LINK_RATE_PER_MIN     links created per minute per caller (0 = unlimited; default 10)
+//   - SHORT_LINK_RATE_BURST       creation burst (default 5)
+//   - SHORT_LINK_PURGE_INTERVAL   how often expired links are deleted (default 1h)
+//   - custom_allowed flag (CUSTOM_ALLOWED_ENABLED=true) accepts "allowed"
+//   - CUSTOM_ALLOWED_MAX          most words in a custom allowed list (default 2000)
+
+package httpserver
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// errLinkNotFound is returned by resolveLink for unknown or expired slugs.
+var errLinkNotFound = errors.New("link not found")
+
+// mountLinkRoutes registers /links. Creation is rate limited per caller.
+func (s *Server) mountLinkRoutes() {
+	perMin := envInt("SHORT_LINK_RATE_PER_MIN", 10)
+	lim := newLimiter(perMin, envInt("SHORT_LINK_RATE_BURST", 5))
+	limited := func(next http.Handler) http.Handler {
+		if perMin <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ok, wait := lim.allow(rateKey(r)); !ok {
+				tooManyRequests(w, wait)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+	s.r.Route("/links", func(r chi.Router) {
+		r.Use(s.requireShortLinks)
+		r.With(s.withOptionalAuth(), limited).Post("/", s.handleCreateLink)
+		r.Get("/{slug}", s.handleGetLink)
+	})
+}
+
+// requireShortLinks 404s /links while the short_links flag is off.
+func (s *Server) requireShortLinks(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.flags.Enabled(featureflags.ShortLinks) {
+			writeError(w, http.StatusNotFound, "not_found", "no route for "+r.URL.Path)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// linkReq is the payload for POST /links.
+type linkReq struct {
+	Mode    string   `json:"mode"`    // as for /game/new; default "normal"
+	Answer  string   `json:"answer"`  // optional; must be an allowed word
+	Allowed []string `json:"allowed"` // optional custom guess list (custom_allowed flag)
+}
+
+// linkRes describes a link. Answer and the custom list are deliberately absent.
+type linkRes struct {
+	Slug          string    `json:"slug"`
+	Mode          string    `json:"mode"`
+	Length        int       `json:"length"`
+	CustomAllowed bool      `json:"customAllowed,omitempty"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+// shortLink is a resolved, unexpired link.
+type shortLink struct {
+	Mode      game.Mode
+	Answer    string
+	Allowed   []string // custom guess list; nil = the global list
+	ExpiresAt time.Time
+}
+
+// handleCreateLink validates the challenge and stores it under a new slug.
+func (s *Server) handleCreateLink(w http.ResponseWriter, r *http.Request) {
+	var req linkReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_json", "")
+		return
+	}
+	mode, err := game.ParseMode(req.Mode)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_mode", "")
+		return
+	}
+	allowed, answer, ok := s.customAllowed(w, req.Allowed, strings.ToLower(strings.TrimSpace(req.Answer)))
+	if !ok {
+		return
+	}
+	if answer == "" {
+		answer = words.RandomAnswer()
+	} else if allowed == nil && !words.IsAllowed(answer) {
+		writeError(w, http.StatusBadRequest, "invalid_answer", "")
+		return
+	}
+
+	creator := ""
+	if me, _ := r.Context().Value(ctxUserKey{}).(*authUser); me != nil {
+		creator = me.ID
+	} else {
+		creator = s.ensureAnonID(w, r)
+	}
+	slug, exp, err := s.createLink(r.Context(), mode, answer, allowed, creator)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(linkRes{Slug: slug, Mode: string(mode), Length: len(answer), CustomAllowed: allowed != nil, ExpiresAt: exp})
+}
+
+// customAllowed validates a request's custom allowed-guess list against its
+// (lowercased) answer. It returns the normalized list, or nil when none was
+// given, and the answer, picked from the list when empty. On a bad request it
+// writes the 400 and returns ok=false.
+func (s *Server) customAllowed(w http.ResponseWriter, list []string, answer string) (allowed []string, ans string, ok bool) {
+	if len(list) == 0 {
+		return nil, answer, true
+	}
+	if !s.flags.Enabled(featureflags.CustomAllowed) {
+		writeError(w, http.StatusBadRequest, "custom_allowed_disabled", "")
+		return nil, "", false
+	}
+	if len(list) > envInt("CUSTOM_ALLOWED_MAX", 2000) {
+		writeError(w, http.StatusBadRequest, "allowed_too_large", "")
+		return nil, "", false
+	}
+	allowed, err := game.NormalizeAllowed(list)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_allowed", "")
+		return nil, "", false
+	}
+	if answer == "" {
+		n, _ := rand.Int(rand.Reader, big.NewInt(int64(len(allowed))))
+		return allowed, allowed[n.Int64()], true
+	}
+	if i := sort.SearchStrings(allowed, answer); i == len(allowed) || allowed[i] != answer {
+		writeError(w, http.StatusBadRequest, "answer_not_in_allowed", "")
+		return nil, "", false
+	}
+	return allowed, answer, true
+}
+
+// createLink stores a challenge under a new slug and returns it with its
+// expiry (SHORT_LINK_TTL from now). Neither the answer nor allowed (nil for
+// the global list) is validated here.
+func (s *Server) createLink(ctx context.Context, mode game.Mode, answer string, allowed []string, creator string) (string, time.Time, error) {
+	now := time.Now().UTC()
+	exp := now.Add(envDuration("SHORT_LINK_TTL", 7*24*time.Hour))
+	list, err := json.Marshal(allowed)
+	if err != nil || allowed == nil {
+		list = []byte("[]")
+	}
+
+	// Retry on the (unlikely) slug collision.
+	for attempt := 0; ; attempt++ {
+		slug := newSlug()
+		_, err := s.db.ExecContext(ctx,
+			`INSERT INTO short_links (slug, mode, answer, allowed, created_by, created_at, expires_at) VALUES (?,?,?,?,?,?,?)`,
+			slug, string(mode), answer, string(list), creator, now.Format(time.RFC3339), exp.Format(time.RFC3339))
+		if err == nil {
+			return slug, exp.Truncate(time.Second), nil
+		}
+		if attempt >= 2 || !strings.Contains(err.Error(), "UNIQUE") {
+			return "", time.Time{}, err
+		}
+	}
+}
+
+// handleGetLink returns a link's public fields; 404 once unknown or expired.
+func (s *Server) handleGetLink(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	lk, err := s.resolveLink(r.Context(), slug)
+	if errors.Is(err, errLinkNotFound) {
+		writeError(w, http.StatusNotFound, "link_not_found", "")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	_ = json.NewEncoder(w).Encode(linkRes{Slug: slug, Mode: string(lk.Mode), Length: len(lk.Answer), CustomAllowed: lk.Allowed != nil, ExpiresAt: lk.ExpiresAt})
+}
+
+// resolveLink loads an unexpired link.
+func (s *Server) resolveLink(ctx context.Context, slug string) (shortLink, error) {
+	var mode, allowed, expires string
+	var lk shortLink
+	err := s.db.QueryRowContext(ctx,
+		`SELECT mode, answer, allowed, expires_at FROM short_links WHERE slug=?`, slug,
+	).Scan(&mode, &lk.Answer, &allowed, &expires)
+	if errors.Is(err, sql.ErrNoRows) {
+		return shortLink{}, errLinkNotFound
+	}
+	if err != nil {
+		return shortLink{}, err
+	}
+	lk.Mode, lk.ExpiresAt = game.Mode(mode), mustParse(expires)
+	if !time.Now().UTC().Before(lk.ExpiresAt) {
+		return shortLink{}, errLinkNotFound
+	}
+	if err := json.Unmarshal([]byte(allowed), &lk.Allowed); err != nil {
+		return shortLink{}, err
+	}
+	if len(lk.Allowed) == 0 {
+		lk.Allowed = nil
+	}
+	return lk, nil
+}
+
+// newSlug returns an 8-character URL-safe random slug.
+func newSlug() string {
+	var b [6]byte
+	_, _ = rand.Read(b[:])
+	return base64.RawURLEncoding.EncodeToString(b[:])
+}
@@ -0,0 +1,122 @@
+package httpserver
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+func TestShortLinks(t *testing.T) {
+	ts := newTestServer(t, "SHORT_LINKS_ENABLED", "true")
+	maker := ts.client()
+	answer := words.AnswersLen(5)[0]
+
+	var created linkRes
+	if status := maker.call("POST", "/links", linkReq{Answer: answer}, &created); status != http.StatusCreated || created.Slug == "" {
+		t.Fatalf("create: status %d %+v", status, created)
+	}
+	if until := time.Until(created.ExpiresAt); until < 167*time.Hour || until > 169*time.Hour {
+		t.Fatalf("expiresAt %v, want about 168h out", created.ExpiresAt)
+	}
+	if status, raw := maker.do("POST", "/links", linkReq{Answer: "zzzzz"}); status != http.StatusBadRequest {
+		t.Fatalf("non-word answer: status %d %s, want 400", status, raw)
+	}
+
+	// A second guest resolves and plays the link without seeing the answer.
+	player := ts.client()
+	status, raw := player.do("GET", "/links/"+created.Slug, nil)
+	if status != http.StatusOK || strings.Contains(string(raw), answer) {
+		t.Fatalf("resolve: status %d %s", status, raw)
+	}
+	var got linkRes
+	player.call("GET", "/links/"+created.Slug, nil, &got)
+	if got.Mode != "normal" || got.Length != 5 || !got.ExpiresAt.Equal(created.ExpiresAt) {
+		t.Fatalf("resolved %+v, created %+v", got, created)
+	}
+	id := player.newGame(newGameReq{Link: created.Slug})
+	if status, res := player.guess(id, answer); status != http.StatusOK || res.State != "won" {
+		t.Fatalf("playing the link's answer: status %d state %q", status, res.State)
+	}
+
+	if _, err := ts.db.Exec(`UPDATE short_links SET expires_at=? WHERE slug=?`,
+		time.Now().UTC().Add(-time.Minute).Format(time.RFC3339), created.Slug); err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := player.do("GET", "/links/"+created.Slug, nil); status != http.StatusNotFound {
+		t.Fatalf("expired link: status %d, want 404", status)
+	}
+	if status, _ := player.do("POST", "/game/new", newGameReq{Link: created.Slug}); status == http.StatusOK {
+		t.Fatal("game started from an expired link")
+	}
+	if status, _ := player.do("GET", "/links/nosuchln", nil); status != http.StatusNotFound {
+		t.Fatalf("unknown link: status %d, want 404", status)
+	}
+}
+
+func TestShortLinksRateLimitAndFlag(t *testing.T) {
+	ts := newTestServer(t, "SHORT_LINKS_ENABLED", "true", "SHORT_LINK_RATE_PER_MIN", "1", "SHORT_LINK_RATE_BURST", "1")
+	c := ts.client()
+	c.signup("linker") // a stable rate key from the first request
+	if status, raw := c.do("POST", "/links", linkReq{}); status != http.StatusCreated {
+		t.Fatalf("first link: status %d %s", status, raw)
+	}
+	if status, _ := c.do("POST", "/links", linkReq{}); status != http.StatusTooManyRequests {
+		t.Fatalf("second link: status %d, want 429", status)
+	}
+
+	_ = ts.flags.Set(featureflags.ShortLinks, false)
+	if status, _ := c.do("POST", "/links", linkReq{}); status != http.StatusNotFound {
+		t.Fatalf("links with the flag off: status %d, want 404", status)
+	}
+}
+
+func TestCustomAllowedGames(t *testing.T) {
+	ts := newTestServer(t, "CUSTOM_ALLOWED_ENABLED", "true", "CUSTOM_ALLOWED_MAX", "4", "SHORT_LINKS_ENABLED", "true")
+	c := ts.client()
+	animals := []string{"Zebra", "horse", "camel"}
+	global := words.AnswersLen(5)[0]
+
+	id := c.newGame(newGameReq{Allowed: animals})
+	if status, _ := c.guess(id, global); status != http.StatusBadRequest {
+		t.Fatalf("global word %q in an animals game: status %d, want 400", global, status)
+	}
+	if status, res := c.guess(id, "camel"); status != http.StatusOK || res.State == "" {
+		t.Fatalf("camel: status %d %+v", status, res)
+	}
+
+	var lk linkRes
+	if status := c.call("POST", "/links", linkReq{Allowed: animals, Answer: "horse"}, &lk); status != http.StatusCreated || !lk.CustomAllowed {
+		t.Fatalf("custom link: status %d %+v", status, lk)
+	}
+	friend := ts.client()
+	linked := friend.newGame(newGameReq{Link: lk.Slug})
+	if status, _ := friend.guess(linked, global); status != http.StatusBadRequest {
+		t.Fatalf("global word in a linked animals game: status %d, want 400", status)
+	}
+	if status, res := friend.guess(linked, "horse"); status != http.StatusOK || res.State != "won" {
+		t.Fatalf("linked answer: status %d state %q", status, res.State)
+	}
+
+	for _, tc := range []struct {
+		name string
+		req  linkReq
+		code string
+	}{
+		{"answer outside the list", linkReq{Allowed: animals, Answer: "crane"}, "answer_not_in_allowed"},
+		{"mixed lengths", linkReq{Allowed: []string{"zebra", "cat"}}, "invalid_allowed"},
+		{"too many", linkReq{Allowed: []string{"aaaaa", "bbbbb", "ccccc", "ddddd", "eeeee"}}, "allowed_too_large"},
+	} {
+		if status, raw := c.do("POST", "/links", tc.req); status != http.StatusBadRequest || errorCode(raw) != tc.code {
+			t.Errorf("%s: status %d %s, want 400 %s", tc.name, status, raw, tc.code)
+		}
+	}
+
+	_ = ts.flags.Set(featureflags.CustomAllowed, false)
+	if status, raw := c.do("POST", "/game/new", newGameReq{Allowed: animals}); status != http.StatusBadRequest || errorCode(raw) != "custom_allowed_disabled" {
+		t.Fatalf("flag off: status %d %s", status, raw)
+	}
+}
@@ -0,0 +1,107 @@
+// apps/go-server/internal/httpserver/routes_results.go
+//
+// Signed result tokens for shareable, verifiable classic game outcomes.
+//   - POST /game/guess includes "resultToken" in the response that finishes a game.
+//   - GET /game/verify?token= → the decoded result if the token is authentic
+//     and unexpired: {gameId, guesses, result, issuedAt, expiresAt}
+//
+// Tokens are HS256 JWTs carrying the game ID, guess count, and outcome only;
+// the answer is never included. A "typ" claim separates them from auth tokens,
+// which share the JWT library (and, by default, the secret).
+//
+// Config:
+//   - result_tokens flag (RESULT_TOKENS_ENABLED=true) issues tokens and serves
+//     /game/verify; when off the route 404s.
+//   - RESULT_TOKEN_SECRET: signing key (default: JWT_SECRET).
+//   - RESULT_TOKEN_TTL: token lifetime (Go duration; default 720h).
+
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+)
+
+// resultTokenType is the "typ" claim value of result tokens.
+const resultTokenType = "result"
+
+// resultClaims is the payload of a result token.
+type resultClaims struct {
+	Type    string `json:"typ"`
+	GameID  string `json:"gid"`
+	Guesses int    `json:"guesses"`
+	Result  string `json:"result"` // "won" | "lost"
+	jwt.RegisteredClaims
+}
+
+// resultSecret returns the key result tokens are signed with.
+func resultSecret() []byte {
+	return []byte(getEnv("RESULT_TOKEN_SECRET", getEnv("JWT_SECRET", "dev_secret_change_me")))
+}
+
+// signResult issues a token for c, valid for RESULT_TOKEN_TTL from now.
+func signResult(c resultClaims) (string, error) {
+	now := time.Now()
+	c.Type = resultTokenType
+	c.IssuedAt = jwt.NewNumericDate(now)
+	c.ExpiresAt = jwt.NewNumericDate(now.Add(envDuration("RESULT_TOKEN_TTL", 30*24*time.Hour)))
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, &c).SignedString(resultSecret())
+}
+
+// parseResult validates tok's signature, expiry, and type and returns its claims.
+func parseResult(tok string) (*resultClaims, error) {
+	var c resultClaims
+	if _, err := jwt.ParseWithClaims(tok, &c, func(*jwt.Token) (interface{}, error) {
+		return resultSecret(), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired()); err != nil {
+		return nil, err
+	}
+	if c.Type != resultTokenType || c.GameID == "" {
+		return nil, errors.New("not a result token")
+	}
+	return &c, nil
+}
+
+// verifyRes is returned by /game/verify.
+type verifyRes struct {
+	GameID    string    `json:"gameId"`
+	Guesses   int       `json:"guesses"`
+	Result    string    `json:"result"`
+	IssuedAt  time.Time `json:"issuedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// handleVerifyResult decodes a result token, rejecting forged or expired ones.
+func (s *Server) handleVerifyResult(w http.ResponseWriter, r *http.Request) {
+	if !s.flags.Enabled(featureflags.ResultTokens) {
+		writeError(w, http.StatusNotFound, "not_found", "no route for "+r.URL.Path)
+		return
+	}
+	tok := r.URL.Query().Get("token")
+	if tok == "" {
+		writeError(w, http.StatusBadRequest, "token_required", "")
+		return
+	}
+	c, err := parseResult(tok)
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		writeError(w, http.StatusBadRequest, "token_expired", "")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_token", "")
+		return
+	}
+	_ = json.NewEncoder(w).Encode(verifyRes{
+		GameID:    c.GameID,
+		Guesses:   c.Guesses,
+		Result:    c.Result,
+		IssuedAt:  c.IssuedAt.Time.UTC(),
+		ExpiresAt: c.ExpiresAt.Time.UTC(),
+	})
+}
@@ -0,0 +1,64 @@
+package httpserver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+func TestResultTokens(t *testing.T) {
+	ts := newTestServer(t, "RESULT_TOKENS_ENABLED", "true", "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("bragger")
+	answer := words.AnswersLen(5)[0]
+	id := c.newGame(newGameReq{Answer: answer})
+	c.guess(id, words.AnswersLen(5)[1])
+	_, res := c.guess(id, answer)
+	if res.ResultToken == "" {
+		t.Fatal("no result token on the winning guess")
+	}
+	parts := strings.Split(res.ResultToken, ".")
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	if strings.Contains(string(payload), answer) {
+		t.Fatalf("token payload %s carries the answer", payload)
+	}
+
+	verify := func(tok string) (int, []byte, verifyRes) {
+		var v verifyRes
+		status, raw := ts.client().do("GET", "/game/verify?token="+url.QueryEscape(tok), nil)
+		if status == http.StatusOK {
+			_ = json.Unmarshal(raw, &v)
+		}
+		return status, raw, v
+	}
+
+	status, raw, v := verify(res.ResultToken)
+	if status != http.StatusOK || v.GameID != id || v.Guesses != 2 || v.Result != "won" || !v.ExpiresAt.After(v.IssuedAt) {
+		t.Fatalf("valid token: %d %s", status, raw)
+	}
+
+	// Tampered: claim a one-guess win with the original signature.
+	forged := strings.Replace(string(payload), `"guesses":2`, `"guesses":1`, 1)
+	tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(forged)) + "." + parts[2]
+	if status, raw, _ := verify(tampered); status != http.StatusBadRequest || errorCode(raw) != "invalid_token" {
+		t.Fatalf("tampered token: %d %s, want 400 invalid_token", status, raw)
+	}
+
+	t.Setenv("RESULT_TOKEN_TTL", "-1m")
+	expired, err := signResult(resultClaims{GameID: id, Guesses: 2, Result: "won"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, raw, _ := verify(expired); status != http.StatusBadRequest || errorCode(raw) != "token_expired" {
+		t.Fatalf("expired token: %d %s, want 400 token_expired", status, raw)
+	}
+
+	if status, raw, _ := verify(""); status != http.StatusBadRequest || errorCode(raw) != "token_required" {
+		t.Fatalf("no token: %d %s, want 400 token_required", status, raw)
+	}
+}
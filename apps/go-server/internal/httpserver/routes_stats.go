@@ -0,0 +1,80 @@
+// apps/go-server/internal/httpserver/routes_stats.go
+//
+// Derived statistics for the logged-in user.
+//   - GET /stats/badges → achievements earned from finished classic games and
+//     daily results (rules in internal/badges)
+//
+// Notes:
+//   - Classic games contribute once finished (status won/lost). Durations are
+//     only known when PERSIST_GAME_RESULTS recorded them.
+//   - Daily results count as wins or losses per their won flag; their elapsed
+//     time counts as the duration.
+
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/badges"
+)
+
+// handleBadges returns the caller's earned badges, oldest first.
+func (s *Server) handleBadges(w http.ResponseWriter, r *http.Request) {
+	me, _ := r.Context().Value(ctxUserKey{}).(*authUser)
+	if me == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
+		return
+	}
+	history, err := s.badgeHistory(me.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "db_error", "")
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"badges": badges.Compute(history)})
+}
+
+// badgeHistory loads the user's finished classic games and daily wins.
+func (s *Server) badgeHistory(userID string) ([]badges.Result, error) {
+	var out []badges.Result
+
+	rows, err := s.db.Query(`SELECT finished_at, status, guesses, COALESCE(duration_ms, 0)
+	                           FROM games
+	                          WHERE user_id=? AND status IN ('won','lost') AND finished_at IS NOT NULL`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var at, status string
+		var res badges.Result
+		if err := rows.Scan(&at, &status, &res.Guesses, &res.DurationMs); err != nil {
+			return nil, err
+		}
+		if res.At, err = time.Parse(time.RFC3339, at); err != nil {
+			continue
+		}
+		res.Won = status == "won"
+		out = append(out, res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	drows, err := s.db.Query(`SELECT created_at, guesses, elapsed_ms, won FROM daily_results WHERE user_id=?`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer drows.Close()
+	for drows.Next() {
+		var at time.Time
+		res := badges.Result{Daily: true}
+		if err := drows.Scan(&at, &res.Guesses, &res.DurationMs, &res.Won); err != nil {
+			return nil, err
+		}
+		res.At = at.UTC()
+		out = append(out, res)
+	}
+	return out, drows.Err()
+}
@@ -0,0 +1,37 @@
+// apps/go-server/internal/httpserver/routes_ui.go
+//
+// Embedded smoke-test UI.
+//   - GET /play → a single static page (ui/play.html) that plays classic games
+//     against this server's own /game endpoints
+//
+// Meant for checking a deployment end to end from a browser without the real
+// frontend. It is compiled into the binary but served only with the serve_ui
+// flag (SERVE_UI=true); otherwise /play 404s like any unknown path.
+
+package httpserver
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+)
+
+//go:embed ui/play.html
+var playPage []byte
+
+// mountUI registers /play.
+func (s *Server) mountUI() {
+	s.r.Get("/play", s.handlePlayPage)
+}
+
+// handlePlayPage serves the embedded board, or the JSON 404 while disabled.
+func (s *Server) handlePlayPage(w http.ResponseWriter, r *http.Request) {
+	if !s.flags.Enabled(featureflags.ServeUI) {
+		writeError(w, http.StatusNotFound, "not_found", "no route for "+r.URL.Path)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write(playPage)
+}
@@ -0,0 +1,27 @@
+package httpserver
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPlayPage(t *testing.T) {
+	ts := newTestServer(t)
+	if status, _ := ts.client().do("GET", "/play", nil); status != http.StatusNotFound {
+		t.Fatalf("SERVE_UI off: status %d, want 404", status)
+	}
+
+	on := newTestServer(t, "SERVE_UI", "true")
+	resp, err := http.Get(on.url + "/play")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		t.Fatalf("SERVE_UI on: status %d Content-Type %q, want 200 HTML", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if status, raw := on.client().do("GET", "/play", nil); status != http.StatusOK || !strings.Contains(string(raw), "/game/new") {
+		t.Fatalf("page does not call the game API: status %d", status)
+	}
+}
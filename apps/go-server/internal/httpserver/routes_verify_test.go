@@ -0,0 +1,55 @@
+package httpserver
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+)
+
+func TestAccountVerification(t *testing.T) {
+	ts := newTestServer(t, "REQUIRE_ACCOUNT_VERIFICATION", "true")
+	c := ts.client()
+	uid := c.signup("newbie")
+	if status, raw := c.do("GET", "/stats/me", nil); status != http.StatusForbidden || errorCode(raw) != "unverified" {
+		t.Fatalf("unverified /stats/me: status %d %s, want 403 unverified", status, raw)
+	}
+
+	// The signup token only goes to the log; issue a fresh one to redeem.
+	token, err := ts.issueVerification(uid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status, raw := c.do("POST", "/auth/verify", verifyReq{Token: "nope"}); status != http.StatusBadRequest || errorCode(raw) != "invalid_token" {
+		t.Fatalf("wrong token: status %d %s", status, raw)
+	}
+	if _, err := ts.db.Exec(`UPDATE users SET verify_expires_at=? WHERE id=?`,
+		time.Now().UTC().Add(-time.Minute).Format(time.RFC3339), uid); err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := c.do("POST", "/auth/verify", verifyReq{Token: token}); status != http.StatusBadRequest {
+		t.Fatalf("expired token: status %d, want 400", status)
+	}
+
+	if token, err = ts.issueVerification(uid); err != nil {
+		t.Fatal(err)
+	}
+	if status, raw := c.do("POST", "/auth/verify", verifyReq{Token: token}); status != http.StatusOK {
+		t.Fatalf("verify: status %d %s", status, raw)
+	}
+	if status, _ := c.do("GET", "/stats/me", nil); status != http.StatusOK {
+		t.Fatalf("verified /stats/me: status %d, want 200", status)
+	}
+	if status, _ := c.do("POST", "/auth/verify", verifyReq{Token: token}); status != http.StatusBadRequest {
+		t.Fatalf("reused token: status %d, want 400", status)
+	}
+
+	// Turning the flag off lets unverified accounts through again.
+	other := ts.client()
+	other.signup("waiting")
+	_ = ts.flags.Set(featureflags.AccountVerify, false)
+	if status, _ := other.do("GET", "/stats/me", nil); status != http.StatusOK {
+		t.Fatalf("flag off: status %d, want 200", status)
+	}
+}
@@ -0,0 +1,279 @@
+// apps/go-server/internal/httpserver/routes_words.go
+//
+// Word-list utility endpoints.
+//   - GET /words/match → words matching a positional pattern
+//     (?pattern=c.a.e&contains=r&excludes=xyz&source=allowed|answers&limit=N)
+//   - GET /words/analyze → per-position mark counts when a corpus of common
+//     openers is scored against ?word= (for choosing daily answers)
+//   - POST /score → stateless scoring of candidate guesses against a given
+//     answer, reporting allowed-list membership per word (for solver authors)
+//   - POST /game/validate → whether {word} is an allowed guess (case-insensitive),
+//     so clients can flag "not in word list" before submitting; no auth, no
+//     game state
+//   - GET /debug/words → loaded answer/allowed counts; ?sample=N (1–maxWordSample,
+//     debug_words flag) adds N random 5-letter answers and allowed words, to
+//     check which lists a deployment loaded
+//   - ?layout=qwerty|azerty|dvorak on /words/analyze and /score adds each
+//     opener's / guess's typing distance on that keyboard (words.TypingDistance),
+//     for speed-typing players; omitted unless asked for
+//
+// Config:
+//   - words_match flag (WORDS_MATCH_ENABLED=true) enables /words/match; off by
+//     default since it can be used to solve puzzles. When off the route 404s.
+//   - Results are capped at maxMatchResults regardless of ?limit.
+//   - With WORDS_ALLOWED_BLOOM=true only source=answers can be matched;
+//     source=allowed answers 501.
+//   - /score accepts at most maxScoreGuesses guesses per request.
+//   - words_analyze flag (WORDS_ANALYZE_ENABLED=true) enables /words/analyze.
+//     Since it scores arbitrary answers it also requires the admin token
+//     (X-Admin-Token); otherwise it would be a scoring oracle for the daily.
+//   - ANALYZE_OPENERS: comma-separated opener corpus (default defaultOpeners).
+//   - debug_words flag (DEBUG_WORDS_ENABLED=true) enables ?sample on
+//     /debug/words; off by default, since samples leak answers. When off
+//     ?sample is ignored and only the counts are returned. Under
+//     WORDS_ALLOWED_BLOOM the allowed sample is omitted.
+
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// maxMatchResults bounds /words/match responses.
+const maxMatchResults = 200
+
+// maxScoreGuesses bounds the guesses accepted by POST /score.
+const maxScoreGuesses = 100
+
+// maxWordSample bounds ?sample on /debug/words.
+const maxWordSample = 100
+
+// defaultOpeners is the ANALYZE_OPENERS fallback: popular first guesses.
+const defaultOpeners = "crane,slate,adieu,raise,arise,stare,trace,audio,roate,soare,salet,least"
+
+// mountWordRoutes registers /words/* and /score utilities.
+func (s *Server) mountWordRoutes() {
+	s.r.Get("/words/match", s.handleWordsMatch)
+	s.r.With(s.requireAdminToken()).Get("/words/analyze", s.handleWordsAnalyze)
+	s.r.Post("/score", s.handleScore)
+	s.r.Post("/game/validate", s.handleValidate)
+}
+
+// debugWordsRes is returned by /debug/words.
+type debugWordsRes struct {
+	Answers       int      `json:"answers"`
+	Allowed       int      `json:"allowed"`
+	SampleAnswers []string `json:"sampleAnswers,omitempty"` // ?sample only
+	SampleAllowed []string `json:"sampleAllowed,omitempty"` // ?sample only; omitted for a Bloom allowed list
+}
+
+// handleDebugWords reports the loaded word counts and, with ?sample=N while
+// debug_words is on, N random words from each list.
+func (s *Server) handleDebugWords(w http.ResponseWriter, r *http.Request) {
+	var res debugWordsRes
+	res.Answers, res.Allowed = words.Stats()
+	if q := r.URL.Query().Get("sample"); q != "" && s.flags.Enabled(featureflags.DebugWords) {
+		n, err := strconv.Atoi(q)
+		if err != nil || n < 1 || n > maxWordSample {
+			writeError(w, http.StatusBadRequest, "invalid_sample", "")
+			return
+		}
+		res.SampleAnswers, res.SampleAllowed = words.Sample(n)
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// matchRes is returned by /words/match.
+type matchRes struct {
+	Words     []string `json:"words"`
+	Truncated bool     `json:"truncated"` // more words matched than were returned
+}
+
+// handleWordsMatch returns allowed (default) or answer words matching the pattern.
+func (s *Server) handleWordsMatch(w http.ResponseWriter, r *http.Request) {
+	if !s.flags.Enabled(featureflags.WordsMatch) {
+		writeError(w, http.StatusNotFound, "not_found", "no route for "+r.URL.Path)
+		return
+	}
+	q := r.URL.Query()
+	limit := 50
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "invalid_limit", "")
+			return
+		}
+		limit = min(n, maxMatchResults)
+	}
+	var fromAnswers bool
+	switch q.Get("source") {
+	case "", "allowed":
+	case "answers":
+		fromAnswers = true
+	default:
+		writeError(w, http.StatusBadRequest, "invalid_source", "")
+		return
+	}
+
+	// Ask for one extra to detect truncation.
+	list, err := words.Match(q.Get("pattern"), q.Get("contains"), q.Get("excludes"), fromAnswers, limit+1)
+	if errors.Is(err, words.ErrAllowedUnavailable) {
+		writeError(w, http.StatusNotImplemented, "allowed_list_unavailable", "")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_pattern", "")
+		return
+	}
+	res := matchRes{Words: list}
+	if len(list) > limit {
+		res.Words, res.Truncated = list[:limit], true
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// handleWordsAnalyze scores the opener corpus against ?word= and returns the
+// per-position mark counts. 404 while the words_analyze flag is off.
+func (s *Server) handleWordsAnalyze(w http.ResponseWriter, r *http.Request) {
+	if !s.flags.Enabled(featureflags.WordsAnalyze) {
+		writeError(w, http.StatusNotFound, "not_found", "no route for "+r.URL.Path)
+		return
+	}
+	word := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("word")))
+	if len(word) < words.MinLength || len(word) > words.MaxLength || !lettersOnly(word) {
+		writeError(w, http.StatusBadRequest, "invalid_word", "")
+		return
+	}
+	layout, ok := layoutParam(w, r)
+	if !ok {
+		return
+	}
+	var openers []string
+	for _, o := range strings.Split(getEnv("ANALYZE_OPENERS", defaultOpeners), ",") {
+		if o = strings.ToLower(strings.TrimSpace(o)); o != "" && lettersOnly(o) {
+			openers = append(openers, o)
+		}
+	}
+	res := analyzeRes{Analysis: words.AnalyzeOpeners(word, openers)}
+	if layout != nil {
+		res.Layout = layout.Name
+		res.Typing = make(map[string]float64, len(res.Openers))
+		for _, o := range res.Openers {
+			res.Typing[o], _ = words.TypingDistance(o, *layout)
+		}
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// analyzeRes is returned by /words/analyze.
+type analyzeRes struct {
+	words.Analysis
+	Layout string             `json:"layout,omitempty"` // ?layout= keyboard
+	Typing map[string]float64 `json:"typing,omitempty"` // opener → typing distance (key widths)
+}
+
+// layoutParam reads the optional ?layout= keyboard. It writes a 400 and
+// reports false for an unknown layout; a nil Layout means none was asked for.
+func layoutParam(w http.ResponseWriter, r *http.Request) (*words.Layout, bool) {
+	name := r.URL.Query().Get("layout")
+	if name == "" {
+		return nil, true
+	}
+	l, ok := words.LayoutByName(name)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid_layout", "layout must be qwerty, azerty, or dvorak")
+		return nil, false
+	}
+	return &l, true
+}
+
+// validateReq/Res are the payloads for POST /game/validate.
+type validateReq struct {
+	Word string `json:"word"`
+}
+
+type validateRes struct {
+	Allowed bool `json:"allowed"`
+	Length  int  `json:"length"` // letters (runes) in the trimmed word
+}
+
+// handleValidate reports whether a word is an allowed guess for its own
+// length. Words outside MinLength–MaxLength or with non-letters are never
+// allowed.
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	var req validateReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_json", "")
+		return
+	}
+	word := game.NormalizeGuess(req.Word)
+	res := validateRes{Length: utf8.RuneCountInString(word)}
+	res.Allowed = len(word) >= words.MinLength && len(word) <= words.MaxLength && lettersOnly(word) && words.IsAllowed(word)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// scoreReq is the payload for POST /score.
+type scoreReq struct {
+	Answer       string   `json:"answer"`
+	Guesses      []string `json:"guesses"`
+	CheckAllowed *bool    `json:"checkAllowed"` // default true: words not in the allowed list are not scored
+}
+
+// scoreEntry is the result for one guess.
+type scoreEntry struct {
+	Word    string `json:"word"`
+	Allowed bool   `json:"allowed"` // in the allowed list for the answer's length
+	Scored  bool   `json:"scored"`  // marks were computed
+	Marks   []int  `json:"marks"`   // 0=miss, 1=present, 2=hit; null when not scored
+
+	Typing *float64 `json:"typing,omitempty"` // typing distance on ?layout= (key widths); letters-only words
+}
+
+// handleScore scores each guess against the supplied answer without touching
+// any game state. Malformed words (wrong length, non-letters) are never scored.
+func (s *Server) handleScore(w http.ResponseWriter, r *http.Request) {
+	var req scoreReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_json", "")
+		return
+	}
+	answer := strings.ToLower(strings.TrimSpace(req.Answer))
+	if len(answer) < words.MinLength || len(answer) > words.MaxLength || !lettersOnly(answer) {
+		writeError(w, http.StatusBadRequest, "invalid_answer", "")
+		return
+	}
+	if len(req.Guesses) > maxScoreGuesses {
+		writeError(w, http.StatusBadRequest, "too_many_guesses", "")
+		return
+	}
+	check := req.CheckAllowed == nil || *req.CheckAllowed
+	layout, ok := layoutParam(w, r)
+	if !ok {
+		return
+	}
+
+	out := make([]scoreEntry, 0, len(req.Guesses))
+	for _, g := range req.Guesses {
+		word := strings.ToLower(strings.TrimSpace(g))
+		e := scoreEntry{Word: word, Allowed: words.IsAllowedLen(word, len(answer))}
+		if len(word) == len(answer) && lettersOnly(word) && (e.Allowed || !check) {
+			e.Scored, e.Marks = true, words.Score(word, answer)
+		}
+		if layout != nil {
+			if d, ok := words.TypingDistance(word, *layout); ok && word != "" {
+				e.Typing = &d
+			}
+		}
+		out = append(out, e)
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"results": out})
+}
@@ -0,0 +1,185 @@
+package httpserver
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+func TestScoreReportsAllowedPerEntry(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client()
+	list := words.AnswersLen(5)
+	answer, allowed := list[0], list[1]
+
+	var res struct {
+		Results []scoreEntry `json:"results"`
+	}
+	guesses := []string{" " + allowed + " ", "qzxvj", "cat", "ab1de"}
+	if status := c.call("POST", "/score", scoreReq{Answer: answer, Guesses: guesses}, &res); status != http.StatusOK {
+		t.Fatalf("score: status %d", status)
+	}
+	want := []scoreEntry{
+		{Word: allowed, Allowed: true, Scored: true, Marks: words.Score(allowed, answer)},
+		{Word: "qzxvj"},
+		{Word: "cat"},
+		{Word: "ab1de"},
+	}
+	if len(res.Results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(res.Results), len(want))
+	}
+	for i, w := range want {
+		g := res.Results[i]
+		if g.Word != w.Word || g.Allowed != w.Allowed || g.Scored != w.Scored || !slices.Equal(g.Marks, w.Marks) {
+			t.Errorf("entry %d = %+v, want %+v", i, g, w)
+		}
+	}
+
+	// checkAllowed=false scores well-formed words outside the list, never malformed ones.
+	off := false
+	c.call("POST", "/score", scoreReq{Answer: answer, Guesses: []string{"qzxvj", "cat"}, CheckAllowed: &off}, &res)
+	if r := res.Results[0]; r.Allowed || !r.Scored || len(r.Marks) != 5 {
+		t.Errorf("unchecked qzxvj = %+v, want scored but not allowed", r)
+	}
+	if r := res.Results[1]; r.Scored || r.Marks != nil {
+		t.Errorf("unchecked cat = %+v, want unscored", r)
+	}
+
+	if status, raw := c.do("POST", "/score", scoreReq{Answer: "no", Guesses: guesses}); status != http.StatusBadRequest || errorCode(raw) != "invalid_answer" {
+		t.Errorf("bad answer: %d %s, want 400 invalid_answer", status, raw)
+	}
+	many := make([]string, maxScoreGuesses+1)
+	if status, raw := c.do("POST", "/score", scoreReq{Answer: answer, Guesses: many}); status != http.StatusBadRequest || errorCode(raw) != "too_many_guesses" {
+		t.Errorf("too many guesses: %d %s, want 400 too_many_guesses", status, raw)
+	}
+}
+
+func TestWordsAnalyzeIsAdminOnly(t *testing.T) {
+	ts := newTestServer(t, "WORDS_ANALYZE_ENABLED", "true", "ADMIN_TOKEN", "s3cret", "ANALYZE_OPENERS", "trace, slate,AUDIO")
+	c := ts.client()
+	if status, _ := c.do("GET", "/words/analyze?word=crane", nil); status != http.StatusForbidden {
+		t.Fatalf("without the admin token: status %d, want 403", status)
+	}
+
+	var res analyzeRes
+	if status := c.call("GET", "/words/analyze?word=CRANE", nil, &res, "X-Admin-Token", "s3cret"); status != http.StatusOK {
+		t.Fatalf("analyze: status %d", status)
+	}
+	if want := words.AnalyzeOpeners("crane", []string{"trace", "slate", "audio"}); res.Answer != "crane" ||
+		!slices.Equal(res.Openers, want.Openers) || !slices.Equal(res.Positions, want.Positions) {
+		t.Fatalf("analysis = %+v, want %+v", res.Analysis, want)
+	}
+	if status, _ := c.do("GET", "/words/analyze?word=cr4ne", nil, "X-Admin-Token", "s3cret"); status != http.StatusBadRequest {
+		t.Fatalf("non-letter word: status %d, want 400", status)
+	}
+
+	_ = ts.flags.Set(featureflags.WordsAnalyze, false)
+	if status, _ := c.do("GET", "/words/analyze?word=crane", nil, "X-Admin-Token", "s3cret"); status != http.StatusNotFound {
+		t.Fatalf("flag off: status %d, want 404", status)
+	}
+}
+
+func TestScoreTypingDistance(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client()
+	list := words.AnswersLen(5)
+	req := scoreReq{Answer: list[0], Guesses: []string{list[1], "ab1de"}}
+
+	var res struct {
+		Results []scoreEntry `json:"results"`
+	}
+	if c.call("POST", "/score", req, &res); res.Results[0].Typing != nil {
+		t.Fatalf("typing without ?layout: %v", *res.Results[0].Typing)
+	}
+	for _, name := range []string{"qwerty", "dvorak"} {
+		if status := c.call("POST", "/score?layout="+name, req, &res); status != http.StatusOK {
+			t.Fatalf("%s: status %d", name, status)
+		}
+		l, _ := words.LayoutByName(name)
+		want, _ := words.TypingDistance(list[1], l)
+		if d := res.Results[0].Typing; d == nil || *d != want {
+			t.Fatalf("%s: typing %v, want %v", name, d, want)
+		}
+		if res.Results[1].Typing != nil {
+			t.Fatalf("%s: typing given for a malformed guess", name)
+		}
+	}
+	if status, raw := c.do("POST", "/score?layout=colemak", req); status != http.StatusBadRequest || errorCode(raw) != "invalid_layout" {
+		t.Fatalf("unknown layout: %d %s, want 400 invalid_layout", status, raw)
+	}
+}
+
+func TestValidateWord(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client() // no signup: the endpoint is unauthenticated
+	known := words.AnswersLen(5)[0]
+
+	for _, tc := range []struct {
+		word    string
+		allowed bool
+		length  int
+	}{
+		{known, true, 5},
+		{strings.ToUpper(known), true, 5},
+		{"  " + strings.ToUpper(known[:1]) + known[1:] + "\n", true, 5},
+		{"qzxvj", false, 5},
+		{"ab1de", false, 5},
+		{"cat", false, 3},
+		{"", false, 0},
+		{"crème", false, 5},
+	} {
+		var res validateRes
+		if status := c.call("POST", "/game/validate", validateReq{Word: tc.word}, &res); status != http.StatusOK {
+			t.Fatalf("%q: status %d", tc.word, status)
+		}
+		if res.Allowed != tc.allowed || res.Length != tc.length {
+			t.Errorf("%q = %+v, want allowed %v length %d", tc.word, res, tc.allowed, tc.length)
+		}
+	}
+	if n := ts.countRows("games"); n != 0 {
+		t.Fatalf("validate created %d games", n)
+	}
+	if status, raw := c.do("POST", "/game/validate", "{"); status != http.StatusBadRequest || errorCode(raw) != "bad_json" {
+		t.Fatalf("bad json: %d %s", status, raw)
+	}
+}
+
+func TestDebugWordsSample(t *testing.T) {
+	ts := newTestServer(t, "DEBUG_WORDS_ENABLED", "true")
+	c := ts.client()
+
+	var res debugWordsRes
+	if status := c.call("GET", "/debug/words?sample=7", nil, &res); status != http.StatusOK {
+		t.Fatalf("sample: status %d", status)
+	}
+	if res.Answers == 0 || len(res.SampleAnswers) != 7 || len(res.SampleAllowed) != 7 {
+		t.Fatalf("sample = %+v, want counts and 7 words from each list", res)
+	}
+	for _, w := range append(res.SampleAnswers, res.SampleAllowed...) {
+		if len(w) != 5 || !words.IsAllowed(w) {
+			t.Fatalf("sampled %q, want a loaded 5-letter word", w)
+		}
+	}
+	for _, w := range res.SampleAnswers {
+		if !slices.Contains(words.AnswersLen(5), w) {
+			t.Fatalf("answer sample has %q, not an answer", w)
+		}
+	}
+
+	for _, q := range []string{"0", "-3", "abc", "101"} {
+		if status, raw := c.do("GET", "/debug/words?sample="+q, nil); status != http.StatusBadRequest || errorCode(raw) != "invalid_sample" {
+			t.Errorf("sample=%s: status %d %s, want 400 invalid_sample", q, status, raw)
+		}
+	}
+
+	// Off: ?sample is ignored and only the counts come back.
+	_ = ts.flags.Set(featureflags.DebugWords, false)
+	status, raw := c.do("GET", "/debug/words?sample=7", nil)
+	if status != http.StatusOK || strings.Contains(string(raw), "sample") {
+		t.Fatalf("flag off: status %d %s, want counts only", status, raw)
+	}
+}
@@ -2,11 +2,14 @@
 //
 // HTTP server wiring for the Wordle backend.
 // Responsibilities:
-//   - Router + middleware (JSON, CORS, timeouts, panic recovery, request IDs).
+//   - Router + middleware (JSON, CORS, timeouts, panic recovery, request IDs,
+//     body limit, metrics).
 //   - Public endpoints: "/", "/health".
-//   - Game endpoints (optional auth): POST /game/new, POST /game/guess.
+//   - Game endpoints (optional auth): /game/*, /links, /challenges/*.
 //   - Daily Challenge endpoints (optional auth): mounted under /daily.
-//   - Auth + profile/stat endpoints (require auth): /auth/*, /stats/me, /games/mine.
+//   - Auth + profile/stat endpoints (require auth): /auth/*, /stats/*, /games/mine.
+//   - Admin endpoints: mounted under /admin.
+//   - Utilities: /words/*, /score, /bootstrap, /play, /metrics.
 //   - JWT + cookie handling, anonymous session cookie, user CRUD helpers.
 //   - Database persistence for games and user stats.
 //
@@ -15,6 +18,7 @@
 //   - Optional auth decorates requests with user context when a valid token is present;
 //     routes can still run for guests.
 //   - Require‑auth middleware enforces presence and validity of a JWT.
+//   - Feature flags (internal/featureflags) gate optional routes at runtime.
 
 package httpserver
 
@@ -23,22 +27,32 @@ import (
 	"crypto/rand"
 	"database/sql"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/mail"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/go-chi/chi/v5"
 	chimw "github.com/go-chi/chi/v5/middleware"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/text/unicode/norm"
 
+	"github.com/robalobadob/wordle/apps/go-server/internal/analysis"
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
 	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+	"github.com/robalobadob/wordle/apps/go-server/internal/metrics"
 	"github.com/robalobadob/wordle/apps/go-server/internal/store"
+	"github.com/robalobadob/wordle/apps/go-server/internal/webhook"
 	"github.com/robalobadob/wordle/apps/go-server/internal/words"
 )
 
@@ -47,17 +61,43 @@ type Server struct {
 	r     *chi.Mux
 	store store.Store
 	db    *sql.DB
+	flags *featureflags.Flags // runtime toggles (env defaults, /admin/flags overrides)
+
+	scorer func() *analysis.Scorer // answer difficulty over words.Answers(), built on first use
+	hooks  webhook.Notifier        // completion webhooks (webhook.Nop unless WEBHOOK_URL is set)
+
+	metrics *metrics.Registry // request + gauge series for /metrics (metrics flag)
+	idem    *idemCache        // Idempotency-Key responses for /game/guess
+
+	bg     context.Context    // lifetime of background jobs
+	cancel context.CancelFunc // stops background jobs (see Close)
+
+	httpSrv *http.Server // listener for Start; drained by Shutdown
 }
 
 // New constructs a Server, installs middleware, and registers routes.
 func New(st store.Store, db *sql.DB) *Server {
-	s := &Server{r: chi.NewRouter(), store: st, db: db}
+	s := &Server{
+		r:     chi.NewRouter(),
+		store: st,
+		db:    db,
+		flags: featureflags.New(),
+		scorer: sync.OnceValue(func() *analysis.Scorer {
+			return analysis.NewScorer(words.Answers())
+		}),
+		metrics: metrics.New(),
+		idem:    newIdemCache(envDuration("IDEMPOTENCY_TTL", 10*time.Minute)),
+	}
+	s.bg, s.cancel = context.WithCancel(context.Background())
+	s.httpSrv = &http.Server{Handler: s.r}
 
 	// --- middleware ---
 	s.r.Use(chimw.RequestID)                 // add X-Request-ID
 	s.r.Use(chimw.RealIP)                    // set RemoteAddr from X-Forwarded-For etc.
+	s.r.Use(s.withMetrics())                 // request counts + latency (metrics flag)
 	s.r.Use(chimw.Recoverer)                 // recover from panics
 	s.r.Use(chimw.Timeout(10 * time.Second)) // bound handler time
+	s.r.Use(withBodyLimit())                 // 413 past MAX_BODY_BYTES (bodylimit.go)
 	s.r.Use(jsonContentType)                 // default JSON responses
 	s.r.Use(corsFromEnv)                     // credentials-friendly CORS
 
@@ -71,32 +111,81 @@ func New(st store.Store, db *sql.DB) *Server {
 		_, _ = w.Write([]byte(`{"ok":true}`))
 	})
 
-	// Game endpoints — OPTIONAL AUTH (guests can play)
-	s.r.With(s.withOptionalAuth()).Post("/game/new", s.handleNewGame)
-	s.r.With(s.withOptionalAuth()).Post("/game/guess", s.handleGuess)
+	// Game endpoints — OPTIONAL AUTH (guests can play unless guests_allowed is off),
+	// throttled per user/anon ID after auth has identified the caller.
+	// Closed with 503 while the maintenance flag is on.
+	play := s.r.With(s.withMaintenance(), s.playAuth(), s.withUserRateLimit())
+	play.Post("/game/new", s.handleNewGame)
+	play.Post("/game/guess", s.handleGuess)
+	play.Post("/game/{id}/hint", s.handleGameHint) // hints flag
 
 	// Daily Challenge — OPTIONAL AUTH (guests can play; progress persisted on win)
-	s.mountDaily(s.r.With(s.withOptionalAuth()))
+	dd := s.mountDaily(play)
+
+	// One-call client start-up payload (bootstrap flag)
+	s.mountBootstrap(dd)
+
+	// Per-game history (owner only: user or anon cookie)
+	s.mountGameRoutes()
 
 	// Auth + profile/stats (require auth)
 	s.mountAuthRoutes()
 
-	// JSON 404 for easier debugging
+	// Operator endpoints (ADMIN_TOKEN)
+	s.mountAdmin()
+
+	// Word-list utilities: /words/match (words_match flag), stateless /score
+	s.mountWordRoutes()
+
+	// Shareable challenge links (short_links flag)
+	s.mountLinkRoutes()
+
+	// Per-challenge leaderboards for link games (challenge_boards flag)
+	s.mountChallengeRoutes(play)
+
+	// Embedded smoke-test board at /play (serve_ui flag)
+	s.mountUI()
+
+	// Prometheus scrape endpoint (metrics flag)
+	s.mountMetrics()
+
+	// Background jobs
+	s.startAbandonSweep()
+	s.startWebhooks()
+	s.startLinkPurge()
+	s.startStoreSweep()
+
+	// JSON 404/405 for easier debugging
 	s.r.NotFound(func(w http.ResponseWriter, r *http.Request) {
-		http.Error(w, `{"error":"not_found","path":"`+r.URL.Path+`"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, "not_found", "no route for "+r.URL.Path)
 	})
-
-	// Debug: word list counts
-	s.r.Get("/debug/words", func(w http.ResponseWriter, r *http.Request) {
-		a, g := words.Stats()
-		_ = json.NewEncoder(w).Encode(map[string]int{"answers": a, "allowed": g})
+	s.r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", r.Method+" not allowed on "+r.URL.Path)
 	})
 
+	// Debug: word list counts (plus random samples with the debug_words flag)
+	s.r.Get("/debug/words", s.handleDebugWords)
+
 	return s
 }
 
 // Start begins serving HTTP on addr.
-func (s *Server) Start(addr string) error { return http.ListenAndServe(addr, s.r) }
+// It returns nil once Shutdown has stopped it.
+func (s *Server) Start(addr string) error {
+	s.httpSrv.Addr = addr
+	if err := s.httpSrv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting connections and waits for in-flight requests to
+// finish, or for ctx to end. Games in the memory store are not flushed;
+// background jobs keep running until Close.
+func (s *Server) Shutdown(ctx context.Context) error { return s.httpSrv.Shutdown(ctx) }
+
+// Close stops background jobs started by the server.
+func (s *Server) Close() { s.cancel() }
 
 // Router exposes the internal router (useful for tests).
 func (s *Server) Router() chi.Router { return s.r }
@@ -122,8 +211,9 @@ func corsFromEnv(next http.Handler) http.Handler {
 		w.Header().Set("Vary", "Origin")
 		w.Header().Set("Access-Control-Allow-Origin", origin)
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
-		w.Header().Set("Access-Control-Allow-Methods", "GET,POST,OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET,POST,DELETE,OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Idempotency-Key")
+		w.Header().Set("Access-Control-Expose-Headers", "Idempotent-Replayed")
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusNoContent)
 			return
@@ -136,45 +226,113 @@ func corsFromEnv(next http.Handler) http.Handler {
 
 // newGameReq/Res payloads for POST /game/new.
 type newGameReq struct {
-	Mode   string `json:"mode"`   // "normal" | "cheat" (cheat currently ignored)
-	Answer string `json:"answer"` // optional fixed answer (testing)
+	Mode   string `json:"mode"`   // "normal" | "hard" | "jotto" | "cheat" (adversarial; the answer dodges guesses) | "practice" (seeded)
+	Answer string `json:"answer"` // optional fixed answer; ignored unless the fixed_answer flag is on (dev/test)
+	Link   string `json:"link"`   // optional short-link slug; overrides mode, answer, and allowed
+	Rows   int    `json:"rows"`   // optional max guesses (game.MinRows–MaxRows, clamped); ignored for links
+
+	Allowed []string `json:"allowed"` // optional custom guess list (custom_allowed flag; see customAllowed)
+
+	Seed string `json:"seed"` // practice only: picks the answer (game.NewSeeded); answer and allowed are ignored
 }
 type newGameRes struct {
 	GameID string `json:"gameId"`
+	Rows   int    `json:"rows"`
+	Seed   string `json:"seed,omitempty"` // practice only: the seed sent, or a generated one, to replay or share
 }
 
+// maxSeedLen bounds a practice seed.
+const maxSeedLen = 64
+
 // handleNewGame creates a new in-memory game and persists a DB "owner" row
 // (either user_id or anonymous_id) for history/stats.
 func (s *Server) handleNewGame(w http.ResponseWriter, r *http.Request) {
 	var req newGameReq
 	_ = json.NewDecoder(r.Body).Decode(&req)
+	mode, err := game.ParseMode(req.Mode)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_mode", "")
+		return
+	}
+	var allowed []string
+	var seed string
+	if req.Link != "" {
+		if !s.flags.Enabled(featureflags.ShortLinks) {
+			writeError(w, http.StatusNotFound, "link_not_found", "")
+			return
+		}
+		lk, err := s.resolveLink(r.Context(), req.Link)
+		if errors.Is(err, errLinkNotFound) {
+			writeError(w, http.StatusNotFound, "link_not_found", "")
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "db_error", "")
+			return
+		}
+		mode, req.Answer, allowed = lk.Mode, lk.Answer, lk.Allowed
+		req.Rows = 0 // link games share one board size so their leaderboards compare
+	} else if mode == game.ModePractice {
+		if seed = strings.TrimSpace(req.Seed); seed == "" {
+			seed = newSlug()
+		}
+		if len(seed) > maxSeedLen {
+			writeError(w, http.StatusBadRequest, "invalid_seed", "")
+			return
+		}
+	} else {
+		if !s.flags.Enabled(featureflags.FixedAnswer) {
+			req.Answer = "" // a client-chosen answer is a free win outside dev/test
+		}
+		var ok bool
+		allowed, req.Answer, ok = s.customAllowed(w, req.Allowed, strings.ToLower(strings.TrimSpace(req.Answer)))
+		if !ok {
+			return
+		}
+	}
 
 	// Create game (random answer by default if req.Answer is empty)
-	g := game.New(req.Answer)
+	var g *game.Game
+	if seed != "" {
+		g = game.NewSeeded(seed, req.Rows)
+	} else {
+		g = game.New(req.Answer, req.Rows)
+		g.Mode = mode
+	}
+	g.Allowed = allowed
 	if err := s.store.Save(r.Context(), g); err != nil {
 		log.Error().Err(err).Msg("save game")
-		http.Error(w, `{"error":"save_failed"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, "save_failed", "")
 		return
 	}
 
-	// Persist owner row; do NOT store answer in DB unless schema requires it
+	// Persist owner row; do NOT store answer in DB unless schema requires it.
+	// The link slug (if any) ties the game to its challenge leaderboard; the
+	// setup snapshot (game_recovery flag only) lets RecoverGames rebuild it.
 	now := time.Now().UTC().Format(time.RFC3339)
+	link := sql.NullString{String: req.Link, Valid: req.Link != ""}
+	var setup sql.NullString
+	if s.flags.Enabled(featureflags.GameRecovery) {
+		if b, err := game.Marshal(g); err == nil {
+			setup = sql.NullString{String: string(b), Valid: true}
+		}
+	}
 	if me, _ := r.Context().Value(ctxUserKey{}).(*authUser); me != nil {
-		_, err := s.db.Exec(`INSERT INTO games (id, user_id, answer, started_at, status, guesses)
-		                     VALUES (?,?,?,?,?,0)`, g.ID, me.ID, "", now, "playing")
+		_, err := s.db.Exec(`INSERT INTO games (id, user_id, answer, started_at, status, guesses, link, setup)
+		                     VALUES (?,?,?,?,?,0,?,?)`, g.ID, me.ID, "", now, "playing", link, setup)
 		if err != nil {
 			log.Warn().Err(err).Str("gameId", g.ID).Msg("insert user game row")
 		}
 	} else {
 		anon := s.ensureAnonID(w, r)
-		_, err := s.db.Exec(`INSERT INTO games (id, anonymous_id, answer, started_at, status, guesses)
-		                     VALUES (?,?,?,?,?,0)`, g.ID, anon, "", now, "playing")
+		_, err := s.db.Exec(`INSERT INTO games (id, anonymous_id, answer, started_at, status, guesses, link, setup)
+		                     VALUES (?,?,?,?,?,0,?,?)`, g.ID, anon, "", now, "playing", link, setup)
 		if err != nil {
 			log.Warn().Err(err).Str("gameId", g.ID).Msg("insert anon game row")
 		}
 	}
 
-	_ = json.NewEncoder(w).Encode(newGameRes{GameID: g.ID})
+	_ = json.NewEncoder(w).Encode(newGameRes{GameID: g.ID, Rows: g.Rows, Seed: seed})
 }
 
 // guessReq/Res payloads for POST /game/guess.
@@ -183,70 +341,180 @@ type guessReq struct {
 	Guess  string `json:"guess"`
 }
 type guessRes struct {
-	Marks []game.Mark `json:"marks"`
-	State string      `json:"state"` // "playing" | "won" | "lost"
+	Marks any    `json:"marks,omitempty"` // per-letter marks (normal mode); see negotiateMarks
+	Count *int   `json:"count,omitempty"` // shared-letter count (jotto mode)
+	State string `json:"state"`           // "playing" | "won" | "lost"
+
+	GuessNumber int `json:"guessNumber"` // 1-based number of the guess just scored
+	Remaining   int `json:"remaining"`   // rows left (g.Rows - guesses so far); may be > 0 after a win
+
+	Answer string `json:"answer,omitempty"` // once finished (won or lost); never while playing
+
+	Evaluated   string   `json:"evaluated,omitempty"`   // the guess as scored (evaluated_guess flag; see game.NormalizeGuess)
+	ResultToken string   `json:"resultToken,omitempty"` // signed result once finished (result_tokens flag)
+	Tags        []string `json:"tags,omitempty"`        // answer's category tags once finished (WORDS_TAGS_FILE)
 }
 
 // handleGuess applies a guess to an in-memory game, persists progress,
 // and (if finished) updates user stats in a best-effort transaction.
+// A repeated Idempotency-Key replays the first response (see idempotency.go).
 func (s *Server) handleGuess(w http.ResponseWriter, r *http.Request) {
 	var req guessReq
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, `{"error":"bad_json"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "bad_json", "")
 		return
 	}
+	s.withIdempotency(w, r, req.GameID, game.NormalizeGuess(req.Guess), func(w http.ResponseWriter) {
+		s.applyGuess(w, r, req)
+	})
+}
+
+// applyGuess is handleGuess after decoding.
+func (s *Server) applyGuess(w http.ResponseWriter, r *http.Request, req guessReq) {
 	g, err := s.store.Get(r.Context(), req.GameID)
 	if err != nil {
-		http.Error(w, `{"error":"not_found"}`, http.StatusNotFound)
+		writeError(w, http.StatusNotFound, "not_found", "")
 		return
 	}
 	marks, state, err := g.ApplyGuess(req.Guess)
 	if err != nil {
-		http.Error(w, `{"error":"`+err.Error()+`"}`, http.StatusBadRequest)
+		s.writeLocalized(w, r, http.StatusBadRequest, "invalid_guess", err.Error())
 		return
 	}
 	if err := s.store.Save(r.Context(), g); err != nil {
-		http.Error(w, `{"error":"save_failed"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, "save_failed", "")
 		return
 	}
 
 	// Persist counters/history (best effort, non-fatal if it fails)
 	me, _ := r.Context().Value(ctxUserKey{}).(*authUser)
-	ownerClause := `anonymous_id=?`
-	ownerArg := any(s.ensureAnonID(w, r))
+	ownerClause := `user_id=?`
+	var ownerArg any
 	if me != nil {
-		ownerClause = `user_id=?`
-		ownerArg = any(me.ID)
+		ownerArg = me.ID
+	} else {
+		ownerClause = `anonymous_id=?`
+		ownerArg = s.ensureAnonID(w, r)
 	}
 
 	tx, _ := s.db.Begin()
 	defer func() { _ = tx.Rollback() }()
 
-	if _, err := tx.Exec(`UPDATE games SET guesses = guesses + 1 WHERE id=? AND `+ownerClause, g.ID, ownerArg); err != nil {
+	// Any guess counts as activity and revives a game the sweep marked abandoned.
+	if _, err := tx.Exec(`UPDATE games
+	                         SET guesses = guesses + 1, last_activity = ?,
+	                             status = CASE WHEN status='abandoned' THEN 'playing' ELSE status END
+	                       WHERE id=? AND `+ownerClause, time.Now().UTC().Format(time.RFC3339), g.ID, ownerArg); err != nil {
 		log.Warn().Err(err).Msg("update guesses")
 	}
+	if _, err := tx.Exec(`INSERT OR IGNORE INTO game_guesses (game_id, seq, guess, created_at) VALUES (?,?,?,?)`,
+		g.ID, len(g.Guesses), g.Guesses[len(g.Guesses)-1], time.Now().UTC().Format(time.RFC3339)); err != nil {
+		log.Warn().Err(err).Msg("record guess")
+	}
 
 	if state == "won" || state == "lost" {
-		if _, err := tx.Exec(`UPDATE games SET status=?, finished_at=? WHERE id=? AND `+ownerClause,
-			state, time.Now().UTC().Format(time.RFC3339), g.ID, ownerArg); err != nil {
+		if err := s.finishGame(tx, g, state, ownerClause, ownerArg); err != nil {
 			log.Warn().Err(err).Msg("finish game")
 		}
 		if me != nil {
-			if err := s.bumpStats(tx, me.ID, state == "won"); err != nil {
+			if err := s.bumpStats(tx, me.ID, state == "won", len(g.Guesses)); err != nil {
 				log.Warn().Err(err).Str("user", me.ID).Msg("bump stats")
 			}
 		}
 	}
 	_ = tx.Commit()
 
-	_ = json.NewEncoder(w).Encode(guessRes{Marks: marks, State: state})
+	if state == "won" || state == "lost" {
+		ev := webhook.Event{Type: webhook.GameCompleted, GameID: g.ID, Result: state, Guesses: len(g.Guesses), At: time.Now().UTC()}
+		if me != nil {
+			ev.UserID = me.ID
+		}
+		s.notify(ev)
+	}
+
+	res := guessRes{Marks: negotiateMarks(w, r).classic(marks), State: state, GuessNumber: len(g.Guesses), Remaining: g.Rows - len(g.Guesses)}
+	if s.flags.Enabled(featureflags.EvaluatedGuess) {
+		res.Evaluated = g.Guesses[len(g.Guesses)-1]
+	}
+	if g.Mode == game.ModeJotto {
+		res.Count = &g.Counts[len(g.Counts)-1]
+	}
+	if state == "won" || state == "lost" {
+		res.Answer = g.Answer
+		res.Tags = words.Tags(g.Answer)
+	}
+	if (state == "won" || state == "lost") && s.flags.Enabled(featureflags.ResultTokens) {
+		if tok, err := signResult(resultClaims{GameID: g.ID, Guesses: len(g.Guesses), Result: state}); err != nil {
+			log.Warn().Err(err).Msg("sign result token")
+		} else {
+			res.ResultToken = tok
+		}
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// finishGame writes the finish record for a completed game (within tx).
+// By default only status and finished_at are set; with PERSIST_GAME_RESULTS=true
+// the final guess count, answer, win flag, and duration are recorded as well.
+// With the game_difficulty flag on, the answer's difficulty score is stored too.
+func (s *Server) finishGame(tx *sql.Tx, g *game.Game, state, ownerClause string, ownerArg any) error {
+	now := time.Now().UTC()
+	if s.flags.Enabled(featureflags.GameDifficulty) {
+		if _, err := tx.Exec(`UPDATE games SET difficulty=? WHERE id=? AND `+ownerClause,
+			s.scorer().Difficulty(g.Answer), g.ID, ownerArg); err != nil {
+			return err
+		}
+	}
+	if !s.flags.Enabled(featureflags.PersistGameResults) {
+		_, err := tx.Exec(`UPDATE games SET status=?, finished_at=? WHERE id=? AND `+ownerClause,
+			state, now.Format(time.RFC3339), g.ID, ownerArg)
+		return err
+	}
+
+	var started string
+	if err := tx.QueryRow(`SELECT started_at FROM games WHERE id=? AND `+ownerClause, g.ID, ownerArg).Scan(&started); err != nil {
+		return err
+	}
+	duration := int64(0)
+	if t := mustParse(started); !t.IsZero() {
+		duration = now.Sub(t).Milliseconds()
+	}
+	won := 0
+	if g.Won {
+		won = 1
+	}
+	_, err := tx.Exec(`UPDATE games
+	                   SET status=?, finished_at=?, guesses=?, answer=?, won=?, duration_ms=?
+	                   WHERE id=? AND `+ownerClause,
+		state, now.Format(time.RFC3339), len(g.Guesses), g.Answer, won, duration, g.ID, ownerArg)
+	return err
 }
 
 // ------------------------------- AUTH --------------------------------------
 
 // Request payloads for signup/login.
-type signupReq struct{ Username, Password string }
-type loginReq struct{ Username, Password string }
+// With LOGIN_IDENTIFIER=either, Username may also hold an email address at login.
+type signupReq struct{ Username, Email, Password string }
+type loginReq struct{ Username, Email, Password string }
+
+// Login identifiers (LOGIN_IDENTIFIER):
+//   - username (default): username required at signup (email optional); log in by username.
+//   - email:  email required at signup (username optional, generated if omitted); log in by email.
+//   - either: username required, email optional at signup; log in by username or email.
+const (
+	identUsername = "username"
+	identEmail    = "email"
+	identEither   = "either"
+)
+
+// loginIdentifier returns the configured LOGIN_IDENTIFIER (unknown values → username).
+func loginIdentifier() string {
+	switch v := strings.ToLower(getEnv("LOGIN_IDENTIFIER", identUsername)); v {
+	case identEmail, identEither:
+		return v
+	}
+	return identUsername
+}
 
 // authUser is placed into request context by auth middleware.
 type authUser struct {
@@ -254,70 +522,89 @@ type authUser struct {
 	Username string `json:"username"`
 }
 
-// mountAuthRoutes registers authentication + gated routes (/auth/*, /stats/me, /games/mine).
+// mountAuthRoutes registers authentication + gated routes (/auth/*, /stats/me, /stats/badges, /games/mine).
 func (s *Server) mountAuthRoutes() {
-	s.r.Post("/auth/signup", s.handleSignup)
-	s.r.Post("/auth/login", s.handleLogin)
+	throttled := s.r.With(s.withAuthRateLimit())
+	throttled.Post("/auth/signup", s.handleSignup)
+	throttled.Post("/auth/login", s.handleLogin)
 	s.r.Post("/auth/logout", s.handleLogout)
+	s.r.With(s.requireAuth()).Post("/auth/refresh", s.handleRefresh)
+	s.r.Post("/auth/verify", s.handleVerify)
 
 	// Current user (gated)
 	s.r.With(s.requireAuth()).Get("/auth/me", func(w http.ResponseWriter, r *http.Request) {
 		me, _ := r.Context().Value(ctxUserKey{}).(*authUser)
 		if me == nil {
-			http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+			writeError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
 			return
 		}
 		_ = json.NewEncoder(w).Encode(me)
 	})
 
+	// Personal data archive (gated)
+	s.r.With(s.requireAuth()).Get("/auth/me/export", s.handleExport)
+
+	// Account deletion (gated; password re-checked)
+	s.r.With(s.requireAuth()).Delete("/auth/me", s.handleDeleteAccount)
+
 	// Stats (gated)
 	s.r.With(s.requireAuth()).Get("/stats/me", func(w http.ResponseWriter, r *http.Request) {
 		me, _ := r.Context().Value(ctxUserKey{}).(*authUser)
 		if me == nil {
-			http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+			writeError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
 			return
 		}
-		u, err := s.findUserByID(me.ID)
+		st, err := s.userStats(me.ID)
 		if err != nil {
-			http.Error(w, `{"error":"not_found"}`, http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, "db_error", "")
 			return
 		}
-		_ = json.NewEncoder(w).Encode(map[string]any{
-			"id":          u.ID,
-			"gamesPlayed": u.GamesPlayed,
-			"wins":        u.Wins,
-			"streak":      u.Streak,
-		})
+		_ = json.NewEncoder(w).Encode(st)
 	})
 
+	// Achievements derived from finished games (gated)
+	s.r.With(s.requireAuth()).Get("/stats/badges", s.handleBadges)
+
 	// Recent games (gated)
 	s.r.With(s.requireAuth()).Get("/games/mine", func(w http.ResponseWriter, r *http.Request) {
 		me, _ := r.Context().Value(ctxUserKey{}).(*authUser)
 		if me == nil {
-			http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+			writeError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
 			return
 		}
-		rows, err := s.db.Query(`SELECT id, status, guesses, started_at, COALESCE(finished_at,'')
+		rows, err := s.db.Query(`SELECT id, status, guesses, started_at, COALESCE(finished_at,''), won, duration_ms, difficulty
 		                         FROM games WHERE user_id=? ORDER BY started_at DESC LIMIT 50`, me.ID)
 		if err != nil {
-			http.Error(w, `{"error":"db_error"}`, http.StatusInternalServerError)
+			writeError(w, http.StatusInternalServerError, "db_error", "")
 			return
 		}
 		defer rows.Close()
 
 		type gameRow struct {
-			ID         string `json:"id"`
-			Status     string `json:"status"`
-			Guesses    int    `json:"guesses"`
-			StartedAt  string `json:"startedAt"`
-			FinishedAt string `json:"finishedAt,omitempty"`
+			ID         string   `json:"id"`
+			Status     string   `json:"status"` // playing | won | lost | abandoned
+			Guesses    int      `json:"guesses"`
+			StartedAt  string   `json:"startedAt"`
+			FinishedAt string   `json:"finishedAt,omitempty"`
+			Won        *bool    `json:"won,omitempty"`        // set when a full finish record exists
+			DurationMs *int64   `json:"durationMs,omitempty"` // set when a full finish record exists
+			Difficulty *float64 `json:"difficulty,omitempty"` // 0–100, set when scored at finish
 		}
 		out := []gameRow{}
 		for rows.Next() {
 			var gr gameRow
-			if err := rows.Scan(&gr.ID, &gr.Status, &gr.Guesses, &gr.StartedAt, &gr.FinishedAt); err == nil {
-				if gr.FinishedAt == "" {
-					gr.FinishedAt = ""
+			var won, duration sql.NullInt64
+			var difficulty sql.NullFloat64
+			if err := rows.Scan(&gr.ID, &gr.Status, &gr.Guesses, &gr.StartedAt, &gr.FinishedAt, &won, &duration, &difficulty); err == nil {
+				if won.Valid {
+					v := won.Int64 == 1
+					gr.Won = &v
+				}
+				if duration.Valid {
+					gr.DurationMs = &duration.Int64
+				}
+				if difficulty.Valid {
+					gr.Difficulty = &difficulty.Float64
 				}
 				out = append(out, gr)
 			}
@@ -330,48 +617,83 @@ func (s *Server) mountAuthRoutes() {
 func (s *Server) handleSignup(w http.ResponseWriter, r *http.Request) {
 	var body signupReq
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, `{"error":"invalid_json"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "invalid_json", "")
 		return
 	}
-	u, err := s.createUser(body.Username, body.Password)
+	u, err := s.createUser(body.Username, body.Email, body.Password)
 	if err != nil {
-		if err.Error() == "username taken" {
-			http.Error(w, `{"error":"Username taken"}`, http.StatusConflict)
-			return
+		switch err.Error() {
+		case "username taken":
+			s.writeLocalized(w, r, http.StatusConflict, "username_taken", "Username taken")
+		case "email taken":
+			s.writeLocalized(w, r, http.StatusConflict, "email_taken", "Email taken")
+		default:
+			s.writeLocalized(w, r, http.StatusBadRequest, "invalid_registration", err.Error())
 		}
-		http.Error(w, `{"error":"`+err.Error()+`"}`, http.StatusBadRequest)
 		return
 	}
 	tok, exp, err := s.signJWT(u.ID, u.Username)
 	if err != nil {
-		http.Error(w, `{"error":"sign_failed"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, "sign_failed", "")
 		return
 	}
 	s.setAuthCookie(w, tok, exp)
 	// Attach any anonymous games to the new account
-	s.claimAnonGames(s.ensureAnonID(w, r), u.ID)
-	_ = json.NewEncoder(w).Encode(map[string]any{"id": u.ID, "username": u.Username, "createdAt": u.CreatedAt})
+	s.claimAnonGames(s.anonIDForClaim(w, r), u.ID)
+	res := map[string]any{"id": u.ID, "username": u.Username, "createdAt": u.CreatedAt}
+	if u.Email != "" {
+		res["email"] = u.Email
+	}
+	if s.flags.Enabled(featureflags.AccountVerify) {
+		if _, err := s.issueVerification(u.ID); err != nil {
+			log.Warn().Err(err).Str("user", u.ID).Msg("issue verification")
+		}
+		res["verified"] = false
+	}
+	_ = json.NewEncoder(w).Encode(res)
 }
 
 // handleLogin authenticates user, sets cookie, and claims anon history.
+// A password hashed below the current BCRYPT_COST is rehashed on the way in.
 func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
 	var body loginReq
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		http.Error(w, `{"error":"invalid_json"}`, http.StatusBadRequest)
+		writeError(w, http.StatusBadRequest, "invalid_json", "")
 		return
 	}
-	u, err := s.findUserByUsername(strings.TrimSpace(body.Username))
+	var (
+		u   *userRow
+		err error
+	)
+	ident := loginIdentifier()
+	email := body.Email
+	if email == "" && ident == identEither && strings.Contains(body.Username, "@") {
+		email = body.Username
+	}
+	switch {
+	case ident == identEmail:
+		u, err = s.findUserByEmail(email)
+	case ident == identEither && email != "":
+		u, err = s.findUserByEmail(email)
+	default:
+		u, err = s.findUserByUsername(strings.TrimSpace(body.Username))
+	}
 	if err != nil || !checkPassword(u.PasswordHash, body.Password) {
-		http.Error(w, `{"error":"Invalid username or password"}`, http.StatusUnauthorized)
+		if ident == identEmail {
+			s.writeLocalized(w, r, http.StatusUnauthorized, "invalid_credentials", "Invalid email or password")
+		} else {
+			s.writeLocalized(w, r, http.StatusUnauthorized, "invalid_credentials", "Invalid username or password")
+		}
 		return
 	}
+	s.upgradeHash(u, body.Password)
 	tok, exp, err := s.signJWT(u.ID, u.Username)
 	if err != nil {
-		http.Error(w, `{"error":"sign_failed"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, "sign_failed", "")
 		return
 	}
 	s.setAuthCookie(w, tok, exp)
-	s.claimAnonGames(s.ensureAnonID(w, r), u.ID)
+	s.claimAnonGames(s.anonIDForClaim(w, r), u.ID)
 	_ = json.NewEncoder(w).Encode(map[string]any{"id": u.ID, "username": u.Username})
 }
 
@@ -381,8 +703,62 @@ func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(map[string]bool{"ok": true})
 }
 
+// handleRefresh exchanges a still-valid token (cookie or bearer) for a fresh
+// one with a new expiry and resets the cookie. Expired tokens are rejected by
+// requireAuth, so a lapsed session still has to log in again.
+func (s *Server) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	me, _ := r.Context().Value(ctxUserKey{}).(*authUser)
+	if me == nil {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
+		return
+	}
+	u, err := s.findUserByID(me.ID)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "invalid_token", "Invalid token")
+		return
+	}
+	tok, exp, err := s.signJWT(u.ID, u.Username)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "sign_failed", "")
+		return
+	}
+	s.setAuthCookie(w, tok, exp)
+	_ = json.NewEncoder(w).Encode(map[string]any{"id": u.ID, "username": u.Username, "token": tok, "expiresAt": exp.UTC().Truncate(time.Second)})
+}
+
 // --------------------------- optional auth ---------------------------------
 
+// playAuth returns the auth middleware for gameplay routes: optional auth when
+// guests are allowed, otherwise requireAuth (401 for unauthenticated callers).
+// The guests_allowed flag is checked per request so it can be flipped at runtime.
+func (s *Server) playAuth() func(http.Handler) http.Handler {
+	optional, required := s.withOptionalAuth(), s.requireAuth()
+	return func(next http.Handler) http.Handler {
+		guest, member := optional(next), required(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if s.flags.Enabled(featureflags.GuestsAllowed) {
+				guest.ServeHTTP(w, r)
+				return
+			}
+			member.ServeHTTP(w, r)
+		})
+	}
+}
+
+// withMaintenance rejects requests with 503 while the maintenance flag is on.
+func (s *Server) withMaintenance() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if s.flags.Enabled(featureflags.Maintenance) {
+				w.Header().Set("Retry-After", "300")
+				writeError(w, http.StatusServiceUnavailable, "maintenance", "")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // withOptionalAuth decorates requests with user context if a valid JWT is present.
 // It never 401s; used for routes where guests are allowed.
 func (s *Server) withOptionalAuth() func(http.Handler) http.Handler {
@@ -432,6 +808,18 @@ func (s *Server) ensureAnonID(w http.ResponseWriter, r *http.Request) string {
 	return id
 }
 
+// anonIDForClaim returns the anon ID whose games should be claimed on login/signup.
+// When guests are disabled no anon cookie is minted; an existing one is still honoured.
+func (s *Server) anonIDForClaim(w http.ResponseWriter, r *http.Request) string {
+	if s.flags.Enabled(featureflags.GuestsAllowed) {
+		return s.ensureAnonID(w, r)
+	}
+	if c, err := r.Cookie(anonCookieName); err == nil {
+		return c.Value
+	}
+	return ""
+}
+
 // claimAnonGames transfers any anonymous games to a user account after auth.
 func (s *Server) claimAnonGames(anonID, userID string) {
 	if anonID == "" || userID == "" {
@@ -448,6 +836,8 @@ func (s *Server) claimAnonGames(anonID, userID string) {
 type userRow struct {
 	ID           string
 	Username     string
+	Email        string // "" when the account has none
+	Verified     bool   // false only while an issued verification is pending
 	PasswordHash string
 	CreatedAt    time.Time
 	GamesPlayed  int
@@ -456,37 +846,67 @@ type userRow struct {
 }
 
 // createUser validates input, checks uniqueness, hashes password, and inserts a new user.
-func (s *Server) createUser(username, pw string) (*userRow, error) {
+// Email is optional unless LOGIN_IDENTIFIER=email, in which case the username
+// may be omitted and is generated.
+func (s *Server) createUser(username, email, pw string) (*userRow, error) {
 	username = normalizeUsername(username)
+	email = normalizeEmail(email)
+	if loginIdentifier() == identEmail {
+		if email == "" {
+			return nil, errors.New("email required")
+		}
+		if username == "" {
+			username = generatedUsername()
+		}
+	}
 	if err := validateSignup(username, pw); err != nil {
 		return nil, err
 	}
+	if email != "" {
+		if err := validateEmail(email); err != nil {
+			return nil, err
+		}
+	}
 	var exists int
-	_ = s.db.QueryRow(`SELECT 1 FROM users WHERE lower(username)=lower(?)`, username).Scan(&exists)
+	_ = s.db.QueryRow(`SELECT 1 FROM users WHERE username_key=?`, usernameKey(username)).Scan(&exists)
 	if exists == 1 {
 		return nil, errors.New("username taken")
 	}
-	h, err := bcrypt.GenerateFromPassword([]byte(pw), bcrypt.DefaultCost)
+	if email != "" {
+		_ = s.db.QueryRow(`SELECT 1 FROM users WHERE email=?`, email).Scan(&exists)
+		if exists == 1 {
+			return nil, errors.New("email taken")
+		}
+	}
+	h, err := bcrypt.GenerateFromPassword([]byte(pw), bcryptCost())
 	if err != nil {
 		return nil, err
 	}
 	now := time.Now().UTC().Format(time.RFC3339)
 	id := genID()
-	if _, err := s.db.Exec(`INSERT INTO users (id, username, password_hash, created_at) VALUES (?,?,?,?)`,
-		id, username, string(h), now); err != nil {
+	if _, err := s.db.Exec(`INSERT INTO users (id, username, username_key, email, password_hash, created_at) VALUES (?,?,?,?,?,?)`,
+		id, username, usernameKey(username), sql.NullString{String: email, Valid: email != ""}, string(h), now); err != nil {
 		return nil, err
 	}
-	return &userRow{ID: id, Username: username, PasswordHash: string(h), CreatedAt: mustParse(now)}, nil
+	return &userRow{ID: id, Username: username, Email: email, PasswordHash: string(h), CreatedAt: mustParse(now)}, nil
 }
 
-// findUserByUsername/ID load a user row or return an error if missing.
+// findUserByUsername/Email/ID load a user row or return an error if missing.
+// Usernames match on usernameKey; legacy case-twins left without a key by
+// migration 020 fall back to lower(username).
 func (s *Server) findUserByUsername(username string) (*userRow, error) {
-	row := s.db.QueryRow(`SELECT id, username, password_hash, created_at, games_played, wins, streak
-	                      FROM users WHERE lower(username)=lower(?)`, username)
+	row := s.db.QueryRow(`SELECT id, username, COALESCE(email,''), verified, password_hash, created_at, games_played, wins, streak
+	                      FROM users WHERE username_key=? OR (username_key IS NULL AND lower(username)=lower(?))
+	                      ORDER BY username_key IS NULL LIMIT 1`, usernameKey(username), username)
+	return scanUser(row)
+}
+func (s *Server) findUserByEmail(email string) (*userRow, error) {
+	row := s.db.QueryRow(`SELECT id, username, COALESCE(email,''), verified, password_hash, created_at, games_played, wins, streak
+	                      FROM users WHERE email=?`, normalizeEmail(email))
 	return scanUser(row)
 }
 func (s *Server) findUserByID(id string) (*userRow, error) {
-	row := s.db.QueryRow(`SELECT id, username, password_hash, created_at, games_played, wins, streak
+	row := s.db.QueryRow(`SELECT id, username, COALESCE(email,''), verified, password_hash, created_at, games_played, wins, streak
 	                      FROM users WHERE id=?`, id)
 	return scanUser(row)
 }
@@ -495,7 +915,7 @@ func (s *Server) findUserByID(id string) (*userRow, error) {
 func scanUser(row *sql.Row) (*userRow, error) {
 	var u userRow
 	var created string
-	if err := row.Scan(&u.ID, &u.Username, &u.PasswordHash, &created, &u.GamesPlayed, &u.Wins, &u.Streak); err != nil {
+	if err := row.Scan(&u.ID, &u.Username, &u.Email, &u.Verified, &u.PasswordHash, &created, &u.GamesPlayed, &u.Wins, &u.Streak); err != nil {
 		return nil, err
 	}
 	u.CreatedAt = mustParse(created)
@@ -513,24 +933,116 @@ func checkPassword(hash, pw string) bool {
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(pw)) == nil
 }
 
-// normalizeUsername trims whitespace; adjust here if you want stricter rules.
+// bcryptCost is the work factor for new password hashes (BCRYPT_COST,
+// default bcrypt.DefaultCost; clamped to bcrypt's MinCost–MaxCost).
+func bcryptCost() int {
+	return min(max(envInt("BCRYPT_COST", bcrypt.DefaultCost), bcrypt.MinCost), bcrypt.MaxCost)
+}
+
+// upgradeHash rehashes pw at bcryptCost when u's stored hash has a lower cost.
+// Call it only after checkPassword passed. Failures are logged and leave the
+// old hash in place; the update is skipped if the hash changed meanwhile.
+func (s *Server) upgradeHash(u *userRow, pw string) {
+	cost, err := bcrypt.Cost([]byte(u.PasswordHash))
+	target := bcryptCost()
+	if err != nil || cost >= target {
+		return
+	}
+	h, err := bcrypt.GenerateFromPassword([]byte(pw), target)
+	if err != nil {
+		log.Warn().Err(err).Str("user", u.ID).Msg("rehash password")
+		return
+	}
+	if _, err := s.db.Exec(`UPDATE users SET password_hash=? WHERE id=? AND password_hash=?`,
+		string(h), u.ID, u.PasswordHash); err != nil {
+		log.Warn().Err(err).Str("user", u.ID).Msg("rehash password")
+		return
+	}
+	u.PasswordHash = string(h)
+}
+
+// normalizeUsername trims whitespace and composes accents (NFC), so the
+// stored display name has one spelling per look.
 func normalizeUsername(u string) string {
-	return strings.TrimSpace(u)
+	return norm.NFC.String(strings.TrimSpace(u))
+}
+
+// usernameKey is the canonical form usernames are unique by (users.username_key):
+//   - NFKC, so compatibility forms (fullwidth "Ｂｏｂ", ligatures) become plain;
+//   - Unicode simple case folding, so e.g. "Bob", "BOB" and "bob" (or a Kelvin
+//     sign K for k) share a key;
+//   - Cyrillic and Greek letters that look like Latin ones map to those (see
+//     confusables), so "Αdmin" with a Greek alpha collides with "admin".
+//
+// ASCII names key to their lowercase, as migration 020's backfill assumes.
+// The display username keeps the typed casing.
+func usernameKey(u string) string {
+	return strings.Map(func(r rune) rune {
+		r = unicode.ToLower(unicode.ToUpper(r))
+		if c, ok := confusables[r]; ok {
+			return c
+		}
+		return r
+	}, norm.NFKC.String(normalizeUsername(u)))
+}
+
+// confusables maps case-folded Cyrillic and Greek letters to the Latin letter
+// they pass for. Letters are listed in lowercase; where the two cases look
+// like different Latin letters (Greek ν/Ν, Cyrillic н/Н), the capital wins,
+// since that is the form that impersonates a capitalised name.
+var confusables = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'в': 'b', 'с': 'c', 'ԁ': 'd', 'е': 'e', 'һ': 'h', 'н': 'h',
+	'і': 'i', 'ј': 'j', 'к': 'k', 'ӏ': 'l', 'м': 'm', 'о': 'o', 'р': 'p',
+	'ԛ': 'q', 'ѕ': 's', 'т': 't', 'ԝ': 'w', 'х': 'x', 'у': 'y', 'ү': 'y',
+	// Greek
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'h', 'ι': 'i', 'κ': 'k', 'μ': 'm',
+	'ν': 'n', 'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'y', 'χ': 'x', 'ζ': 'z',
+}
+
+// normalizeEmail trims and lowercases an address so uniqueness is case-insensitive.
+func normalizeEmail(e string) string {
+	return strings.ToLower(strings.TrimSpace(e))
+}
+
+// validateEmail accepts a bare address (no display name) of at most 254 chars.
+func validateEmail(e string) error {
+	a, err := mail.ParseAddress(e)
+	if err != nil || a.Address != e || len(e) > 254 || !strings.Contains(e[strings.LastIndex(e, "@")+1:], ".") {
+		return errors.New("invalid email")
+	}
+	return nil
+}
+
+// generatedUsername returns a random valid username for email-only signups.
+func generatedUsername() string {
+	var b [5]byte
+	_, _ = rand.Read(b[:])
+	return "player_" + hex.EncodeToString(b[:])
 }
 
 // validateSignup enforces basic username/password rules.
 func validateSignup(u, p string) error {
-	if len(u) < 3 || len(u) > 24 {
+	if err := validateUsername(u); err != nil {
+		return err
+	}
+	if len(p) < 8 || len(p) > 100 {
+		return errors.New("password must be 8–100 chars")
+	}
+	return nil
+}
+
+// validateUsername enforces length and charset rules for usernames: 3–24
+// characters, each a letter in any script, an ASCII digit, or underscore.
+func validateUsername(u string) error {
+	if n := utf8.RuneCountInString(u); n < 3 || n > 24 {
 		return errors.New("username must be 3–24 chars")
 	}
 	for _, r := range u {
-		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+		if !(r == '_' || unicode.IsLetter(r) || r >= '0' && r <= '9') {
 			return errors.New("username: letters, numbers, underscore only")
 		}
 	}
-	if len(p) < 8 || len(p) > 100 {
-		return errors.New("password must be 8–100 chars")
-	}
 	return nil
 }
 
@@ -545,8 +1057,12 @@ func genID() string {
 	return s
 }
 
+// distributionBuckets is the number of win_guess_N columns (wins in 1..6 guesses).
+const distributionBuckets = 6
+
 // bumpStats increments games played; updates wins and streak based on result (within tx).
-func (s *Server) bumpStats(tx *sql.Tx, userID string, won bool) error {
+// A win in 1..6 guesses also increments that win_guess_N bucket.
+func (s *Server) bumpStats(tx *sql.Tx, userID string, won bool, guesses int) error {
 	var gp, wins, streak int
 	row := tx.QueryRow(`SELECT games_played, wins, streak FROM users WHERE id=?`, userID)
 	if err := row.Scan(&gp, &wins, &streak); err != nil {
@@ -559,8 +1075,24 @@ func (s *Server) bumpStats(tx *sql.Tx, userID string, won bool) error {
 	} else {
 		streak = 0
 	}
-	_, err := tx.Exec(`UPDATE users SET games_played=?, wins=?, streak=? WHERE id=?`, gp, wins, streak, userID)
-	return err
+	if _, err := tx.Exec(`UPDATE users SET games_played=?, wins=?, streak=? WHERE id=?`, gp, wins, streak, userID); err != nil {
+		return err
+	}
+	if won && guesses >= 1 && guesses <= distributionBuckets {
+		col := "win_guess_" + strconv.Itoa(guesses) // bounded above; safe to splice
+		if _, err := tx.Exec(`UPDATE users SET `+col+` = `+col+` + 1 WHERE id=?`, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// guessDistribution returns the user's wins by guess count (index 0 = 1 guess).
+func (s *Server) guessDistribution(userID string) ([]int, error) {
+	d := make([]int, distributionBuckets)
+	err := s.db.QueryRow(`SELECT win_guess_1, win_guess_2, win_guess_3, win_guess_4, win_guess_5, win_guess_6
+	                        FROM users WHERE id=?`, userID).Scan(&d[0], &d[1], &d[2], &d[3], &d[4], &d[5])
+	return d, err
 }
 
 // ------------------------------ JWT & cookies ------------------------------
@@ -649,7 +1181,7 @@ func (s *Server) requireAuth() func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			tokenStr := bearerOrCookie(r)
 			if tokenStr == "" {
-				http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+				writeError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized")
 				return
 			}
 			claims := jwt.MapClaims{}
@@ -657,18 +1189,23 @@ func (s *Server) requireAuth() func(http.Handler) http.Handler {
 				return []byte(getEnv("JWT_SECRET", "dev_secret_change_me")), nil
 			})
 			if err != nil || !token.Valid {
-				http.Error(w, `{"error":"Invalid token"}`, http.StatusUnauthorized)
+				writeError(w, http.StatusUnauthorized, "invalid_token", "Invalid token")
 				return
 			}
 			id, _ := claims["id"].(string)
 			username, _ := claims["username"].(string)
 			if id == "" || username == "" {
-				http.Error(w, `{"error":"Invalid token"}`, http.StatusUnauthorized)
+				writeError(w, http.StatusUnauthorized, "invalid_token", "Invalid token")
+				return
+			}
+			// Ensure user still exists (and has verified, when required)
+			u, err := s.findUserByID(id)
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, "invalid_token", "Invalid token")
 				return
 			}
-			// Ensure user still exists
-			if _, err := s.findUserByID(id); err != nil {
-				http.Error(w, `{"error":"Invalid token"}`, http.StatusUnauthorized)
+			if !u.Verified && s.flags.Enabled(featureflags.AccountVerify) {
+				writeError(w, http.StatusForbidden, "unverified", "")
 				return
 			}
 			ctx := context.WithValue(r.Context(), ctxUserKey{}, &authUser{ID: id, Username: username})
@@ -686,3 +1223,19 @@ func getEnv(k, def string) string {
 	}
 	return def
 }
+
+// envInt returns the integer value of env var k, or def if unset/invalid.
+func envInt(k string, def int) int {
+	if n, err := strconv.Atoi(os.Getenv(k)); err == nil {
+		return n
+	}
+	return def
+}
+
+// envDuration returns the duration value of env var k (e.g. "90s"), or def if unset/invalid.
+func envDuration(k string, def time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(k)); err == nil {
+		return d
+	}
+	return def
+}
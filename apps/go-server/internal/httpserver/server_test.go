@@ -0,0 +1,687 @@
+package httpserver
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/featureflags"
+	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+	"github.com/robalobadob/wordle/apps/go-server/internal/store"
+	"github.com/robalobadob/wordle/apps/go-server/internal/words"
+)
+
+// newGame starts a classic game with req (nil for defaults) and returns its ID.
+func (c *testClient) newGame(req any) string {
+	c.t.Helper()
+	var res newGameRes
+	if status := c.call("POST", "/game/new", req, &res); status != http.StatusOK || res.GameID == "" {
+		c.t.Fatalf("/game/new: status %d, game %q", status, res.GameID)
+	}
+	return res.GameID
+}
+
+// guess submits word to a classic game and decodes the response.
+func (c *testClient) guess(gameID, word string, hdr ...string) (int, guessRes) {
+	c.t.Helper()
+	var res guessRes
+	status := c.call("POST", "/game/guess", guessReq{GameID: gameID, Guess: word}, &res, hdr...)
+	return status, res
+}
+
+func TestGuestsDisabled(t *testing.T) {
+	ts := newTestServer(t, "ALLOW_GUESTS", "false")
+	guest := ts.client()
+	for _, path := range []string{"/game/new", "/daily/new"} {
+		if status, raw := guest.do("POST", path, nil); status != http.StatusUnauthorized {
+			t.Errorf("guest POST %s: status %d %s, want 401", path, status, raw)
+		}
+	}
+	u, _ := url.Parse(ts.url)
+	for _, c := range guest.hc.Jar.Cookies(u) {
+		if c.Name == anonCookieName {
+			t.Errorf("anon cookie %q minted with guests disabled", c.Value)
+		}
+	}
+
+	user := ts.client()
+	user.signup("member")
+	for _, path := range []string{"/game/new", "/daily/new"} {
+		if status, raw := user.do("POST", path, nil); status != http.StatusOK {
+			t.Errorf("user POST %s: status %d %s, want 200", path, status, raw)
+		}
+	}
+}
+
+func TestGuestsAllowedByDefault(t *testing.T) {
+	ts := newTestServer(t)
+	guest := ts.client()
+	for _, path := range []string{"/game/new", "/daily/new"} {
+		if status, raw := guest.do("POST", path, nil); status != http.StatusOK {
+			t.Errorf("guest POST %s: status %d %s, want 200", path, status, raw)
+		}
+	}
+}
+
+func TestFinishRecord(t *testing.T) {
+	for _, persist := range []string{"true", "false"} {
+		ts := newTestServer(t, "PERSIST_GAME_RESULTS", persist, "ALLOW_FIXED_ANSWER", "true")
+		c := ts.client()
+		c.signup("finisher")
+		list := words.AnswersLen(5)
+		answer, miss := list[0], list[1]
+
+		won := c.newGame(newGameReq{Answer: answer})
+		c.guess(won, miss)
+		if status, res := c.guess(won, answer); status != http.StatusOK || res.State != "won" {
+			t.Fatalf("winning guess: status %d state %q", status, res.State)
+		}
+		lost := c.newGame(newGameReq{Answer: answer, Rows: 1})
+		if status, res := c.guess(lost, miss); status != http.StatusOK || res.State != "lost" {
+			t.Fatalf("losing guess: status %d state %q", status, res.State)
+		}
+
+		for _, tc := range []struct {
+			id      string
+			status  string
+			guesses int
+			won     int64
+		}{{won, "won", 2, 1}, {lost, "lost", 1, 0}} {
+			var (
+				status, finished string
+				guesses          int
+				ans              sql.NullString
+				wonCol, duration sql.NullInt64
+			)
+			if err := ts.db.QueryRow(`SELECT status, COALESCE(finished_at,''), guesses, answer, won, duration_ms FROM games WHERE id=?`, tc.id).
+				Scan(&status, &finished, &guesses, &ans, &wonCol, &duration); err != nil {
+				t.Fatal(err)
+			}
+			if status != tc.status || finished == "" {
+				t.Fatalf("persist=%s: status %q finished_at %q, want %q and a timestamp", persist, status, finished, tc.status)
+			}
+			if persist == "false" {
+				if wonCol.Valid || duration.Valid {
+					t.Fatalf("persist=false: won=%v duration=%v, want NULL", wonCol, duration)
+				}
+				continue
+			}
+			if guesses != tc.guesses || ans.String != answer || !wonCol.Valid || wonCol.Int64 != tc.won || !duration.Valid || duration.Int64 < 0 {
+				t.Fatalf("persist=true: %s record = guesses %d answer %v won %v duration %v", tc.status, guesses, ans, wonCol, duration)
+			}
+		}
+	}
+}
+
+func TestDailyRequireAuth(t *testing.T) {
+	ts := newTestServer(t, "DAILY_REQUIRE_AUTH", "true")
+	guest := ts.client()
+	for _, path := range []string{"/daily/new", "/daily/guess"} {
+		if status, raw := guest.do("POST", path, nil); status != http.StatusUnauthorized {
+			t.Errorf("guest POST %s: status %d %s, want 401", path, status, raw)
+		}
+	}
+	if status, raw := guest.do("GET", "/daily/mine", nil); status != http.StatusUnauthorized {
+		t.Errorf("guest GET /daily/mine: status %d %s, want 401", status, raw)
+	}
+	guest.newGame(nil) // classic stays open
+
+	user := ts.client()
+	user.signup("regular")
+	user.startDaily(ts)
+
+	// Flipped off at runtime, guests are back in.
+	_ = ts.flags.Set(featureflags.DailyRequireAuth, false)
+	guest.startDaily(ts)
+}
+
+func TestLocalizedErrors(t *testing.T) {
+	ts := newTestServer(t, "LOCALIZED_ERRORS", "true")
+	c := ts.client()
+	id := c.newGame(nil)
+	var res struct {
+		Error struct{ Code, Message string }
+	}
+	c.call("POST", "/game/guess", guessReq{GameID: id, Guess: "qzxvj"}, &res, "Accept-Language", "es-ES,es;q=0.9")
+	if res.Error.Message != "no está en la lista de palabras" {
+		t.Fatalf("es message = %q", res.Error.Message)
+	}
+	c.call("POST", "/game/guess", guessReq{GameID: id, Guess: "qzxvj"}, &res, "Accept-Language", "ja")
+	if res.Error.Message != "not in word list" {
+		t.Fatalf("unsupported locale message = %q, want English", res.Error.Message)
+	}
+}
+
+func TestSQLGameStoreSurvivesRestart(t *testing.T) {
+	ts := newTestServerStore(t, store.NewSQLStore, "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	list := words.AnswersLen(5)
+	id := c.newGame(newGameReq{Answer: list[0]})
+	c.guess(id, list[1])
+
+	// A second store on the same database stands in for a restarted instance.
+	g, err := store.NewSQLStore(ts.db).Get(context.Background(), id)
+	if err != nil {
+		t.Fatalf("Get after restart: %v", err)
+	}
+	if g.Answer != list[0] || len(g.Guesses) != 1 || g.Guesses[0] != list[1] || g.Finished {
+		t.Fatalf("restored game = %+v", g)
+	}
+}
+
+func TestEmailAccounts(t *testing.T) {
+	type creds map[string]string
+	for _, tc := range []struct {
+		ident  string
+		signup creds // besides the password
+		login  creds
+		reject creds // a login the mode doesn't accept
+	}{
+		{"email", creds{"email": "Ada@Example.com"}, creds{"email": "ada@example.COM"}, creds{"username": "ada"}},
+		{"either", creds{"username": "ada", "email": "ada@example.com"}, creds{"username": "ADA@example.com"}, creds{"username": "nobody"}},
+		{"username", creds{"username": "ada", "email": "ada@example.com"}, creds{"username": "ada"}, creds{"email": "ada@example.com"}},
+	} {
+		ts := newTestServer(t, "LOGIN_IDENTIFIER", tc.ident)
+		withPW := func(c creds) creds {
+			out := creds{"password": "password123"}
+			for k, v := range c {
+				out[k] = v
+			}
+			return out
+		}
+
+		var res struct {
+			ID       string `json:"id"`
+			Username string `json:"username"`
+			Email    string `json:"email"`
+		}
+		if status := ts.client().call("POST", "/auth/signup", withPW(tc.signup), &res); status != http.StatusOK {
+			t.Fatalf("%s: signup status %d", tc.ident, status)
+		}
+		if res.Email != "ada@example.com" || res.Username == "" {
+			t.Fatalf("%s: signup = %+v, want the normalized email and a username", tc.ident, res)
+		}
+
+		dup := withPW(tc.signup)
+		dup["username"] = "someone_else"
+		dup["email"] = "ADA@example.com"
+		if status, raw := ts.client().do("POST", "/auth/signup", dup); status != http.StatusConflict || errorCode(raw) != "email_taken" {
+			t.Fatalf("%s: duplicate email: status %d %s", tc.ident, status, raw)
+		}
+		bad := withPW(creds{"username": "bob", "email": "not-an-email"})
+		if status, _ := ts.client().do("POST", "/auth/signup", bad); status != http.StatusBadRequest {
+			t.Fatalf("%s: malformed email: status %d, want 400", tc.ident, status)
+		}
+
+		var login struct {
+			ID string `json:"id"`
+		}
+		if status := ts.client().call("POST", "/auth/login", withPW(tc.login), &login); status != http.StatusOK || login.ID != res.ID {
+			t.Fatalf("%s: login %v: status %d id %q, want %q", tc.ident, tc.login, status, login.ID, res.ID)
+		}
+		if status, _ := ts.client().do("POST", "/auth/login", withPW(tc.reject)); status != http.StatusUnauthorized {
+			t.Fatalf("%s: login %v: status %d, want 401", tc.ident, tc.reject, status)
+		}
+	}
+}
+
+func TestStatsDistribution(t *testing.T) {
+	ts := newTestServer(t, "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("counter")
+	list := words.AnswersLen(5)
+	answer := list[0]
+	play := func(rows int, guesses ...string) {
+		id := c.newGame(newGameReq{Answer: answer, Rows: rows})
+		for _, w := range guesses {
+			c.guess(id, w)
+		}
+	}
+	play(0, answer)
+	play(0, list[1], list[2], answer)
+	play(0, list[3], list[4], answer)
+	play(2, list[1], list[2]) // a loss fills no bucket
+
+	var st statsRes
+	if status := c.call("GET", "/stats/me", nil, &st); status != http.StatusOK {
+		t.Fatalf("stats: status %d", status)
+	}
+	want := []int{1, 0, 2, 0, 0, 0}
+	if st.GamesPlayed != 4 || st.Wins != 3 || len(st.Distribution) != len(want) {
+		t.Fatalf("stats = %+v, want 4 played, 3 wins, %v", st, want)
+	}
+	for i := range want {
+		if st.Distribution[i] != want[i] {
+			t.Fatalf("distribution = %v, want %v", st.Distribution, want)
+		}
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client()
+	uid := c.signup("refresher")
+	// token signs a session for uid expiring at exp with the default dev secret.
+	token := func(exp time.Time) string {
+		tok, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"id": uid, "username": "refresher", "exp": exp.Unix(), "iat": time.Now().Unix(),
+		}).SignedString([]byte("dev_secret_change_me"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tok
+	}
+
+	nearExpiry := time.Now().Add(time.Minute)
+	var res struct {
+		ID        string    `json:"id"`
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+	bearer := ts.client()
+	if status := bearer.call("POST", "/auth/refresh", nil, &res, "Authorization", "Bearer "+token(nearExpiry)); status != http.StatusOK {
+		t.Fatalf("refresh: status %d", status)
+	}
+	if res.ID != uid || !res.ExpiresAt.After(nearExpiry.Add(13*24*time.Hour)) {
+		t.Fatalf("refresh = %+v, want a fresh 14-day expiry", res)
+	}
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(res.Token, claims, func(*jwt.Token) (any, error) { return []byte("dev_secret_change_me"), nil }); err != nil {
+		t.Fatalf("refreshed token: %v", err)
+	}
+	if exp, _ := claims.GetExpirationTime(); exp == nil || !exp.After(nearExpiry) {
+		t.Fatalf("refreshed exp = %v, want after %v", exp, nearExpiry)
+	}
+	// The new token also came back as the cookie.
+	if status, _ := bearer.do("GET", "/auth/me", nil); status != http.StatusOK {
+		t.Fatalf("/auth/me with the refreshed cookie: status %d", status)
+	}
+
+	if status, _ := ts.client().do("POST", "/auth/refresh", nil, "Authorization", "Bearer "+token(time.Now().Add(-time.Minute))); status != http.StatusUnauthorized {
+		t.Fatalf("expired token: status %d, want 401", status)
+	}
+	if status, _ := ts.client().do("POST", "/auth/refresh", nil); status != http.StatusUnauthorized {
+		t.Fatalf("no token: status %d, want 401", status)
+	}
+}
+
+func TestEvaluatedGuessEcho(t *testing.T) {
+	ts := newTestServer(t, "ECHO_EVALUATED_GUESS", "true", "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("echoer")
+	list := words.AnswersLen(5)
+	id := c.newGame(newGameReq{Answer: list[0]})
+
+	raw := "  " + strings.ToUpper(list[1]) + "\t"
+	if status, res := c.guess(id, raw); status != http.StatusOK || res.Evaluated != game.NormalizeGuess(raw) || res.Evaluated != list[1] {
+		t.Fatalf("guess %q: status %d, evaluated %q; want %q", raw, status, res.Evaluated, list[1])
+	}
+
+	daily := ts.client()
+	daily.signup("daily_echoer")
+	gameID, answer := daily.startDaily(ts)
+	raw = " " + strings.ToUpper(answer)
+	if status, res := daily.dailyGuess(gameID, raw); status != http.StatusOK || res.Evaluated != answer {
+		t.Fatalf("daily guess %q: status %d, evaluated %q; want %q", raw, status, res.Evaluated, answer)
+	}
+	if _, res := daily.dailyGuess(gameID, answer); res.State != "locked" || res.Evaluated != "" {
+		t.Fatalf("locked daily guess: state %q, evaluated %q; want nothing echoed", res.State, res.Evaluated)
+	}
+
+	_ = ts.flags.Set(featureflags.EvaluatedGuess, false)
+	if _, res := c.guess(id, list[0]); res.Evaluated != "" {
+		t.Fatalf("flag off: evaluated %q, want none", res.Evaluated)
+	}
+}
+
+func TestGuessRemainingCountsDown(t *testing.T) {
+	ts := newTestServer(t, "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("counter")
+	list := words.AnswersLen(5)
+
+	id := c.newGame(newGameReq{Answer: list[0]})
+	for i, w := range list[1 : 1+game.DefaultRows] {
+		status, res := c.guess(id, w)
+		if status != http.StatusOK || res.GuessNumber != i+1 || res.Remaining != game.DefaultRows-i-1 {
+			t.Fatalf("classic guess %d: status %d number %d remaining %d", i+1, status, res.GuessNumber, res.Remaining)
+		}
+	}
+
+	// A win reports the rows it didn't need.
+	id = c.newGame(newGameReq{Answer: list[0]})
+	c.guess(id, list[1])
+	if _, res := c.guess(id, list[0]); res.State != "won" || res.GuessNumber != 2 || res.Remaining != game.DefaultRows-2 {
+		t.Fatalf("win on guess 2: %+v", res)
+	}
+
+	gameID, answer := c.startDaily(ts)
+	for i, w := range wrongGuesses(answer, 3) {
+		status, res := c.dailyGuess(gameID, w)
+		if status != http.StatusOK || res.GuessNumber != i+1 || res.Remaining != game.DefaultRows-i-1 {
+			t.Fatalf("daily guess %d: status %d number %d remaining %d", i+1, status, res.GuessNumber, res.Remaining)
+		}
+	}
+}
+
+func TestAnswerRevealedOnLoss(t *testing.T) {
+	ts := newTestServer(t, "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("loser")
+	list := words.AnswersLen(5)
+
+	id := c.newGame(newGameReq{Answer: list[0], Rows: 2})
+	if _, res := c.guess(id, list[1]); res.State != "playing" || res.Answer != "" {
+		t.Fatalf("mid-game: state %q answer %q, want no answer", res.State, res.Answer)
+	}
+	if _, res := c.guess(id, list[2]); res.State != "lost" || res.Answer != list[0] {
+		t.Fatalf("losing guess: state %q answer %q, want %q", res.State, res.Answer, list[0])
+	}
+
+	gameID, answer := c.startDaily(ts)
+	for i, w := range wrongGuesses(answer, game.DefaultRows) {
+		_, res := c.dailyGuess(gameID, w)
+		last := i == game.DefaultRows-1
+		if !last && res.Answer != "" {
+			t.Fatalf("daily guess %d reveals the answer", i+1)
+		}
+		if last && (res.State != "lost" || res.Answer != answer) {
+			t.Fatalf("daily loss: state %q answer %q, want %q", res.State, res.Answer, answer)
+		}
+	}
+	if _, res := c.dailyGuess(gameID, answer); res.State != "locked" || res.Answer != answer {
+		t.Fatalf("locked after a loss: state %q answer %q, want %q", res.State, res.Answer, answer)
+	}
+}
+
+func TestFixedAnswerFlag(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client()
+	c.signup("fixer")
+	list := words.AnswersLen(5)
+
+	// Off by default: the answer is dropped and the game is still created.
+	// A one-row game ends on the first guess and reveals the real answer.
+	picked := 0
+	for i := 0; i < 5; i++ {
+		id := c.newGame(newGameReq{Answer: list[0], Rows: 1})
+		_, res := c.guess(id, list[0])
+		if res.State == "playing" || res.Answer == "" {
+			t.Fatalf("one-row game after a guess: %+v", res)
+		}
+		if res.Answer == list[0] {
+			picked++
+		}
+	}
+	if picked == 5 {
+		t.Fatal("flag off: every game used the client's answer")
+	}
+
+	_ = ts.flags.Set(featureflags.FixedAnswer, true)
+	id := c.newGame(newGameReq{Answer: list[0]})
+	if _, res := c.guess(id, list[0]); res.State != "won" || res.GuessNumber != 1 {
+		t.Fatalf("flag on: %+v, want a first-guess win", res)
+	}
+}
+
+func TestRehashOnLogin(t *testing.T) {
+	ts := newTestServer(t)
+	uid := ts.client().signup("oldhash")
+	cost := func() int {
+		t.Helper()
+		var h string
+		if err := ts.db.QueryRow(`SELECT password_hash FROM users WHERE id=?`, uid).Scan(&h); err != nil {
+			t.Fatal(err)
+		}
+		n, err := bcrypt.Cost([]byte(h))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+	if n := cost(); n != 4 {
+		t.Fatalf("cost after signup = %d, want 4", n)
+	}
+
+	// The cost is read per request, so raising it takes effect on the next login.
+	t.Setenv("BCRYPT_COST", "5")
+	if status, _ := ts.client().do("POST", "/auth/login", map[string]string{"username": "oldhash", "password": "wrong-password"}); status != http.StatusUnauthorized {
+		t.Fatalf("wrong password: status %d", status)
+	}
+	if n := cost(); n != 4 {
+		t.Fatalf("cost after a failed login = %d, want 4", n)
+	}
+	if status, _ := ts.client().do("POST", "/auth/login", map[string]string{"username": "oldhash", "password": "password123"}); status != http.StatusOK {
+		t.Fatalf("login: status %d", status)
+	}
+	if n := cost(); n != 5 {
+		t.Fatalf("cost after login = %d, want 5", n)
+	}
+	// The upgraded hash still checks out.
+	if status, _ := ts.client().do("POST", "/auth/login", map[string]string{"username": "oldhash", "password": "password123"}); status != http.StatusOK {
+		t.Fatalf("login after rehash: status %d", status)
+	}
+}
+
+func TestBcryptCostClamped(t *testing.T) {
+	for env, want := range map[string]int{"": bcrypt.DefaultCost, "2": bcrypt.MinCost, "12": 12, "99": bcrypt.MaxCost} {
+		t.Setenv("BCRYPT_COST", env)
+		if got := bcryptCost(); got != want {
+			t.Errorf("BCRYPT_COST=%q: cost %d, want %d", env, got, want)
+		}
+	}
+}
+
+func TestPracticeSeed(t *testing.T) {
+	ts := newTestServer(t, "ALLOW_FIXED_ANSWER", "true")
+	c := ts.client()
+	c.signup("practicer")
+	list := words.AnswersLen(5)
+
+	// answer plays a one-row practice game with seed, losing on purpose so
+	// the answer is revealed, and returns the seed echoed back and the answer.
+	answer := func(seed string) (string, string) {
+		t.Helper()
+		var res newGameRes
+		if status := c.call("POST", "/game/new", newGameReq{Mode: "practice", Seed: seed, Answer: list[0], Rows: 1}, &res); status != http.StatusOK {
+			t.Fatalf("practice %q: status %d", seed, status)
+		}
+		wrong := list[0]
+		if game.SeedAnswer(res.Seed) == wrong {
+			wrong = list[1]
+		}
+		_, g := c.guess(res.GameID, wrong)
+		if g.State != "lost" || g.Answer == "" {
+			t.Fatalf("practice %q: %+v, want a revealed loss", seed, g)
+		}
+		return res.Seed, g.Answer
+	}
+
+	seed, first := answer("share-me")
+	_, second := answer("  share-me ")
+	if seed != "share-me" || first != second || first != game.SeedAnswer("share-me") {
+		t.Fatalf("same seed: %q then %q (seed %q), want %q twice", first, second, seed, game.SeedAnswer("share-me"))
+	}
+
+	// Without a seed one is generated, and it replays the same board.
+	generated, a := answer("")
+	if generated == "" {
+		t.Fatal("no seed generated")
+	}
+	if _, b := answer(generated); a != b {
+		t.Fatalf("generated seed %q: %q then %q", generated, a, b)
+	}
+
+	status, raw := c.do("POST", "/game/new", newGameReq{Mode: "practice", Seed: strings.Repeat("s", maxSeedLen+1)})
+	if status != http.StatusBadRequest || errorCode(raw) != "invalid_seed" {
+		t.Fatalf("long seed: status %d %s, want 400 invalid_seed", status, raw)
+	}
+	// Other modes don't echo a seed.
+	var res newGameRes
+	if c.call("POST", "/game/new", newGameReq{Seed: "share-me"}, &res); res.Seed != "" {
+		t.Fatalf("classic game echoed seed %q", res.Seed)
+	}
+}
+
+func TestUsernameKey(t *testing.T) {
+	for _, tc := range []struct{ a, b string }{
+		{"Bob", "bob"},
+		{"BOB", " bob "},
+		{"Kelvin", "Kelvin"}, // Kelvin sign folds to k
+		{"ΣΟΦΟΣ", "σοφος"},
+		{"σοφος", "σοφοσ"},        // final and medial sigma
+		{"Αdmin", "admin"},        // Greek capital alpha
+		{"аdmin", "admin"},        // Cyrillic a
+		{"ВОВ", "bob"},            // Cyrillic capitals
+		{"ａｄｍｉｎ", "admin"},        // fullwidth (NFKC)
+		{"Zoe\u0308", "ZO\u00cb"}, // decomposed and precomposed diaeresis
+	} {
+		if ka, kb := usernameKey(tc.a), usernameKey(tc.b); ka != kb {
+			t.Errorf("usernameKey(%q) = %q, usernameKey(%q) = %q; want equal", tc.a, ka, tc.b, kb)
+		}
+	}
+	for _, pair := range [][2]string{{"alice", "alicf"}, {"zoe", "zoë"}, {"ελένη", "eleni"}} {
+		if usernameKey(pair[0]) == usernameKey(pair[1]) {
+			t.Errorf("distinct names %q and %q share a key", pair[0], pair[1])
+		}
+	}
+	// Migration 020 backfilled lower(username); ASCII keys must still match it.
+	for _, u := range []string{"Bob", "ALICE_2", "x_Y_9"} {
+		if got := usernameKey(u); got != strings.ToLower(u) {
+			t.Errorf("usernameKey(%q) = %q, want %q", u, got, strings.ToLower(u))
+		}
+	}
+}
+
+func TestValidateUsername(t *testing.T) {
+	for _, u := range []string{"bob", "Alice_2", "Ελένη", "Zoë", "Дмитрий", strings.Repeat("я", 24)} {
+		if err := validateUsername(u); err != nil {
+			t.Errorf("validateUsername(%q) = %v, want ok", u, err)
+		}
+	}
+	for _, u := range []string{"ab", strings.Repeat("я", 25), "bob!", "bo b", "bob٣", "😀😀😀"} {
+		if validateUsername(u) == nil {
+			t.Errorf("validateUsername(%q) accepted", u)
+		}
+	}
+}
+
+func TestUsernameUniqueness(t *testing.T) {
+	ts := newTestServer(t, "AUTH_RATE_PER_MIN", "1000", "AUTH_RATE_BURST", "1000", "AUTH_NAME_RATE_PER_MIN", "1000", "AUTH_NAME_RATE_BURST", "1000")
+	ts.client().signup("Alice_2")
+
+	for _, name := range []string{"ALICE_2", "alice_2", "aLiCe_2"} {
+		if status, raw := ts.client().do("POST", "/auth/signup", map[string]string{"username": name, "password": "password123"}); status != http.StatusConflict || errorCode(raw) != "username_taken" {
+			t.Errorf("signup %s: %d %s, want 409 username_taken", name, status, raw)
+		}
+	}
+	// Lookalikes from other scripts take the same key: a Greek capital alpha,
+	// a Cyrillic small a, fullwidth letters.
+	for _, name := range []string{"Αlice_2", "аlice_2", "Ａlice_2"} {
+		if status, raw := ts.client().do("POST", "/auth/signup", map[string]string{"username": name, "password": "password123"}); status != http.StatusConflict || errorCode(raw) != "username_taken" {
+			t.Errorf("confusable signup %s: %d %s, want 409 username_taken", name, status, raw)
+		}
+	}
+
+	var stored, key string
+	if err := ts.db.QueryRow(`SELECT username, username_key FROM users`).Scan(&stored, &key); err != nil || stored != "Alice_2" || key != "alice_2" {
+		t.Fatalf("stored %q key %q (%v), want display Alice_2 and key alice_2", stored, key, err)
+	}
+
+	c := ts.client()
+	var me struct {
+		Username string `json:"username"`
+	}
+	if status := c.call("POST", "/auth/login", map[string]string{"username": "aLiCe_2", "password": "password123"}, &me); status != http.StatusOK {
+		t.Fatalf("login with other casing: status %d", status)
+	}
+	if status := c.call("GET", "/auth/me", nil, &me); status != http.StatusOK || me.Username != "Alice_2" {
+		t.Fatalf("/auth/me: status %d username %q, want Alice_2", status, me.Username)
+	}
+	// Names in other scripts sign up, keep their spelling, and log in with
+	// any casing.
+	c = ts.client()
+	c.signup("Ελένη")
+	if status := c.call("POST", "/auth/login", map[string]string{"username": "ΕΛΈΝΗ", "password": "password123"}, &me); status != http.StatusOK {
+		t.Fatalf("login as ΕΛΈΝΗ: status %d", status)
+	}
+	if status := c.call("GET", "/auth/me", nil, &me); status != http.StatusOK || me.Username != "Ελένη" {
+		t.Fatalf("/auth/me: status %d username %q, want Ελένη", status, me.Username)
+	}
+}
+
+func TestShutdownDrainsInFlightRequests(t *testing.T) {
+	s := New(store.NewMemoryStore(), openTestDB(t))
+	t.Cleanup(s.Close)
+	started, release := make(chan struct{}), make(chan struct{})
+	s.Router().Get("/test/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	served := make(chan error, 1)
+	go func() { served <- s.Start(addr) }()
+	for i := 0; ; i++ {
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			break
+		}
+		if i == 100 {
+			t.Fatal("server never started listening")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	status := make(chan int, 1)
+	go func() {
+		res, err := http.Get("http://" + addr + "/test/slow")
+		if err != nil {
+			status <- 0
+			return
+		}
+		res.Body.Close()
+		status <- res.StatusCode
+	}()
+	<-started
+
+	stopped := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stopped <- s.Shutdown(ctx)
+	}()
+	select {
+	case err := <-stopped:
+		t.Fatalf("Shutdown returned (%v) before the in-flight request finished", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	if code := <-status; code != http.StatusOK {
+		t.Fatalf("in-flight request: status %d, want 200", code)
+	}
+	if err := <-stopped; err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if err := <-served; err != nil {
+		t.Fatalf("Start after Shutdown: %v, want nil", err)
+	}
+	if _, err := http.Get("http://" + addr + "/healthz"); err == nil {
+		t.Fatal("server still accepting connections after Shutdown")
+	}
+}
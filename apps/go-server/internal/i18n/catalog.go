@@ -0,0 +1,60 @@
+// apps/go-server/internal/i18n/catalog.go
+//
+// Translations keyed by the English message. Add a locale by adding a map;
+// keep keys byte-identical to the strings the server produces.
+
+package i18n
+
+var catalogs = map[string]map[string]string{
+	"es": {
+		"username must be 3–24 chars":                 "el nombre de usuario debe tener entre 3 y 24 caracteres",
+		"username: letters, numbers, underscore only": "nombre de usuario: solo letras, números y guion bajo",
+		"password must be 8–100 chars":                "la contraseña debe tener entre 8 y 100 caracteres",
+		"Username taken":                              "Nombre de usuario no disponible",
+		"Invalid username or password":                "Usuario o contraseña incorrectos",
+		"Email taken":                                 "Correo electrónico no disponible",
+		"invalid email":                               "correo electrónico no válido",
+		"email required":                              "se requiere un correo electrónico",
+		"Invalid email or password":                   "Correo electrónico o contraseña incorrectos",
+		"Invalid password":                            "Contraseña incorrecta",
+		"game finished":                               "la partida ha terminado",
+		"invalid guess":                               "intento no válido",
+		"not in word list":                            "no está en la lista de palabras",
+		"guess temporarily blocked":                   "palabra bloqueada temporalmente",
+		"word not allowed":                            "palabra no permitida",
+	},
+	"fr": {
+		"username must be 3–24 chars":                 "le nom d'utilisateur doit comporter de 3 à 24 caractères",
+		"username: letters, numbers, underscore only": "nom d'utilisateur : lettres, chiffres et tiret bas uniquement",
+		"password must be 8–100 chars":                "le mot de passe doit comporter de 8 à 100 caractères",
+		"Username taken":                              "Nom d'utilisateur déjà pris",
+		"Invalid username or password":                "Nom d'utilisateur ou mot de passe incorrect",
+		"Email taken":                                 "Adresse e-mail déjà utilisée",
+		"invalid email":                               "adresse e-mail invalide",
+		"email required":                              "adresse e-mail requise",
+		"Invalid email or password":                   "Adresse e-mail ou mot de passe incorrect",
+		"Invalid password":                            "Mot de passe incorrect",
+		"game finished":                               "la partie est terminée",
+		"invalid guess":                               "proposition invalide",
+		"not in word list":                            "absent de la liste de mots",
+		"guess temporarily blocked":                   "mot temporairement bloqué",
+		"word not allowed":                            "mot non autorisé",
+	},
+	"de": {
+		"username must be 3–24 chars":                 "Benutzername muss 3–24 Zeichen lang sein",
+		"username: letters, numbers, underscore only": "Benutzername: nur Buchstaben, Ziffern und Unterstrich",
+		"password must be 8–100 chars":                "Passwort muss 8–100 Zeichen lang sein",
+		"Username taken":                              "Benutzername bereits vergeben",
+		"Invalid username or password":                "Ungültiger Benutzername oder Passwort",
+		"Email taken":                                 "E-Mail-Adresse bereits vergeben",
+		"invalid email":                               "ungültige E-Mail-Adresse",
+		"email required":                              "E-Mail-Adresse erforderlich",
+		"Invalid email or password":                   "Ungültige E-Mail-Adresse oder Passwort",
+		"Invalid password":                            "Ungültiges Passwort",
+		"game finished":                               "Spiel beendet",
+		"invalid guess":                               "ungültiger Rateversuch",
+		"not in word list":                            "nicht in der Wortliste",
+		"guess temporarily blocked":                   "Wort vorübergehend gesperrt",
+		"word not allowed":                            "Wort nicht erlaubt",
+	},
+}
@@ -0,0 +1,78 @@
+// apps/go-server/internal/i18n/i18n.go
+//
+// Minimal message catalog for user-facing error strings.
+// Responsibilities:
+//   - Pick a supported locale from a `lang` query param or Accept-Language.
+//   - Translate an English message into that locale.
+//
+// Notes:
+//   - Messages are keyed by their English text, so existing error strings
+//     work as keys without a separate ID scheme.
+//   - Unknown locales and messages missing from a catalog fall back to English.
+
+package i18n
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default is the fallback locale; its "catalog" is the key itself.
+const Default = "en"
+
+// Locale picks the caller's locale: ?lang= wins, then the highest-weighted
+// supported Accept-Language entry, else Default.
+func Locale(r *http.Request) string {
+	if l := base(r.URL.Query().Get("lang")); supported(l) {
+		return l
+	}
+	type pref struct {
+		tag string
+		q   float64
+	}
+	var prefs []pref
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		if tag != "" && q > 0 {
+			prefs = append(prefs, pref{base(tag), q})
+		}
+	}
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].q > prefs[j].q })
+	for _, p := range prefs {
+		if supported(p.tag) {
+			return p.tag
+		}
+	}
+	return Default
+}
+
+// T translates msg into locale, falling back to msg itself.
+func T(locale, msg string) string {
+	if t, ok := catalogs[locale][msg]; ok {
+		return t
+	}
+	return msg
+}
+
+// base reduces a language tag to its lowercase primary subtag ("es-MX" → "es").
+func base(tag string) string {
+	tag, _, _ = strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	return tag
+}
+
+// supported reports whether locale has a catalog (or is the default).
+func supported(locale string) bool {
+	if locale == Default {
+		return true
+	}
+	_, ok := catalogs[locale]
+	return ok
+}
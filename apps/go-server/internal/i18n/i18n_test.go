@@ -0,0 +1,57 @@
+package i18n
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocale(t *testing.T) {
+	for _, tc := range []struct {
+		query, accept, want string
+	}{
+		{"", "", Default},
+		{"", "es-MX,es;q=0.9,en;q=0.8", "es"},
+		{"", "ja, fr;q=0.5", "fr"},
+		{"", "de;q=0.2, fr;q=0.7", "fr"},
+		{"", "fr;q=0, de;q=0.1", "de"},
+		{"", "ja, zh", Default},
+		{"?lang=de", "fr", "de"},
+		{"?lang=xx", "fr", "fr"},
+	} {
+		r := httptest.NewRequest("GET", "/"+tc.query, nil)
+		r.Header.Set("Accept-Language", tc.accept)
+		if got := Locale(r); got != tc.want {
+			t.Errorf("Locale(%q, Accept-Language %q) = %q, want %q", tc.query, tc.accept, got, tc.want)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T("es", "not in word list"); got != "no está en la lista de palabras" {
+		t.Errorf("T(es) = %q", got)
+	}
+	if got := T("fr", "not in word list"); got != "absent de la liste de mots" {
+		t.Errorf("T(fr) = %q", got)
+	}
+	if got := T("es", "no such message"); got != "no such message" {
+		t.Errorf("missing message = %q, want the English text", got)
+	}
+	if got := T("xx", "not in word list"); got != "not in word list" {
+		t.Errorf("unknown locale = %q, want the English text", got)
+	}
+}
+
+// Every locale translates the same set of messages.
+func TestCatalogsComplete(t *testing.T) {
+	ref := catalogs["es"]
+	for locale, cat := range catalogs {
+		for msg := range ref {
+			if cat[msg] == "" {
+				t.Errorf("%s: missing %q", locale, msg)
+			}
+		}
+		if len(cat) != len(ref) {
+			t.Errorf("%s has %d messages, es has %d", locale, len(cat), len(ref))
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// apps/go-server/internal/metrics/metrics.go
+//
+// Minimal Prometheus metrics (text exposition format 0.0.4), without the
+// client library.
+// Responsibilities:
+//   - Registry: per-route request counters and latency histograms, plus
+//     gauges whose values are read from callbacks at scrape time.
+//   - WriteText: renders everything for a GET /metrics scrape.
+//
+// Exported series:
+//   - http_requests_total{method,route,status}           counter
+//   - http_request_duration_seconds{method,route}        histogram (DefBuckets)
+//   - any gauge registered with GaugeFunc
+//
+// Notes:
+//   - route is the matched route pattern (e.g. "/game/{id}"), never the raw
+//     path, so label cardinality stays bounded.
+//   - Output is sorted so successive scrapes diff cleanly.
+
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefBuckets are the latency histogram upper bounds, in seconds.
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Sample is one gauge value with its labels.
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+type reqKey struct {
+	method, route string
+	status        int
+}
+
+type routeKey struct {
+	method, route string
+}
+
+type histogram struct {
+	counts []uint64 // per bucket, non-cumulative
+	sum    float64
+	count  uint64
+}
+
+type gauge struct {
+	name, help string
+	fn         func() []Sample
+}
+
+// Registry holds every series. Safe for concurrent use.
+type Registry struct {
+	mu        sync.Mutex
+	requests  map[reqKey]uint64
+	durations map[routeKey]*histogram
+	gauges    []gauge
+}
+
+// New returns an empty Registry.
+func New() *Registry {
+	return &Registry{
+		requests:  make(map[reqKey]uint64),
+		durations: make(map[routeKey]*histogram),
+	}
+}
+
+// ObserveRequest counts one finished request and records its latency.
+func (r *Registry) ObserveRequest(method, route string, status int, d time.Duration) {
+	secs := d.Seconds()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests[reqKey{method, route, status}]++
+	h := r.durations[routeKey{method, route}]
+	if h == nil {
+		h = &histogram{counts: make([]uint64, len(DefBuckets))}
+		r.durations[routeKey{method, route}] = h
+	}
+	for i, le := range DefBuckets {
+		if secs <= le {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += secs
+	h.count++
+}
+
+// GaugeFunc registers a gauge; fn is called on every scrape and must be
+// safe for concurrent use.
+func (r *Registry) GaugeFunc(name, help string, fn func() []Sample) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.gauges = append(r.gauges, gauge{name, help, fn})
+}
+
+// WriteText writes every series in the Prometheus text format.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	reqKeys := make([]reqKey, 0, len(r.requests))
+	for k := range r.requests {
+		reqKeys = append(reqKeys, k)
+	}
+	sort.Slice(reqKeys, func(i, j int) bool {
+		a, b := reqKeys[i], reqKeys[j]
+		if a.route != b.route {
+			return a.route < b.route
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.status < b.status
+	})
+	var sb strings.Builder
+	sb.WriteString("# HELP http_requests_total HTTP requests by method, route, and status code.\n")
+	sb.WriteString("# TYPE http_requests_total counter\n")
+	for _, k := range reqKeys {
+		fmt.Fprintf(&sb, "http_requests_total{method=%s,route=%s,status=\"%d\"} %d\n",
+			quote(k.method), quote(k.route), k.status, r.requests[k])
+	}
+
+	durKeys := make([]routeKey, 0, len(r.durations))
+	for k := range r.durations {
+		durKeys = append(durKeys, k)
+	}
+	sort.Slice(durKeys, func(i, j int) bool {
+		a, b := durKeys[i], durKeys[j]
+		if a.route != b.route {
+			return a.route < b.route
+		}
+		return a.method < b.method
+	})
+	sb.WriteString("# HELP http_request_duration_seconds HTTP request latency by method and route.\n")
+	sb.WriteString("# TYPE http_request_duration_seconds histogram\n")
+	for _, k := range durKeys {
+		h := r.durations[k]
+		labels := fmt.Sprintf("method=%s,route=%s", quote(k.method), quote(k.route))
+		var cum uint64
+		for i, le := range DefBuckets {
+			cum += h.counts[i]
+			fmt.Fprintf(&sb, "http_request_duration_seconds_bucket{%s,le=%s} %d\n", labels, quote(formatFloat(le)), cum)
+		}
+		fmt.Fprintf(&sb, "http_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, h.count)
+		fmt.Fprintf(&sb, "http_request_duration_seconds_sum{%s} %s\n", labels, formatFloat(h.sum))
+		fmt.Fprintf(&sb, "http_request_duration_seconds_count{%s} %d\n", labels, h.count)
+	}
+	gauges := append([]gauge(nil), r.gauges...)
+	r.mu.Unlock()
+
+	// Gauge callbacks run outside the lock; they may take their own.
+	for _, g := range gauges {
+		fmt.Fprintf(&sb, "# HELP %s %s\n# TYPE %s gauge\n", g.name, g.help, g.name)
+		for _, s := range g.fn() {
+			fmt.Fprintf(&sb, "%s%s %s\n", g.name, labelSet(s.Labels), formatFloat(s.Value))
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// labelSet renders labels as {k="v",...} in key order ("" when empty).
+func labelSet(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + quote(labels[k])
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// labelEscaper applies the only escapes the text format defines.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quote renders a label value as a double-quoted string.
+func quote(s string) string {
+	return `"` + labelEscaper.Replace(s) + `"`
+}
+
+// formatFloat renders v the way Prometheus clients do.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteText(t *testing.T) {
+	r := New()
+	r.ObserveRequest("GET", "/game/{id}", 200, 3*time.Millisecond)
+	r.ObserveRequest("GET", "/game/{id}", 200, 200*time.Millisecond)
+	r.ObserveRequest("POST", "/game/guess", 400, time.Millisecond)
+	r.GaugeFunc("wordle_things", "Things.", func() []Sample {
+		return []Sample{{Labels: map[string]string{"state": `a"b`}, Value: 2}}
+	})
+
+	var sb strings.Builder
+	if err := r.WriteText(&sb); err != nil {
+		t.Fatal(err)
+	}
+	out := sb.String()
+	for _, want := range []string{
+		"# TYPE http_requests_total counter\n",
+		`http_requests_total{method="GET",route="/game/{id}",status="200"} 2` + "\n",
+		`http_requests_total{method="POST",route="/game/guess",status="400"} 1` + "\n",
+		"# TYPE http_request_duration_seconds histogram\n",
+		`http_request_duration_seconds_bucket{method="GET",route="/game/{id}",le="0.005"} 1` + "\n",
+		`http_request_duration_seconds_bucket{method="GET",route="/game/{id}",le="0.1"} 1` + "\n",
+		`http_request_duration_seconds_bucket{method="GET",route="/game/{id}",le="0.25"} 2` + "\n",
+		`http_request_duration_seconds_bucket{method="GET",route="/game/{id}",le="+Inf"} 2` + "\n",
+		`http_request_duration_seconds_count{method="GET",route="/game/{id}"} 2` + "\n",
+		"# TYPE wordle_things gauge\n",
+		`wordle_things{state="a\"b"} 2` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output is missing %q:\n%s", want, out)
+		}
+	}
+	if i, j := strings.Index(out, `route="/game/guess"`), strings.Index(out, `route="/game/{id}"`); i > j {
+		t.Error("series are not sorted by route")
+	}
+}
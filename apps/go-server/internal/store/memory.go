@@ -8,7 +8,11 @@
 //   - Stores *game.Game objects keyed by ID in a map.
 //   - Concurrency-safe via RWMutex (concurrent reads allowed, writes exclusive).
 //   - State is lost when the process restarts.
-//   - Errors are returned for missing game IDs on Get().
+//   - ErrNotFound is returned for missing game IDs on Get().
+//   - With NewMemoryStoreTTL, Sweep evicts finished games after finishedTTL and
+//     any game older than maxAge, so a long-running server doesn't keep every
+//     game forever (the caller schedules Sweep).
+//   - See sql.go for the durable implementation (GAME_STORE=sql).
 
 package store
 
@@ -16,12 +20,16 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/robalobadob/wordle/apps/go-server/internal/game"
 )
 
+// ErrNotFound is returned by Get for unknown game IDs.
+var ErrNotFound = errors.New("not found")
+
 // Store defines the persistence interface for game sessions.
-// Implementations may be backed by memory (this package), Redis, SQL, etc.
+// Implementations may be backed by memory (this package), SQL (sql.go), etc.
 type Store interface {
 	// Save persists or updates a game state.
 	Save(ctx context.Context, g *game.Game) error
@@ -31,15 +39,43 @@ type Store interface {
 	Get(ctx context.Context, id string) (*game.Game, error)
 }
 
+// Sweeper is implemented by stores that evict expired games on demand.
+type Sweeper interface {
+	// Sweep removes expired games and returns how many were removed.
+	Sweep(ctx context.Context) int
+}
+
+// Counter is implemented by stores that can cheaply count the games they hold.
+type Counter interface {
+	// Count returns how many games are held and how many of them are unfinished.
+	Count(ctx context.Context) (total, playing int)
+}
+
 // memory is an in-memory map-based Store implementation.
 type memory struct {
-	mu    sync.RWMutex           // guards games map
-	games map[string]*game.Game  // keyed by Game.ID
+	mu    sync.RWMutex          // guards games map
+	games map[string]*game.Game // keyed by Game.ID
+
+	finishedTTL time.Duration    // keep finished games this long after FinishedAt (0 = forever)
+	maxAge      time.Duration    // drop any game this long after CreatedAt (0 = forever)
+	now         func() time.Time // clock (overridable for tests)
 }
 
-// NewMemoryStore constructs a new in-memory Store.
+// NewMemoryStore constructs a new in-memory Store that never evicts.
 func NewMemoryStore() Store {
-	return &memory{games: make(map[string]*game.Game)}
+	return NewMemoryStoreTTL(0, 0)
+}
+
+// NewMemoryStoreTTL constructs an in-memory Store whose Sweep evicts games
+// finished more than finishedTTL ago and games created more than maxAge ago
+// (abandoned ones included). A zero duration disables that rule.
+func NewMemoryStoreTTL(finishedTTL, maxAge time.Duration) Store {
+	return &memory{
+		games:       make(map[string]*game.Game),
+		finishedTTL: finishedTTL,
+		maxAge:      maxAge,
+		now:         time.Now,
+	}
 }
 
 // Save adds or updates the game in the map.
@@ -58,5 +94,47 @@ func (m *memory) Get(ctx context.Context, id string) (*game.Game, error) {
 	if g, ok := m.games[id]; ok {
 		return g, nil
 	}
-	return nil, errors.New("not found")
+	return nil, ErrNotFound
+}
+
+// Delete removes a game by ID; unknown IDs are ignored.
+func (m *memory) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.games, id)
+	return nil
+}
+
+// Count implements Counter.
+func (m *memory) Count(ctx context.Context) (total, playing int) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, g := range m.games {
+		if !g.Finished {
+			playing++
+		}
+	}
+	return len(m.games), playing
+}
+
+// Sweep implements Sweeper using the store's TTLs.
+func (m *memory) Sweep(ctx context.Context) int {
+	if m.finishedTTL <= 0 && m.maxAge <= 0 {
+		return 0
+	}
+	now := m.now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for id, g := range m.games {
+		expired := m.maxAge > 0 && !g.CreatedAt.IsZero() && now.Sub(g.CreatedAt) > m.maxAge
+		if m.finishedTTL > 0 && g.Finished && !g.FinishedAt.IsZero() && now.Sub(g.FinishedAt) > m.finishedTTL {
+			expired = true
+		}
+		if expired {
+			delete(m.games, id)
+			n++
+		}
+	}
+	return n
 }
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+)
+
+func TestMemorySweep(t *testing.T) {
+	ctx := context.Background()
+	st := NewMemoryStoreTTL(10*time.Minute, 24*time.Hour)
+	m := st.(*memory)
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return clock }
+
+	for _, g := range []*game.Game{
+		{ID: "playing", CreatedAt: clock},
+		{ID: "finished", CreatedAt: clock, Finished: true, FinishedAt: clock},
+		{ID: "abandoned", CreatedAt: clock.Add(-20 * time.Hour)},
+		{ID: "legacy", Finished: true}, // no timestamps: never swept
+	} {
+		_ = st.Save(ctx, g)
+	}
+	if n := m.Sweep(ctx); n != 0 {
+		t.Fatalf("Sweep at start removed %d, want 0", n)
+	}
+
+	clock = clock.Add(11 * time.Minute)
+	if n := m.Sweep(ctx); n != 1 {
+		t.Fatalf("Sweep after finishedTTL removed %d, want 1", n)
+	}
+	if _, err := st.Get(ctx, "finished"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("finished game still present: %v", err)
+	}
+
+	clock = clock.Add(4 * time.Hour)
+	if n := m.Sweep(ctx); n != 1 {
+		t.Fatalf("Sweep after maxAge removed %d, want 1", n)
+	}
+	if _, err := st.Get(ctx, "abandoned"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("abandoned game still present: %v", err)
+	}
+	for _, id := range []string{"playing", "legacy"} {
+		if _, err := st.Get(ctx, id); err != nil {
+			t.Fatalf("%s evicted early: %v", id, err)
+		}
+	}
+	if total, playing := m.Count(ctx); total != 2 || playing != 1 {
+		t.Fatalf("Count = %d, %d, want 2, 1", total, playing)
+	}
+}
+
+func TestMemoryStoreNeverEvictsByDefault(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryStore().(*memory)
+	m.now = func() time.Time { return time.Now().Add(365 * 24 * time.Hour) }
+	_ = m.Save(ctx, &game.Game{ID: "old", CreatedAt: time.Now(), Finished: true, FinishedAt: time.Now()})
+	if n := m.Sweep(ctx); n != 0 {
+		t.Fatalf("Sweep without TTLs removed %d", n)
+	}
+}
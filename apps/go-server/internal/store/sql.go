@@ -0,0 +1,116 @@
+// apps/go-server/internal/store/sql.go
+//
+// SQL-backed implementation of the Store interface (table game_state,
+// migrations 011, 012 and 018).
+//
+// Characteristics:
+//   - Games survive restarts and are visible to every instance sharing the DB.
+//   - Save is an upsert keyed by game ID, so concurrent saves never fail on a
+//     duplicate key; the last write wins.
+//   - Get returns a fresh copy; callers must Save after mutating it.
+//   - Guesses, jotto counts, and any custom allowed list are stored as JSON arrays; CreatedAt and
+//     FinishedAt as RFC3339Nano strings ('' for the zero time).
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+)
+
+// sqlStore persists games in the game_state table.
+type sqlStore struct {
+	db *sql.DB
+}
+
+// NewSQLStore constructs a Store backed by db. The game_state table must exist.
+func NewSQLStore(db *sql.DB) Store {
+	return &sqlStore{db: db}
+}
+
+// Save inserts or replaces the game's state.
+func (s *sqlStore) Save(ctx context.Context, g *game.Game) error {
+	guesses, err := json.Marshal(nonNil(g.Guesses))
+	if err != nil {
+		return err
+	}
+	counts, err := json.Marshal(nonNil(g.Counts))
+	if err != nil {
+		return err
+	}
+	allowed, err := json.Marshal(nonNil(g.Allowed))
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO game_state (id, mode, answer, rows, cols, guesses, counts, finished, won, created_at, finished_at, allowed, updated_at)
+		 VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)
+		 ON CONFLICT(id) DO UPDATE SET
+		   mode=excluded.mode, answer=excluded.answer, rows=excluded.rows, cols=excluded.cols,
+		   guesses=excluded.guesses, counts=excluded.counts, finished=excluded.finished,
+		   won=excluded.won, created_at=excluded.created_at, finished_at=excluded.finished_at,
+		   allowed=excluded.allowed, updated_at=excluded.updated_at`,
+		g.ID, string(g.Mode), g.Answer, g.Rows, g.Cols, string(guesses), string(counts),
+		g.Finished, g.Won, formatTime(g.CreatedAt), formatTime(g.FinishedAt), string(allowed),
+		time.Now().UTC().Format(time.RFC3339))
+	return err
+}
+
+// Get loads a game by ID. Returns ErrNotFound if there is no such game.
+func (s *sqlStore) Get(ctx context.Context, id string) (*game.Game, error) {
+	g := &game.Game{ID: id}
+	var mode, guesses, counts, created, finished, allowed string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT mode, answer, rows, cols, guesses, counts, finished, won, created_at, finished_at, allowed
+		   FROM game_state WHERE id=?`, id,
+	).Scan(&mode, &g.Answer, &g.Rows, &g.Cols, &guesses, &counts, &g.Finished, &g.Won, &created, &finished, &allowed)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	g.Mode = game.Mode(mode)
+	g.CreatedAt, _ = time.Parse(time.RFC3339Nano, created)
+	g.FinishedAt, _ = time.Parse(time.RFC3339Nano, finished)
+	if err := json.Unmarshal([]byte(guesses), &g.Guesses); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(counts), &g.Counts); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(allowed), &g.Allowed); err != nil {
+		return nil, err
+	}
+	if len(g.Allowed) == 0 {
+		g.Allowed = nil
+	}
+	if len(g.Counts) == 0 {
+		g.Counts = nil // match a game that was never scored in jotto mode
+	}
+	if len(g.Guesses) == 0 {
+		g.Guesses = nil
+	}
+	return g, nil
+}
+
+// formatTime encodes t as RFC3339Nano, or "" for the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// nonNil returns s, or an empty slice so it encodes as [] rather than null.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
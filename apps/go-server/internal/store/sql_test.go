@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/robalobadob/wordle/apps/go-server/internal/game"
+)
+
+// openTestDB opens a SQLite database in a temp dir with every ../../sql
+// migration applied in order.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db")+"?_busy_timeout=5000&_foreign_keys=on")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	files, err := filepath.Glob(filepath.Join("..", "..", "sql", "*.sql"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no migrations found: %v", err)
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(string(b)); err != nil {
+			t.Fatalf("apply %s: %v", f, err)
+		}
+	}
+	return db
+}
+
+func TestSQLStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	s := NewSQLStore(openTestDB(t))
+	created := time.Date(2026, 3, 1, 12, 0, 0, 123456789, time.UTC)
+
+	for _, g := range []*game.Game{
+		{ID: "fresh", Mode: game.ModeNormal, Answer: "crane", Rows: 6, Cols: 5, CreatedAt: created},
+		{ID: "won", Mode: game.ModeHard, Answer: "crane", Rows: 6, Cols: 5, Guesses: []string{"slate", "crane"},
+			Finished: true, Won: true, CreatedAt: created, FinishedAt: created.Add(time.Minute)},
+		{ID: "jotto", Mode: game.ModeJotto, Answer: "crane", Rows: 8, Cols: 5, Guesses: []string{"slate"}, Counts: []int{2},
+			Allowed: []string{"crane", "slate"}, CreatedAt: created},
+	} {
+		if err := s.Save(ctx, g); err != nil {
+			t.Fatalf("Save %s: %v", g.ID, err)
+		}
+		got, err := s.Get(ctx, g.ID)
+		if err != nil {
+			t.Fatalf("Get %s: %v", g.ID, err)
+		}
+		if !reflect.DeepEqual(got, g) {
+			t.Fatalf("%s round trip:\n got %+v\nwant %+v", g.ID, got, g)
+		}
+	}
+
+	if _, err := s.Get(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get missing: err %v, want ErrNotFound", err)
+	}
+}
+
+func TestSQLStoreConcurrentSaves(t *testing.T) {
+	ctx := context.Background()
+	s := NewSQLStore(openTestDB(t))
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			g := &game.Game{ID: "shared", Mode: game.ModeNormal, Answer: "crane", Rows: 6, Cols: 5,
+				Guesses: []string{fmt.Sprintf("g%04d", i)}}
+			errs <- s.Save(ctx, g)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent Save: %v", err)
+		}
+	}
+	g, err := s.Get(ctx, "shared")
+	if err != nil || len(g.Guesses) != 1 {
+		t.Fatalf("after concurrent saves: %+v, %v", g, err)
+	}
+}
@@ -0,0 +1,153 @@
+// apps/go-server/internal/webhook/webhook.go
+//
+// Outbound webhooks for game completion events.
+// Responsibilities:
+//   - Notifier: the interface handlers call; Nop when webhooks are off.
+//   - Dispatcher: bounded in-memory queue drained by one worker that POSTs
+//     JSON events with an HMAC-SHA256 signature and retries failures.
+//
+// Delivery:
+//   - Notify never blocks: when the queue is full the event is dropped and logged.
+//   - Each event is attempted up to Retries+1 times with doubling backoff;
+//     any 2xx response counts as delivered.
+//   - Signature header: X-Wordle-Signature: sha256=<hex HMAC of the body>.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SignatureHeader carries the body signature on every delivery.
+const SignatureHeader = "X-Wordle-Signature"
+
+// Event types.
+const (
+	GameCompleted  = "game.completed"
+	DailyCompleted = "daily.completed"
+)
+
+// Event is the JSON payload POSTed for a completion.
+type Event struct {
+	Type    string    `json:"type"`             // GameCompleted | DailyCompleted
+	GameID  string    `json:"gameId,omitempty"` // classic game or daily session ID
+	UserID  string    `json:"userId,omitempty"` // empty for guests
+	Result  string    `json:"result"`           // "won" | "lost"
+	Guesses int       `json:"guesses"`
+	Date    string    `json:"date,omitempty"` // daily date key
+	At      time.Time `json:"at"`
+}
+
+// Notifier receives completion events.
+type Notifier interface {
+	Notify(Event)
+}
+
+// Nop discards events.
+type Nop struct{}
+
+// Notify implements Notifier.
+func (Nop) Notify(Event) {}
+
+// Dispatcher delivers events to a single URL asynchronously.
+type Dispatcher struct {
+	URL     string
+	Secret  string
+	Client  *http.Client
+	Retries int           // extra attempts after the first
+	Backoff time.Duration // wait before the first retry; doubles each time
+
+	queue chan Event
+}
+
+// NewDispatcher builds a dispatcher with a queue of queueSize events.
+// Call Run to start delivering.
+func NewDispatcher(url, secret string, queueSize int) *Dispatcher {
+	return &Dispatcher{
+		URL:     url,
+		Secret:  secret,
+		Client:  &http.Client{Timeout: 5 * time.Second},
+		Retries: 3,
+		Backoff: time.Second,
+		queue:   make(chan Event, max(queueSize, 1)),
+	}
+}
+
+// Notify enqueues e without blocking; drops it if the queue is full.
+func (d *Dispatcher) Notify(e Event) {
+	select {
+	case d.queue <- e:
+	default:
+		log.Warn().Str("type", e.Type).Str("gameId", e.GameID).Msg("webhook queue full; event dropped")
+	}
+}
+
+// Run delivers queued events until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-d.queue:
+			if err := d.deliver(ctx, e); err != nil {
+				log.Warn().Err(err).Str("type", e.Type).Str("gameId", e.GameID).Msg("webhook delivery failed")
+			}
+		}
+	}
+}
+
+// deliver POSTs e, retrying with backoff.
+func (d *Dispatcher) deliver(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	wait := d.Backoff
+	for attempt := 0; ; attempt++ {
+		if err = d.post(ctx, body); err == nil || attempt >= d.Retries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+// post performs a single signed delivery attempt.
+func (d *Dispatcher) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, "sha256="+Sign(d.Secret, body))
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the hex HMAC-SHA256 of body under secret.
+func Sign(secret string, body []byte) string {
+	m := hmac.New(sha256.New, []byte(secret))
+	m.Write(body)
+	return hex.EncodeToString(m.Sum(nil))
+}
@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// receiver records deliveries, failing the first `fail` attempts with a 500.
+func receiver(t *testing.T, secret string, fail int32) (*httptest.Server, <-chan Event, *atomic.Int32) {
+	t.Helper()
+	got := make(chan Event, 4)
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) <= fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(SignatureHeader) != "sha256="+Sign(secret, body) {
+			t.Errorf("bad signature %q", r.Header.Get(SignatureHeader))
+		}
+		var e Event
+		if err := json.Unmarshal(body, &e); err != nil {
+			t.Errorf("payload %s: %v", body, err)
+		}
+		got <- e
+	}))
+	t.Cleanup(srv.Close)
+	return srv, got, &attempts
+}
+
+func TestDispatcherSignsAndRetries(t *testing.T) {
+	srv, got, attempts := receiver(t, "s3cret", 2)
+	d := NewDispatcher(srv.URL, "s3cret", 4)
+	d.Backoff = time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	d.Notify(Event{Type: GameCompleted, GameID: "g1", Result: "won", Guesses: 3})
+	select {
+	case e := <-got:
+		if e.Type != GameCompleted || e.GameID != "g1" || e.Result != "won" || e.Guesses != 3 {
+			t.Fatalf("delivered %+v", e)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("event never delivered")
+	}
+	if n := attempts.Load(); n != 3 {
+		t.Fatalf("%d attempts, want 2 failures then a success", n)
+	}
+}
+
+func TestNotifyNeverBlocks(t *testing.T) {
+	d := NewDispatcher("http://127.0.0.1:0", "", 1) // not running: the queue fills
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			d.Notify(Event{Type: DailyCompleted})
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Notify blocked on a full queue")
+	}
+}
+
+func TestSign(t *testing.T) {
+	a, b := Sign("k", []byte("body")), Sign("k", []byte("body!"))
+	if len(a) != 64 || a == b || a == Sign("other", []byte("body")) {
+		t.Fatalf("Sign gave %q and %q", a, b)
+	}
+}
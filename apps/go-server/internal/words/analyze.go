@@ -0,0 +1,49 @@
+// apps/go-server/internal/words/analyze.go
+//
+// Opener analysis for picking daily answers.
+// For a candidate answer, scores a corpus of opening guesses against it and
+// tallies the marks per position, showing how much a typical first guess
+// reveals (many early hits makes for a short game).
+
+package words
+
+// PositionMarks counts the marks one position received across the openers.
+type PositionMarks struct {
+	Miss    int `json:"miss"`
+	Present int `json:"present"`
+	Hit     int `json:"hit"`
+}
+
+// Analysis is the result of AnalyzeOpeners.
+type Analysis struct {
+	Answer    string          `json:"answer"`
+	Openers   []string        `json:"openers"`   // openers actually scored
+	Positions []PositionMarks `json:"positions"` // one entry per letter of Answer
+}
+
+// AnalyzeOpeners scores each opener against answer with Score and tallies the
+// marks per position. Openers whose length differs from answer are skipped.
+func AnalyzeOpeners(answer string, openers []string) Analysis {
+	out := Analysis{
+		Answer:    answer,
+		Openers:   []string{},
+		Positions: make([]PositionMarks, len(answer)),
+	}
+	for _, o := range openers {
+		if len(o) != len(answer) {
+			continue
+		}
+		out.Openers = append(out.Openers, o)
+		for i, m := range Score(o, answer) {
+			switch m {
+			case 2:
+				out.Positions[i].Hit++
+			case 1:
+				out.Positions[i].Present++
+			default:
+				out.Positions[i].Miss++
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,24 @@
+package words
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAnalyzeOpeners(t *testing.T) {
+	// trace → ⬛🟩🟩🟨🟩, slate → ⬛⬛🟩⬛🟩, audio → 🟨⬛⬛⬛⬛ against crane.
+	got := AnalyzeOpeners("crane", []string{"trace", "slate", "audio", "ab"})
+	if !slices.Equal(got.Openers, []string{"trace", "slate", "audio"}) {
+		t.Fatalf("openers = %v, want the wrong-length one skipped", got.Openers)
+	}
+	want := []PositionMarks{
+		{Miss: 2, Present: 1},
+		{Miss: 2, Hit: 1},
+		{Miss: 1, Hit: 2},
+		{Miss: 2, Present: 1},
+		{Miss: 1, Hit: 2},
+	}
+	if !slices.Equal(got.Positions, want) {
+		t.Fatalf("positions = %+v, want %+v", got.Positions, want)
+	}
+}
@@ -0,0 +1,100 @@
+// apps/go-server/internal/words/bloom.go
+//
+// Optional Bloom-filter storage for the classic allowed-guess lists.
+//
+// With WORDS_ALLOWED_BLOOM=true, Init replaces each length's exact allowed set
+// with a Bloom filter once the lists are loaded, which is much smaller than a
+// map for large guess lists (roughly 1.8 bytes per word at the default rate).
+//
+// Tradeoffs:
+//   • No false negatives: every loaded word is still accepted.
+//   • False positives: a non-word passes IsAllowed with probability about
+//     WORDS_ALLOWED_BLOOM_FP (default 0.001). Answers are always checked
+//     against the exact answer set first, so they are never affected.
+//   • The allowed list can no longer be enumerated, so Match over the allowed
+//     list returns ErrAllowedUnavailable.
+//   • The daily list (Allowed()) stays an exact set.
+
+package words
+
+import (
+	"hash/fnv"
+	"math"
+	"os"
+	"strconv"
+)
+
+// bloom is a fixed-size Bloom filter over strings.
+type bloom struct {
+	bits []uint64
+	m    uint64 // number of bits
+	k    uint64 // hash functions per key
+	n    int    // keys added
+}
+
+// newBloom sizes a filter for n keys at false-positive rate fp.
+func newBloom(n int, fp float64) *bloom {
+	if n < 1 {
+		n = 1
+	}
+	if fp <= 0 || fp >= 1 {
+		fp = 0.001
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(fp) / (math.Ln2 * math.Ln2)))
+	m = max(m, 64)
+	k := uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	k = max(k, 1)
+	return &bloom{bits: make([]uint64, (m+63)/64), m: m, k: k}
+}
+
+// hashes returns the two base hashes for double hashing (h1 + i*h2).
+func (b *bloom) hashes(w string) (uint64, uint64) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(w))
+	h1 := h.Sum64()
+	h2 := h1>>33 | h1<<31 // rotate for an independent-enough second hash
+	return h1, h2 | 1     // odd h2 so probes never repeat a single bit
+}
+
+// add inserts w.
+func (b *bloom) add(w string) {
+	h1, h2 := b.hashes(w)
+	for i := uint64(0); i < b.k; i++ {
+		bit := (h1 + i*h2) % b.m
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+	b.n++
+}
+
+// has reports whether w may have been added (false means definitely not).
+func (b *bloom) has(w string) bool {
+	h1, h2 := b.hashes(w)
+	for i := uint64(0); i < b.k; i++ {
+		bit := (h1 + i*h2) % b.m
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// useAllowedBloom moves every length's allowed set into a Bloom filter when
+// WORDS_ALLOWED_BLOOM=true. Must run after answersSet is built.
+func (l *lists) useAllowedBloom() {
+	if os.Getenv("WORDS_ALLOWED_BLOOM") != "true" {
+		return
+	}
+	fp, err := strconv.ParseFloat(os.Getenv("WORDS_ALLOWED_BLOOM_FP"), 64)
+	if err != nil {
+		fp = 0.001
+	}
+	l.allowedBloom = make(map[int]*bloom, len(l.allowedSet))
+	for n, set := range l.allowedSet {
+		b := newBloom(len(set), fp)
+		for w := range set {
+			b.add(w)
+		}
+		l.allowedBloom[n] = b
+	}
+	l.allowedSet = nil
+}
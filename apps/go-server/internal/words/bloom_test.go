@@ -0,0 +1,69 @@
+package words
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// synthWord returns a distinct 6-letter lowercase word for i.
+func synthWord(i int) string {
+	b := []byte("aaaaaa")
+	for j := len(b) - 1; j >= 0 && i > 0; j-- {
+		b[j] = byte('a' + i%26)
+		i /= 26
+	}
+	return string(b)
+}
+
+func TestBloomNoFalseNegatives(t *testing.T) {
+	const n, fp = 5000, 0.01
+	b := newBloom(n, fp)
+	for i := 0; i < n; i++ {
+		b.add(synthWord(i))
+	}
+	for i := 0; i < n; i++ {
+		if !b.has(synthWord(i)) {
+			t.Fatalf("false negative for %q", synthWord(i))
+		}
+	}
+	positives := 0
+	const probes = 20000
+	for i := n; i < n+probes; i++ {
+		if b.has(synthWord(i)) {
+			positives++
+		}
+	}
+	if rate := float64(positives) / probes; rate > 3*fp {
+		t.Fatalf("false-positive rate %.4f, configured %.2f", rate, fp)
+	}
+}
+
+func TestAllowedBloomMode(t *testing.T) {
+	// Registered first so it runs after the env is restored.
+	t.Cleanup(func() { _, _ = Reload() })
+	dir := t.TempDir()
+	allowed := []string{"crane", "slate", "adieu", "planet"}
+	for i := 0; i < 500; i++ {
+		allowed = append(allowed, synthWord(i*7 + 1)[1:]) // 5-letter filler
+	}
+	t.Setenv("WORDS_ANSWERS_FILE", writeList(t, dir, "answers.txt", "crane", "zebra"))
+	t.Setenv("WORDS_ALLOWED_FILE", writeList(t, dir, "allowed.txt", allowed...))
+	t.Setenv("WORDS_ALLOWED_BLOOM", "true")
+	t.Setenv("WORDS_ALLOWED_BLOOM_FP", strconv.FormatFloat(0.001, 'f', -1, 64))
+	if _, err := Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	for _, w := range append(allowed, "zebra") {
+		if !IsAllowed(w) {
+			t.Fatalf("%q rejected in Bloom mode", w)
+		}
+	}
+	if _, err := Match(".....", "", "", false, 0); !errors.Is(err, ErrAllowedUnavailable) {
+		t.Fatalf("Match over allowed: err %v, want ErrAllowedUnavailable", err)
+	}
+	if got, err := Match(".....", "", "", true, 0); err != nil || len(got) != 2 {
+		t.Fatalf("Match over answers = %v, %v", got, err)
+	}
+}
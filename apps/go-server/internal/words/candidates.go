@@ -0,0 +1,54 @@
+// apps/go-server/internal/words/candidates.go
+//
+// Candidate filtering: which words were still possible given a set of guesses.
+//
+// A word w is consistent with the guesses if scoring every guess against w
+// yields exactly the marks the guess got against the real answer.
+
+package words
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+)
+
+// Consistent returns the words in pool (in pool order) that are consistent
+// with guesses scored against answer. The answer itself is excluded.
+func Consistent(pool []string, answer string, guesses []string) []string {
+	want := make([][]int, len(guesses))
+	for i, g := range guesses {
+		want[i] = Score(g, answer)
+	}
+	var out []string
+	for _, w := range pool {
+		if w == answer || len(w) != len(answer) {
+			continue
+		}
+		ok := true
+		for i, g := range guesses {
+			if !slices.Equal(Score(g, w), want[i]) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// SampleConsistent picks up to n consistent words, shuffled deterministically
+// by seed so repeated requests for the same game return the same sample.
+func SampleConsistent(pool []string, answer string, guesses []string, n int, seed string) []string {
+	list := Consistent(pool, answer, guesses)
+	h := fnv.New64a()
+	h.Write([]byte(seed))
+	rng := rand.New(rand.NewPCG(h.Sum64(), 0))
+	rng.Shuffle(len(list), func(i, j int) { list[i], list[j] = list[j], list[i] })
+	if len(list) > n {
+		list = list[:n]
+	}
+	return list
+}
@@ -0,0 +1,63 @@
+package words
+
+import (
+	"slices"
+	"testing"
+)
+
+var candidatePool = []string{
+	"crane", "crate", "trace", "grace", "brace", "space", "slate", "plate",
+	"skate", "state", "shade", "shake", "shame", "shape", "share", "stare",
+}
+
+func TestConsistent(t *testing.T) {
+	const answer = "shape"
+	guesses := []string{"crane", "slate"}
+	got := Consistent(candidatePool, answer, guesses)
+
+	if slices.Contains(got, answer) {
+		t.Fatalf("Consistent %v includes the answer", got)
+	}
+	// Exactly the pool words (other than the answer) that score like the answer.
+	var want []string
+	for _, w := range candidatePool {
+		if w == answer {
+			continue
+		}
+		ok := true
+		for _, g := range guesses {
+			ok = ok && slices.Equal(Score(g, w), Score(g, answer))
+		}
+		if ok {
+			want = append(want, w)
+		}
+	}
+	if !slices.Equal(got, want) || len(want) == 0 {
+		t.Fatalf("Consistent = %v, want %v", got, want)
+	}
+	if got := Consistent(candidatePool, answer, nil); len(got) != len(candidatePool)-1 {
+		t.Fatalf("with no guesses got %d words, want the whole pool but the answer", len(got))
+	}
+}
+
+func TestSampleConsistent(t *testing.T) {
+	const answer = "shape"
+	guesses := []string{"crane"}
+	all := Consistent(candidatePool, answer, guesses)
+
+	a := SampleConsistent(candidatePool, answer, guesses, 3, "game-1")
+	if len(a) != 3 {
+		t.Fatalf("sample = %v, want 3 words", a)
+	}
+	for _, w := range a {
+		if !slices.Contains(all, w) {
+			t.Fatalf("sample word %q is not consistent with %v", w, guesses)
+		}
+	}
+	if b := SampleConsistent(candidatePool, answer, guesses, 3, "game-1"); !slices.Equal(a, b) {
+		t.Fatalf("same seed gave %v then %v", a, b)
+	}
+	if got := SampleConsistent(candidatePool, answer, guesses, 100, "game-1"); len(got) != len(all) {
+		t.Fatalf("large n gave %d words, want all %d", len(got), len(all))
+	}
+}
@@ -77,32 +77,7 @@ func Allowed() map[string]struct{} {
 //   Pass 1: mark exact matches (hits) and count remaining letters.
 //   Pass 2: for non-hits, mark present if unused letters remain.
 func Score(guess, answer string) []int {
-	n := len(answer)
-	out := make([]int, n)
-	if len(guess) != n {
-		return out
-	}
-
-	// Pass 1: hits and frequency counts
-	freq := make(map[byte]int, n)
-	for i := 0; i < n; i++ {
-		if guess[i] == answer[i] {
-			out[i] = 2 // hit
-		} else {
-			freq[answer[i]]++
-		}
-	}
-
-	// Pass 2: mark presents where applicable
-	for i := 0; i < n; i++ {
-		if out[i] == 2 {
-			continue
-		}
-		c := guess[i]
-		if freq[c] > 0 {
-			out[i] = 1 // present
-			freq[c]--
-		}
-	}
+	out := make([]int, len(answer))
+	ScoreInto(guess, answer, out)
 	return out
 }
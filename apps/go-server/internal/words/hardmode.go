@@ -0,0 +1,50 @@
+// apps/go-server/internal/words/hardmode.go
+//
+// Hard-mode constraint checking shared by the daily and classic engines.
+//
+// Rules (as in the original game):
+//   • A letter marked hit must stay in the same position in later guesses.
+//   • A letter marked present (or hit) must be reused in later guesses,
+//     at least as many times as it was revealed.
+//
+// Constraints are always derived by re-scoring earlier guesses with Score,
+// so the two-pass algorithm is the single source of truth.
+
+package words
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CheckHardMode reports whether guess honours every hint revealed by the
+// prior guesses against answer. Returns a descriptive error for the first
+// violated rule, or nil.
+func CheckHardMode(answer string, prior []string, guess string) error {
+	for _, p := range prior {
+		marks := Score(p, answer)
+
+		// Hits must stay in place.
+		for i, m := range marks {
+			if m == 2 && (i >= len(guess) || guess[i] != p[i]) {
+				return fmt.Errorf("must use revealed letter %s in position %d",
+					strings.ToUpper(string(p[i])), i+1)
+			}
+		}
+
+		// Revealed letters (hit or present) must appear at least as often.
+		need := map[byte]int{}
+		for i, m := range marks {
+			if m > 0 {
+				need[p[i]]++
+			}
+		}
+		for i := 0; i < len(p); i++ {
+			c := p[i]
+			if need[c] > 0 && strings.Count(guess, string(c)) < need[c] {
+				return fmt.Errorf("guess must contain %s", strings.ToUpper(string(c)))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// apps/go-server/internal/words/keyboard.go
+//
+// Keyboard-distance metrics for speed-typing players: how far the fingers
+// travel to type a word on a given layout.
+//
+// Model:
+//   - Keys sit on a grid one key-width apart; each letter row is shifted
+//     right by its physical stagger (and, for Dvorak's top row, by the
+//     punctuation keys before "p").
+//   - TypingDistance is the sum of straight-line distances between
+//     consecutive letters, in key widths. Repeated letters cost nothing.
+//   - Layouts: qwerty, azerty, dvorak (US). Only a–z are placed.
+
+package words
+
+import (
+	"math"
+	"strings"
+)
+
+// Layout places the letters a–z on a staggered three-row keyboard.
+type Layout struct {
+	Name string
+	keys [26]keyPos
+}
+
+// keyPos is a key centre in key widths (x) and rows from the top (y).
+type keyPos struct {
+	x, y float64
+	ok   bool
+}
+
+// newLayout builds a Layout from its letter rows (top to bottom) and the
+// horizontal offset of each row's first letter.
+func newLayout(name string, rows [3]string, offsets [3]float64) Layout {
+	l := Layout{Name: name}
+	for y, row := range rows {
+		for x := 0; x < len(row); x++ {
+			l.keys[row[x]-'a'] = keyPos{x: offsets[y] + float64(x), y: float64(y), ok: true}
+		}
+	}
+	return l
+}
+
+var layouts = map[string]Layout{
+	"qwerty": newLayout("qwerty", [3]string{"qwertyuiop", "asdfghjkl", "zxcvbnm"}, [3]float64{0, 0.25, 0.75}),
+	"azerty": newLayout("azerty", [3]string{"azertyuiop", "qsdfghjklm", "wxcvbn"}, [3]float64{0, 0.25, 0.75}),
+	"dvorak": newLayout("dvorak", [3]string{"pyfgcrl", "aoeuidhtns", "qjkxbmwvz"}, [3]float64{3, 0.25, 1.75}),
+}
+
+// LayoutByName looks a layout up by case-insensitive name.
+func LayoutByName(name string) (Layout, bool) {
+	l, ok := layouts[strings.ToLower(strings.TrimSpace(name))]
+	return l, ok
+}
+
+// TypingDistance returns the finger travel for word on l, in key widths
+// rounded to two decimals. ok is false if word has a character l does not
+// place (anything outside a–z).
+func TypingDistance(word string, l Layout) (dist float64, ok bool) {
+	var prev keyPos
+	for i := 0; i < len(word); i++ {
+		c := word[i] - 'a'
+		if c >= 26 || !l.keys[c].ok {
+			return 0, false
+		}
+		k := l.keys[c]
+		if i > 0 {
+			dist += math.Hypot(k.x-prev.x, k.y-prev.y)
+		}
+		prev = k
+	}
+	return math.Round(dist*100) / 100, true
+}
@@ -0,0 +1,39 @@
+package words
+
+import "testing"
+
+func TestTypingDistance(t *testing.T) {
+	for _, tc := range []struct {
+		layout, word string
+		want         float64
+	}{
+		{"qwerty", "as", 1},    // neighbours on the home row
+		{"qwerty", "qa", 1.03}, // one row down, a quarter key right
+		{"qwerty", "aaaa", 0},  // repeats cost nothing
+		{"qwerty", "crane", 14.78},
+		{"azerty", "as", 1.6}, // a sits on the top row here
+		{"azerty", "qa", 1.03},
+		{"dvorak", "ao", 1},
+		{"dvorak", "as", 9}, // opposite ends of the home row
+		{"dvorak", "adieu", 9},
+	} {
+		l, ok := LayoutByName(tc.layout)
+		if !ok {
+			t.Fatalf("LayoutByName(%q) failed", tc.layout)
+		}
+		if got, ok := TypingDistance(tc.word, l); !ok || got != tc.want {
+			t.Errorf("TypingDistance(%q, %s) = %v, %v; want %v", tc.word, tc.layout, got, ok, tc.want)
+		}
+	}
+
+	qwerty, _ := LayoutByName(" QWERTY ")
+	if qwerty.Name != "qwerty" {
+		t.Fatalf("LayoutByName is case-sensitive: %+v", qwerty)
+	}
+	if _, ok := TypingDistance("ab1", qwerty); ok {
+		t.Error("TypingDistance accepted a digit")
+	}
+	if _, ok := LayoutByName("colemak"); ok {
+		t.Error("LayoutByName accepted an unknown layout")
+	}
+}
@@ -0,0 +1,79 @@
+// apps/go-server/internal/words/match.go
+//
+// Pattern search over the word lists (crossword-style helper).
+//
+// A pattern is one character per position: a letter fixes that position and
+// '.' is a wildcard. The pattern's length selects the word length.
+//
+// Filters:
+//   • contains – every listed letter must appear somewhere in the word.
+//   • excludes – none of the listed letters may appear in the word.
+
+package words
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ErrBadPattern is returned for patterns of unsupported length or with
+// characters other than a–z and '.'.
+var ErrBadPattern = errors.New("invalid pattern")
+
+// ErrAllowedUnavailable is returned when matching against the allowed list
+// while it is stored as a Bloom filter (WORDS_ALLOWED_BLOOM), which can't be
+// enumerated.
+var ErrAllowedUnavailable = errors.New("allowed list not enumerable")
+
+// Match returns up to limit words (sorted) matching pattern, containing every
+// letter in contains and none in excludes. Words come from the answer list
+// when fromAnswers is true, otherwise from the allowed list. limit <= 0 means
+// no cap.
+func Match(pattern, contains, excludes string, fromAnswers bool, limit int) ([]string, error) {
+	pattern = strings.ToLower(pattern)
+	if len(pattern) < MinLength || len(pattern) > MaxLength {
+		return nil, ErrBadPattern
+	}
+	for i := 0; i < len(pattern); i++ {
+		if c := pattern[i]; c != '.' && (c < 'a' || c > 'z') {
+			return nil, ErrBadPattern
+		}
+	}
+
+	l := current()
+	if !fromAnswers && l.allowedBloom != nil {
+		return nil, ErrAllowedUnavailable
+	}
+	set := l.allowedSet[len(pattern)]
+	if fromAnswers {
+		set = l.answersSet[len(pattern)]
+	}
+	out := []string{}
+	for w := range set {
+		if matchWord(w, pattern, strings.ToLower(contains), strings.ToLower(excludes)) {
+			out = append(out, w)
+		}
+	}
+	sort.Strings(out)
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// matchWord reports whether w fits pattern and the letter filters.
+// w and pattern must have the same length.
+func matchWord(w, pattern, contains, excludes string) bool {
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '.' && pattern[i] != w[i] {
+			return false
+		}
+	}
+	for _, c := range contains {
+		if !strings.ContainsRune(w, c) {
+			return false
+		}
+	}
+	return !strings.ContainsAny(w, excludes)
+}
@@ -0,0 +1,48 @@
+package words
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	// Registered first so it runs after the env is restored.
+	t.Cleanup(func() { _, _ = Reload() })
+	dir := t.TempDir()
+	t.Setenv("WORDS_ANSWERS_FILE", writeList(t, dir, "answers.txt", "crane", "crate"))
+	t.Setenv("WORDS_ALLOWED_FILE", writeList(t, dir, "allowed.txt", "crane", "crate", "chase", "cease", "grape", "crazy", "cranes"))
+	if _, err := Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	for _, tc := range []struct {
+		pattern, contains, excludes string
+		answers                     bool
+		limit                       int
+		want                        string
+	}{
+		{"c.a.e", "", "", false, 0, "cease chase crane crate"},
+		{"C.A.E", "r", "", false, 0, "crane crate"},
+		{"c.a.e", "", "nt", false, 0, "cease chase"},
+		{"c.a.e", "", "", true, 0, "crane crate"},
+		{".....", "rz", "", false, 0, "crazy"},
+		{"c.a.e", "", "", false, 2, "cease chase"},
+		{"c.a.es", "", "", false, 0, "cranes"},
+		{"x.a.e", "", "", false, 0, ""},
+	} {
+		got, err := Match(tc.pattern, tc.contains, tc.excludes, tc.answers, tc.limit)
+		if err != nil {
+			t.Fatalf("Match(%q, %q, %q): %v", tc.pattern, tc.contains, tc.excludes, err)
+		}
+		if strings.Join(got, " ") != tc.want {
+			t.Errorf("Match(%q, %q, %q, answers=%v, limit=%d) = %v, want [%s]", tc.pattern, tc.contains, tc.excludes, tc.answers, tc.limit, got, tc.want)
+		}
+	}
+
+	for _, bad := range []string{"c.a", "c.a.e.s.t.y", "c?a.e", "c a e"} {
+		if _, err := Match(bad, "", "", false, 0); !errors.Is(err, ErrBadPattern) {
+			t.Errorf("Match(%q): err %v, want ErrBadPattern", bad, err)
+		}
+	}
+}
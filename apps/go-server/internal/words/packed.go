@@ -0,0 +1,31 @@
+// apps/go-server/internal/words/packed.go
+//
+// Compact bitmask encoding of integer marks (as produced by Score).
+//
+// Layout: 2 bits per letter, letter i in bits 2i..2i+1 (least significant
+// first), each holding 0 = miss, 1 = present, 2 = hit. A MaxLength word needs
+// 16 bits, so any supported guess fits in a uint32. The word length is not
+// encoded; decoders take it from the game.
+
+package words
+
+// PackMarks encodes marks into a bitmask. Values outside 0–2 are packed as miss.
+func PackMarks(marks []int) uint32 {
+	var v uint32
+	for i, m := range marks {
+		if m < 0 || m > 2 {
+			m = 0
+		}
+		v |= uint32(m) << (2 * i)
+	}
+	return v
+}
+
+// UnpackMarks decodes the first n letters of a PackMarks bitmask.
+func UnpackMarks(v uint32, n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = int(v>>(2*i)) & 0b11
+	}
+	return out
+}
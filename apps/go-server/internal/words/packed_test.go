@@ -0,0 +1,31 @@
+package words
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPackMarksRoundTrip(t *testing.T) {
+	for _, tc := range []struct{ guess, answer string }{
+		{"crane", "crane"},
+		{"sassy", "asses"}, // repeated letters on both sides
+		{"geese", "eerie"},
+		{"llama", "hello"},
+		{"abbey", "bobby"},
+		{"banana", "ananas"},
+		{"zzzzzzzz", "abcdefgh"},
+	} {
+		marks := Score(tc.guess, tc.answer)
+		got := UnpackMarks(PackMarks(marks), len(marks))
+		if !slices.Equal(got, marks) {
+			t.Errorf("%s vs %s: %v round-tripped to %v", tc.guess, tc.answer, marks, got)
+		}
+	}
+
+	if got := PackMarks([]int{2, 1, 0, 0, 2}); got != 0b10_00_00_01_10 {
+		t.Errorf("PackMarks([2 1 0 0 2]) = %b, want letter 0 in the low bits", got)
+	}
+	if got := PackMarks([]int{2, 7, -1}); got != 2 {
+		t.Errorf("out-of-range marks packed as %b, want them as misses", got)
+	}
+}
@@ -0,0 +1,72 @@
+// apps/go-server/internal/words/pattern.go
+//
+// Allocation-free scoring for bulk workloads (cheat mode, solver par,
+// analysis) that score one guess against thousands of answers.
+//
+// Notes:
+//   - ScoreInto gives the same marks as Score but writes into a caller
+//     buffer, so a loop can reuse one slice.
+//   - PatternCode packs the marks straight into a uint16 using the PackMarks
+//     layout (2 bits per letter), so UnpackMarks(uint32(code), n) gives back
+//     Score(guess, answer) for any supported length. Codes are only
+//     comparable between words of the same length.
+
+package words
+
+// ScoreInto writes Score(guess, answer) into out[:len(answer)]. out must hold
+// at least len(answer) entries; anything past that is left untouched.
+func ScoreInto(guess, answer string, out []int) {
+	n := len(answer)
+	out = out[:n]
+	clear(out)
+	if len(guess) != n {
+		return
+	}
+
+	var left [256]int
+	for i := 0; i < n; i++ {
+		if guess[i] == answer[i] {
+			out[i] = 2
+		} else {
+			left[answer[i]]++
+		}
+	}
+	for i := 0; i < n; i++ {
+		if out[i] == 2 {
+			continue
+		}
+		if c := guess[i]; left[c] > 0 {
+			out[i] = 1
+			left[c]--
+		}
+	}
+}
+
+// PatternCode returns PackMarks(Score(guess, answer)) as a uint16 without
+// allocating. Only the first MaxLength letters fit; longer words lose the rest.
+func PatternCode(guess, answer string) uint16 {
+	n := len(answer)
+	if len(guess) != n {
+		return 0
+	}
+
+	var left [256]int
+	var code uint16
+	for i := 0; i < n; i++ {
+		if guess[i] == answer[i] {
+			code |= 2 << (2 * i)
+		} else {
+			left[answer[i]]++
+		}
+	}
+	for i := 0; i < n; i++ {
+		if guess[i] == answer[i] {
+			continue
+		}
+		if c := guess[i]; left[c] > 0 {
+			code |= 1 << (2 * i)
+			left[c]--
+		}
+	}
+	return code
+}
@@ -0,0 +1,71 @@
+package words
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestScoreInto(t *testing.T) {
+	for _, tc := range []struct {
+		guess, answer string
+		want          []int
+	}{
+		{"crane", "crane", []int{2, 2, 2, 2, 2}},
+		{"sassy", "asses", []int{1, 1, 2, 1, 0}}, // the answer has three s: one hit, two presents
+		{"geese", "eerie", []int{0, 2, 1, 0, 2}},
+		{"llama", "hello", []int{1, 1, 0, 0, 0}},
+		{"banana", "ananas", []int{0, 1, 1, 1, 1, 1}},
+		{"cat", "crane", []int{0, 0, 0, 0, 0}}, // length mismatch scores nothing
+	} {
+		out := []int{9, 9, 9, 9, 9, 9, 9, 9, 9}
+		ScoreInto(tc.guess, tc.answer, out)
+		if !slices.Equal(out[:len(tc.answer)], tc.want) {
+			t.Errorf("ScoreInto(%s, %s) = %v, want %v", tc.guess, tc.answer, out[:len(tc.answer)], tc.want)
+		}
+		if out[len(tc.answer)] != 9 {
+			t.Errorf("ScoreInto(%s, %s) wrote past len(answer): %v", tc.guess, tc.answer, out)
+		}
+	}
+}
+
+func TestPatternCodeRoundTrip(t *testing.T) {
+	list := Answers()
+	if len(list) > 300 {
+		list = list[:300] // every pair of a slice is plenty
+	}
+	buf := make([]int, MaxLength)
+	for _, g := range list {
+		for _, a := range list {
+			ScoreInto(g, a, buf)
+			code := PatternCode(g, a)
+			if got := UnpackMarks(uint32(code), len(a)); !slices.Equal(got, buf[:len(a)]) {
+				t.Fatalf("PatternCode(%s, %s) = %b unpacks to %v, want %v", g, a, code, got, buf[:len(a)])
+			}
+			if uint32(code) != PackMarks(Score(g, a)) {
+				t.Fatalf("PatternCode(%s, %s) = %b, want PackMarks(Score) %b", g, a, code, PackMarks(Score(g, a)))
+			}
+		}
+	}
+	if got := UnpackMarks(uint32(PatternCode("zzzzzzzz", "abczzzzh")), 8); !slices.Equal(got, []int{0, 0, 0, 2, 2, 2, 2, 0}) {
+		t.Errorf("8-letter code unpacks to %v", got)
+	}
+	if PatternCode("cat", "crane") != 0 {
+		t.Error("length mismatch gave a non-zero code")
+	}
+}
+
+func BenchmarkScore(b *testing.B) {
+	list := Answers()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Score("crane", list[i%len(list)])
+	}
+}
+
+func BenchmarkPatternCode(b *testing.B) {
+	list := Answers()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = PatternCode("crane", list[i%len(list)])
+	}
+}
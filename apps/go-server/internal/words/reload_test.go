@@ -0,0 +1,85 @@
+package words
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeList writes one word per line to dir/name and returns the path.
+func writeList(t *testing.T, dir, name string, list ...string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	var b []byte
+	for _, w := range list {
+		b = append(b, w+"\n"...)
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReloadPicksUpNewWord(t *testing.T) {
+	// Registered first so it runs after the env is restored.
+	t.Cleanup(func() { _, _ = Reload() })
+	dir := t.TempDir()
+	t.Setenv("WORDS_ANSWERS_FILE", writeList(t, dir, "answers.txt", "crane", "slate"))
+	allowed := writeList(t, dir, "allowed.txt", "crane", "slate", "adieu")
+	t.Setenv("WORDS_ALLOWED_FILE", allowed)
+
+	if _, err := Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if IsAllowed("zebra") {
+		t.Fatal("zebra allowed before it was added")
+	}
+
+	writeList(t, dir, "allowed.txt", "crane", "slate", "adieu", "zebra")
+	r, err := Reload()
+	if err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if !IsAllowed("zebra") {
+		t.Fatal("zebra not allowed after reload")
+	}
+	if r.Allowed != 4 || r.Answers != 2 {
+		t.Fatalf("report = %+v, want 2 answers and 4 allowed", r)
+	}
+}
+
+func TestReloadRebuildsTheme(t *testing.T) {
+	// Registered first so it runs after the env is restored.
+	t.Cleanup(func() { _, _ = Reload() })
+	dir := t.TempDir()
+	t.Setenv("WORDS_ANSWERS_FILE", writeList(t, dir, "answers.txt", "crane"))
+	t.Setenv("WORDS_ALLOWED_FILE", writeList(t, dir, "allowed.txt", "crane"))
+	t.Setenv("THEME_ANSWERS_FILE", writeList(t, dir, "theme.txt", "zebra"))
+	today := time.Now().UTC().Format("2006-01-02")
+	t.Setenv("THEME_START", today)
+	t.Setenv("THEME_END", today)
+
+	if _, err := Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got := themedClassicAnswers(5); len(got) != 0 {
+		t.Fatalf("themed classic = %v before zebra is allowed", got)
+	}
+
+	writeList(t, dir, "allowed.txt", "crane", "zebra")
+	if _, err := Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got := themedClassicAnswers(5); len(got) != 1 || got[0] != "zebra" {
+		t.Fatalf("themed classic = %v after reload, want [zebra]", got)
+	}
+
+	t.Setenv("THEME_END", "2000-01-01")
+	if _, err := Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got := themedClassicAnswers(5); got != nil {
+		t.Fatalf("themed classic = %v after the window moved, want nil", got)
+	}
+}
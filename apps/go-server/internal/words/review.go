@@ -0,0 +1,155 @@
+// apps/go-server/internal/words/review.go
+//
+// Post-game review: a letter grade per guess for how well it narrowed the
+// possible answers.
+//
+// For each guess, in order:
+//   - The candidates are the pool words still consistent with the earlier
+//     guesses' marks.
+//   - A guess's value is the information it is expected to give: log2 of the
+//     candidate count over the expected number left after it (a guess that
+//     wins on the spot leaves none).
+//   - The best available guess is the pool word with the most expected
+//     information; the grade is the guess's share of that.
+//
+// Expected rather than actual remaining counts are used, so a lucky guess
+// isn't graded above a sound one.
+
+package words
+
+import "math"
+
+// GuessReview is the review of one guess.
+type GuessReview struct {
+	Guess        string  `json:"guess"`
+	Candidates   int     `json:"candidates"`   // answers still possible before the guess
+	Remaining    int     `json:"remaining"`    // answers still possible after it
+	Expected     float64 `json:"expected"`     // expected answers left by the guess
+	Best         string  `json:"best"`         // pool word (or the guess) leaving the fewest expected
+	BestExpected float64 `json:"bestExpected"` // expected answers left by Best
+	Grade        string  `json:"grade"`        // "A" (near-optimal) … "F"
+}
+
+// ReviewGuesses grades each guess of a game against answer. pool is the
+// answer list the candidates (and the best guess) are drawn from; words of
+// another length are ignored, and answer counts as a candidate even if pool
+// lacks it.
+func ReviewGuesses(pool []string, answer string, guesses []string) []GuessReview {
+	n := len(answer)
+	var probes, cands []string
+	seen := false
+	for _, w := range pool {
+		if len(w) == n {
+			probes = append(probes, w)
+			seen = seen || w == answer
+		}
+	}
+	if !seen {
+		probes = append(probes, answer)
+	}
+	cands = append(cands, probes...)
+
+	buckets := make([]int, pow3(n))
+	out := make([]GuessReview, 0, len(guesses))
+	for _, g := range guesses {
+		rv := GuessReview{Guess: g, Candidates: len(cands), Best: g}
+		rv.Expected = expectedLeft(g, cands, buckets)
+		rv.BestExpected = rv.Expected
+		for _, p := range probes {
+			if e := expectedLeft(p, cands, buckets); e < rv.BestExpected {
+				rv.Best, rv.BestExpected = p, e
+			}
+		}
+		rv.Grade = grade(len(cands), rv.Expected, rv.BestExpected)
+
+		want := patternCode(g, answer)
+		kept := cands[:0:0]
+		for _, c := range cands {
+			if c != g && patternCode(g, c) == want {
+				kept = append(kept, c)
+			}
+		}
+		cands = kept
+		rv.Remaining = len(cands)
+		out = append(out, rv)
+	}
+	return out
+}
+
+// expectedLeft returns the expected number of candidates left unsolved after
+// guessing probe, with cands equally likely. buckets is scratch space of
+// pow3(len(probe)) entries.
+func expectedLeft(probe string, cands []string, buckets []int) float64 {
+	if len(cands) == 0 || len(probe) != len(cands[0]) {
+		return float64(len(cands))
+	}
+	clear(buckets)
+	for _, c := range cands {
+		buckets[patternCode(probe, c)]++
+	}
+	solved := len(buckets) - 1 // all hits
+	sum := 0
+	for code, k := range buckets {
+		if code != solved {
+			sum += k * k
+		}
+	}
+	return float64(sum) / float64(len(cands))
+}
+
+// grade maps a guess's share of the best available information to A–F.
+func grade(cands int, expected, best float64) string {
+	if expected <= best {
+		return "A"
+	}
+	if best <= 0 {
+		best = 0.5 // the best guess wins outright; treat it as halving once more
+	}
+	share := 0.0
+	if expected > 0 {
+		share = math.Log2(float64(cands)/expected) / math.Log2(float64(cands)/best)
+	}
+	switch {
+	case share >= 0.9:
+		return "A"
+	case share >= 0.75:
+		return "B"
+	case share >= 0.6:
+		return "C"
+	case share >= 0.4:
+		return "D"
+	}
+	return "F"
+}
+
+// patternCode is Score as a base-3 number (hit = 2), without allocating.
+func patternCode(guess, answer string) int {
+	var free [26]int
+	n := len(answer)
+	for i := 0; i < n; i++ {
+		if guess[i] != answer[i] {
+			free[answer[i]-'a']++
+		}
+	}
+	code := 0
+	for i := 0; i < n; i++ {
+		m := 0
+		if guess[i] == answer[i] {
+			m = 2
+		} else if c := guess[i] - 'a'; free[c] > 0 {
+			m = 1
+			free[c]--
+		}
+		code = code*3 + m
+	}
+	return code
+}
+
+// pow3 returns 3^n.
+func pow3(n int) int {
+	p := 1
+	for i := 0; i < n; i++ {
+		p *= 3
+	}
+	return p
+}
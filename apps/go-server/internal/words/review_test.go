@@ -0,0 +1,46 @@
+package words
+
+import "testing"
+
+func TestReviewGradesInformativeGuessHigher(t *testing.T) {
+	// The -atch trap: every candidate shares four letters, so only a guess
+	// probing the first letters narrows it down.
+	pool := []string{"batch", "catch", "hatch", "latch", "match", "patch", "watch"}
+	great := ReviewGuesses(pool, "watch", []string{"bplmw"})[0]
+	wasteful := ReviewGuesses(pool, "watch", []string{"zzzzz"})[0]
+
+	if great.Grade != "A" || wasteful.Grade != "F" {
+		t.Fatalf("grades: bplmw %q, zzzzz %q; want A and F", great.Grade, wasteful.Grade)
+	}
+	if great.Candidates != 7 || great.Remaining != 1 || great.Expected >= wasteful.Expected {
+		t.Fatalf("bplmw review = %+v", great)
+	}
+	if wasteful.Remaining != 7 || wasteful.Expected != 7 {
+		t.Fatalf("zzzzz review = %+v, want nothing ruled out", wasteful)
+	}
+
+	// Candidates carry over between guesses; a guess left with one candidate
+	// that names it is optimal.
+	seq := ReviewGuesses(pool, "watch", []string{"zzzzz", "bplmw", "watch"})
+	if len(seq) != 3 || seq[1].Candidates != 7 || seq[2].Candidates != 1 || seq[2].Remaining != 0 || seq[2].Grade != "A" {
+		t.Fatalf("sequence = %+v", seq)
+	}
+}
+
+func TestGradeBands(t *testing.T) {
+	for _, tc := range []struct {
+		expected, best float64
+		want           string
+	}{
+		{4, 4, "A"},
+		{4, 8, "A"},    // better than the best probe
+		{64, 1, "D"},   // 4 of 10 bits
+		{128, 1, "F"},  // 3 of 10
+		{1024, 1, "F"}, // nothing learned
+		{8, 1, "C"},    // 7 of 10
+	} {
+		if got := grade(1024, tc.expected, tc.best); got != tc.want {
+			t.Errorf("grade(1024, %v, %v) = %q, want %q", tc.expected, tc.best, got, tc.want)
+		}
+	}
+}
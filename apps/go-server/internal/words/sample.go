@@ -0,0 +1,36 @@
+// apps/go-server/internal/words/sample.go
+//
+// Random samples of the loaded lists, for checking which lists a deployment
+// actually loaded (GET /debug/words?sample=N).
+
+package words
+
+import "math/rand/v2"
+
+// Sample returns up to n random default-length answers and up to n random
+// default-length allowed words (answers included), each without repeats.
+// The allowed sample is nil while the allowed list is a Bloom filter, which
+// can't be enumerated.
+func Sample(n int) (answers, allowed []string) {
+	l := current()
+	answers = pick(l.answersByLen[DefaultLength], n)
+	if l.allowedBloom == nil {
+		set := l.allowedSet[DefaultLength]
+		all := make([]string, 0, len(set))
+		for w := range set {
+			all = append(all, w)
+		}
+		allowed = pick(all, n)
+	}
+	return answers, allowed
+}
+
+// pick returns up to n distinct random entries of list; list is not modified.
+func pick(list []string, n int) []string {
+	n = max(min(n, len(list)), 0)
+	out := make([]string, 0, n)
+	for _, i := range rand.Perm(len(list))[:n] {
+		out = append(out, list[i])
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+package words
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSample(t *testing.T) {
+	// Registered first so it runs after the env is restored.
+	t.Cleanup(func() { _, _ = Reload() })
+	dir := t.TempDir()
+	t.Setenv("WORDS_ANSWERS_FILE", writeList(t, dir, "answers.txt", "crane", "slate", "trace"))
+	t.Setenv("WORDS_ALLOWED_FILE", writeList(t, dir, "allowed.txt", "crane", "slate", "trace", "adieu", "zebra", "planet"))
+	if _, err := Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	fives := []string{"crane", "slate", "trace", "adieu", "zebra"}
+
+	for n, want := range map[int][2]int{0: {0, 0}, -1: {0, 0}, 2: {2, 2}, 10: {3, 5}} {
+		answers, allowed := Sample(n)
+		if len(answers) != want[0] || len(allowed) != want[1] {
+			t.Fatalf("Sample(%d) = %v, %v; want %d and %d words", n, answers, allowed, want[0], want[1])
+		}
+		for _, list := range [][]string{answers, allowed} {
+			seen := map[string]bool{}
+			for _, w := range list {
+				if seen[w] || !slices.Contains(fives, w) {
+					t.Fatalf("Sample(%d): %q repeated or not a loaded 5-letter word (%v)", n, w, list)
+				}
+				seen[w] = true
+			}
+		}
+		for _, w := range answers {
+			if !slices.Contains(fives[:3], w) {
+				t.Fatalf("Sample(%d): answer sample has %q", n, w)
+			}
+		}
+	}
+
+	t.Setenv("WORDS_ALLOWED_BLOOM", "true")
+	if _, err := Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if answers, allowed := Sample(2); len(answers) != 2 || allowed != nil {
+		t.Fatalf("Bloom list: Sample(2) = %v, %v; want 2 answers and no allowed sample", answers, allowed)
+	}
+}
@@ -0,0 +1,29 @@
+// apps/go-server/internal/words/share.go
+//
+// Emoji rendering for shareable result grids.
+// Converts integer marks (as produced by Score) into the familiar
+// 🟩 / 🟨 / ⬛ rows without ever including the letters themselves.
+
+package words
+
+import "strings"
+
+// EmojiRow renders one scored guess as a row of emoji squares:
+//
+//	2 (hit)     → 🟩
+//	1 (present) → 🟨
+//	0 (miss)    → ⬛
+func EmojiRow(marks []int) string {
+	var b strings.Builder
+	for _, m := range marks {
+		switch m {
+		case 2:
+			b.WriteString("🟩")
+		case 1:
+			b.WriteString("🟨")
+		default:
+			b.WriteString("⬛")
+		}
+	}
+	return b.String()
+}
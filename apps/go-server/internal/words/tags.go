@@ -0,0 +1,104 @@
+// apps/go-server/internal/words/tags.go
+//
+// Optional category/theme tags for answers (e.g. "bird"), shown on the end
+// screen once a game is over. Tags never affect play, and callers must only
+// send them for finished games so they can't be used as hints.
+//
+// File format (WORDS_TAGS_FILE), one answer per line:
+//   robin: bird, garden
+//   # comments and blank lines are ignored
+// Words are lowercased; tags are trimmed and kept in file order. A word listed
+// twice keeps the tags of its last line; malformed lines are logged and skipped.
+// The file is read on first use and again on each Reload.
+//
+// Environment variables:
+//   WORDS_TAGS_FILE=/path/to/tags.txt   (unset = no tags)
+
+package words
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	tagsOnce sync.Once
+	tagsMu   sync.RWMutex
+	tagsBy   map[string][]string // word → tags
+)
+
+// reloadTags re-reads WORDS_TAGS_FILE and swaps the result in.
+func reloadTags() {
+	tagsOnce.Do(func() {}) // a later first Tags call mustn't load over this
+	setTags(loadTags())
+}
+
+// setTags installs m under tagsMu.
+func setTags(m map[string][]string) {
+	tagsMu.Lock()
+	tagsBy = m
+	tagsMu.Unlock()
+}
+
+// loadTags reads WORDS_TAGS_FILE; nil when unset or unreadable.
+func loadTags() map[string][]string {
+	path := os.Getenv("WORDS_TAGS_FILE")
+	if path == "" {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		log.Warn().Err(err).Str("file", path).Msg("tags: load failed; answers have no tags")
+		return nil
+	}
+	defer f.Close()
+
+	tagsBy := map[string][]string{}
+	skipped := 0
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word, list, ok := strings.Cut(line, ":")
+		word = strings.ToLower(strings.TrimSpace(word))
+		if !ok || !validWord(word) {
+			skipped++
+			continue
+		}
+		var tags []string
+		for _, t := range strings.Split(list, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				tags = append(tags, t)
+			}
+		}
+		if len(tags) == 0 {
+			skipped++
+			continue
+		}
+		tagsBy[word] = tags
+	}
+	if err := sc.Err(); err != nil {
+		log.Warn().Err(err).Str("file", path).Msg("tags: read failed; using the tags read so far")
+	}
+	log.Info().Int("words", len(tagsBy)).Int("skipped", skipped).Str("file", path).Msg("tags: loaded")
+	return tagsBy
+}
+
+// Tags returns the tags for word (case-insensitive), or nil if it has none.
+// The result is a copy the caller may modify.
+func Tags(word string) []string {
+	tagsOnce.Do(func() { setTags(loadTags()) })
+	tagsMu.RLock()
+	tags := tagsBy[strings.ToLower(word)]
+	tagsMu.RUnlock()
+	if len(tags) == 0 {
+		return nil
+	}
+	return append([]string(nil), tags...)
+}
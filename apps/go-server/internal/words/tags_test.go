@@ -0,0 +1,49 @@
+package words
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTags(t *testing.T) {
+	// Registered first so it runs after the env is restored.
+	t.Cleanup(func() { _, _ = Reload() })
+	t.Setenv("WORDS_TAGS_FILE", writeList(t, t.TempDir(), "tags.txt",
+		"# answers → tags",
+		"robin: bird, garden",
+		"",
+		"Crane: bird,  machine ,",
+		"crane: bird",
+		"no colon here",
+		"toolongword: nope",
+		"slate:",
+	))
+	if _, err := Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	for word, want := range map[string][]string{
+		"robin": {"bird", "garden"},
+		"ROBIN": {"bird", "garden"},
+		"crane": {"bird"}, // last line wins
+		"slate": nil,      // no tags listed
+		"zebra": nil,
+	} {
+		if got := Tags(word); !reflect.DeepEqual(got, want) {
+			t.Errorf("Tags(%q) = %q, want %q", word, got, want)
+		}
+	}
+
+	Tags("robin")[0] = "changed"
+	if got := Tags("robin"); got[0] != "bird" {
+		t.Fatalf("modifying the result changed the stored tags: %q", got)
+	}
+
+	t.Setenv("WORDS_TAGS_FILE", "")
+	if _, err := Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got := Tags("robin"); got != nil {
+		t.Fatalf("Tags after unsetting the file = %q, want nil", got)
+	}
+}
@@ -0,0 +1,107 @@
+// apps/go-server/internal/words/theme.go
+//
+// Themed answer pools for time-boxed events (e.g. "animals week").
+//
+// When THEME_ANSWERS_FILE is set, its words replace the answer pool for both
+// the daily (DailyAnswers) and classic games (RandomAnswer) on dates within
+// [THEME_START, THEME_END] (inclusive, "YYYY-MM-DD", UTC). Outside the range,
+// or if the file yields no usable words, the normal pools are used.
+//
+// Validation:
+//   • Themed words are filtered per mode so every themed answer is a legal
+//     guess in that mode (classic: IsAllowed; daily: Allowed()).
+//   • Dropped words are logged once per load.
+//   • The list and window are re-read with the word lists (Init, Reload),
+//     so the classic filter always matches the lists in use.
+//
+// Environment variables:
+//   THEME_ANSWERS_FILE=/path/to/animals.txt
+//   THEME_START=2025-09-01
+//   THEME_END=2025-09-07
+
+package words
+
+import (
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// theme is one load of the themed pools; like lists, never modified once built.
+type theme struct {
+	start   string   // first active date key (inclusive)
+	end     string   // last active date key (inclusive)
+	classic []string // themed answers valid for classic games
+	daily   []string // themed answers valid for the daily
+}
+
+// loadTheme reads and validates the themed list against l, the generation
+// being built. Returns nil when no theme is configured or it fails to load.
+func loadTheme(l *lists) *theme {
+	path := os.Getenv("THEME_ANSWERS_FILE")
+	if path == "" {
+		return nil
+	}
+	list, err := readWordFile(path, nil)
+	if err != nil {
+		log.Warn().Err(err).Str("file", path).Msg("theme: load failed; using normal answers")
+		return nil
+	}
+	th := &theme{start: os.Getenv("THEME_START"), end: os.Getenv("THEME_END")}
+
+	dailySet := Allowed()
+	for _, w := range list {
+		if l.isAllowed(w, len(w)) {
+			th.classic = append(th.classic, w)
+		}
+		if _, ok := dailySet[w]; ok {
+			th.daily = append(th.daily, w)
+		}
+	}
+	log.Info().
+		Int("words", len(list)).
+		Int("classic", len(th.classic)).
+		Int("daily", len(th.daily)).
+		Str("start", th.start).
+		Str("end", th.end).
+		Msg("theme: loaded")
+	return th
+}
+
+// activeTheme returns the installed theme if it applies on t, else nil.
+func activeTheme(t time.Time) *theme {
+	th := current().theme
+	if th == nil {
+		return nil
+	}
+	dk := t.UTC().Format("2006-01-02")
+	if th.start == "" || th.end == "" || dk < th.start || dk > th.end {
+		return nil
+	}
+	return th
+}
+
+// DailyAnswers returns the daily answer pool in effect on t:
+// the themed list within the event window, otherwise Answers().
+func DailyAnswers(t time.Time) []string {
+	if th := activeTheme(t); th != nil && len(th.daily) > 0 {
+		return th.daily
+	}
+	return Answers()
+}
+
+// themedClassicAnswers returns the classic themed pool of length n if active now, else nil.
+func themedClassicAnswers(n int) []string {
+	th := activeTheme(time.Now())
+	if th == nil {
+		return nil
+	}
+	var out []string
+	for _, w := range th.classic {
+		if len(w) == n {
+			out = append(out, w)
+		}
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package words
+
+import (
+	"testing"
+	"time"
+)
+
+func TestThemeWindow(t *testing.T) {
+	// Registered first so it runs after the env is restored.
+	t.Cleanup(func() { _, _ = Reload() })
+	dir := t.TempDir()
+	themed := Answers()[:3]
+	t.Setenv("WORDS_ANSWERS_FILE", writeList(t, dir, "answers.txt", "crane", "slate"))
+	t.Setenv("WORDS_ALLOWED_FILE", writeList(t, dir, "allowed.txt", append([]string{"crane", "slate"}, themed...)...))
+	t.Setenv("THEME_ANSWERS_FILE", writeList(t, dir, "theme.txt", append([]string{"qzxvj"}, themed...)...))
+	now := time.Now().UTC()
+	t.Setenv("THEME_START", now.AddDate(0, 0, -1).Format("2006-01-02"))
+	t.Setenv("THEME_END", now.AddDate(0, 0, 1).Format("2006-01-02"))
+	if _, err := Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	isThemed := func(w string) bool {
+		for _, th := range themed {
+			if w == th {
+				return true
+			}
+		}
+		return false
+	}
+
+	if got := DailyAnswers(now); len(got) != len(themed) || !isThemed(got[0]) {
+		t.Fatalf("DailyAnswers inside the window = %v, want %v", got, themed)
+	}
+	for i := 0; i < 50; i++ {
+		if w := RandomAnswer(); !isThemed(w) {
+			t.Fatalf("RandomAnswer inside the window = %q, want one of %v", w, themed)
+		}
+	}
+
+	outside := now.AddDate(0, 0, 5)
+	if got := DailyAnswers(outside); len(got) != len(Answers()) {
+		t.Fatalf("DailyAnswers outside the window has %d words, want the full %d", len(got), len(Answers()))
+	}
+
+	t.Setenv("THEME_END", now.AddDate(0, 0, -1).Format("2006-01-02"))
+	if _, err := Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	for i := 0; i < 50; i++ {
+		if w := RandomAnswer(); w != "crane" && w != "slate" {
+			t.Fatalf("RandomAnswer outside the window = %q, want crane or slate", w)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// apps/go-server/internal/words/weighted.go
+//
+// Weighted answer lists for classic games.
+//
+// When WORDS_ANSWERS_WEIGHTED is set, the classic answer pool is built from
+// several files, each with an integer weight, e.g.
+//
+//   WORDS_ANSWERS_WEIGHTED=/data/common.txt:9,/data/longtail.txt:1
+//
+// RandomAnswerLen first picks a list with probability weight/Σweights (among
+// lists that have answers of the requested length), then a word uniformly
+// from it. A word present in several lists is correspondingly more likely.
+//
+// Notes:
+//   • The merged lists replace WORDS_ANSWERS_FILE as the answer pool; without
+//     WORDS_ALLOWED_FILE they also serve as the allowed list.
+//   • The daily is unaffected: it maps dates onto its own fixed answer list
+//     (DailyAnswers), which stays a single pinned snapshot.
+//   • A missing weight defaults to 1; weights ≤ 0 drop the list.
+
+package words
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// weightedList is one answer file with its selection weight.
+type weightedList struct {
+	words  []string
+	weight int64
+}
+
+// parseWeightedSpec splits "path:weight,path:weight" into entries.
+// The weight is taken after the last ':' so paths may contain colons.
+func parseWeightedSpec(spec string) ([]string, []int64, error) {
+	var paths []string
+	var weights []int64
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		path, weight := part, int64(1)
+		if i := strings.LastIndex(part, ":"); i > 0 {
+			w, err := strconv.ParseInt(part[i+1:], 10, 64)
+			if err != nil {
+				return nil, nil, fmt.Errorf("words: bad weight in %q", part)
+			}
+			path, weight = part[:i], w
+		}
+		if weight <= 0 {
+			continue
+		}
+		paths = append(paths, path)
+		weights = append(weights, weight)
+	}
+	return paths, weights, nil
+}
+
+// loadWeighted reads every list in spec, tallying lines in lc. It returns the
+// merged word list and the per-length pools; words are not yet filtered
+// against the answer set.
+func loadWeighted(spec string, lc *lineCount) ([]string, map[int][]weightedList, error) {
+	paths, weights, err := parseWeightedSpec(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	var merged []string
+	pools := make(map[int][]weightedList)
+	for i, p := range paths {
+		list, err := readWordFile(p, lc)
+		if err != nil {
+			return nil, nil, err
+		}
+		merged = append(merged, list...)
+		for n, words := range byLength(list) {
+			pools[n] = append(pools[n], weightedList{words: words, weight: weights[i]})
+		}
+	}
+	return merged, pools, nil
+}
+
+// filterWeighted drops words that are not answers (e.g. removed by
+// enforceAnswersAllowed) and lists left empty.
+func filterWeighted(pools map[int][]weightedList, answers map[int]map[string]struct{}) map[int][]weightedList {
+	out := make(map[int][]weightedList, len(pools))
+	for n, lists := range pools {
+		for _, l := range lists {
+			var kept []string
+			for _, w := range l.words {
+				if _, ok := answers[n][w]; ok {
+					kept = append(kept, w)
+				}
+			}
+			if len(kept) > 0 {
+				out[n] = append(out[n], weightedList{words: kept, weight: l.weight})
+			}
+		}
+	}
+	return out
+}
+
+// pickWeighted chooses a list in proportion to its weight, then a word from
+// it uniformly. randN must return a uniform value in [0, n); "" if lists is empty.
+func pickWeighted(lists []weightedList, randN func(n int64) int64) string {
+	var total int64
+	for _, l := range lists {
+		if len(l.words) > 0 {
+			total += l.weight
+		}
+	}
+	if total <= 0 {
+		return ""
+	}
+	r := randN(total)
+	for _, l := range lists {
+		if len(l.words) == 0 {
+			continue
+		}
+		if r < l.weight {
+			return l.words[randN(int64(len(l.words)))]
+		}
+		r -= l.weight
+	}
+	return ""
+}
+
+// cryptoRandN returns a crypto-random value in [0, n).
+func cryptoRandN(n int64) int64 {
+	v, _ := rand.Int(rand.Reader, big.NewInt(n))
+	return v.Int64()
+}
@@ -0,0 +1,81 @@
+package words
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseWeightedSpec(t *testing.T) {
+	paths, weights, err := parseWeightedSpec(" /a.txt:9, c:/b.txt:2 ,/c.txt, /d.txt:0,,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(paths, []string{"/a.txt", "c:/b.txt", "/c.txt"}) || !slices.Equal(weights, []int64{9, 2, 1}) {
+		t.Fatalf("parsed %v %v", paths, weights)
+	}
+	if _, _, err := parseWeightedSpec("/a.txt:lots"); err == nil {
+		t.Fatal("non-numeric weight accepted")
+	}
+}
+
+func TestPickWeightedDistribution(t *testing.T) {
+	lists := []weightedList{
+		{words: []string{"crane", "slate"}, weight: 9},
+		{words: []string{"zebra"}, weight: 1},
+		{words: nil, weight: 50}, // empty lists never win
+	}
+	const draws = 20000
+	counts := map[string]int{}
+	for i := 0; i < draws; i++ {
+		counts[pickWeighted(lists, cryptoRandN)]++
+	}
+	if got := float64(counts["zebra"]) / draws; math.Abs(got-0.1) > 0.02 {
+		t.Fatalf("long-tail share %.3f, want about 0.10 (%v)", got, counts)
+	}
+	if got := float64(counts["crane"]) / draws; math.Abs(got-0.45) > 0.03 {
+		t.Fatalf("crane share %.3f, want about 0.45 (%v)", got, counts)
+	}
+	if counts[""] != 0 {
+		t.Fatalf("%d empty picks", counts[""])
+	}
+	if w := pickWeighted([]weightedList{{weight: 3}}, cryptoRandN); w != "" {
+		t.Fatalf("pick from empty lists = %q", w)
+	}
+}
+
+func TestWeightedAnswerLists(t *testing.T) {
+	// Registered first so it runs after the env is restored.
+	t.Cleanup(func() { _, _ = Reload() })
+	dir := t.TempDir()
+	common := writeList(t, dir, "common.txt", "crane", "slate", "adieu")
+	rare := writeList(t, dir, "rare.txt", "zebra")
+	t.Setenv("WORDS_ANSWERS_WEIGHTED", common+":3,"+rare+":1")
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	daily := slices.Clone(DailyAnswers(day))
+	if _, err := Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	const draws = 8000
+	rareN := 0
+	for i := 0; i < draws; i++ {
+		switch w := RandomAnswerLen(5); w {
+		case "zebra":
+			rareN++
+		case "crane", "slate", "adieu":
+		default:
+			t.Fatalf("RandomAnswerLen = %q, not from the weighted lists", w)
+		}
+	}
+	if got := float64(rareN) / draws; math.Abs(got-0.25) > 0.03 {
+		t.Fatalf("rare list share %.3f, want about 0.25", got)
+	}
+	if !IsAllowed("zebra") || !IsAllowed("adieu") {
+		t.Fatal("weighted lists not used as the allowed list")
+	}
+	if !slices.Equal(DailyAnswers(day), daily) {
+		t.Fatal("weighted lists changed the daily answers")
+	}
+}
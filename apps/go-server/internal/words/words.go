@@ -4,12 +4,16 @@
 //
 // Responsibilities:
 //   - Load answer and allowed guess lists from environment-provided files or fall back to embedded defaults.
-//   - Maintain sets for quick lookups (answers only, answers∪guesses).
+//   - Maintain per-length sets for quick lookups (answers only, answers∪guesses).
 //   - Supply utility functions like RandomAnswer, IsAllowed, IsAnswer, and Stats.
+//   - Reload the configured lists at runtime (Reload, POST /admin/words/reload).
+//   - Swap in a themed answer pool during configured event windows (theme.go).
 //
 // Word Lists:
-//   - "answers": canonical solutions (exactly 5 lowercase letters).
-//   - "allowed": valid guesses (always includes answers).
+//   - "answers": canonical solutions (lowercase, MinLength–MaxLength letters).
+//   - "allowed": valid guesses (always includes answers of the same length).
+//     Init enforces this invariant per length; see enforceAnswersAllowed.
+//   - Both are keyed by word length; 5 letters (DefaultLength) is the default.
 //
 // Initialization behavior (Init):
 //   1. If WORDS_ANSWERS_FILE and WORDS_ALLOWED_FILE are both set,
@@ -23,11 +27,38 @@
 // Environment variables:
 //   WORDS_ANSWERS_FILE=/path/to/answers.txt
 //   WORDS_ALLOWED_FILE=/path/to/allowed.txt
+//   WORDS_SEED_ALLOWED=true   answers missing from the allowed list are added
+//                             to it (default); false drops them from answers
+//   WORDS_ALLOWED_BLOOM=true  store allowed guesses in Bloom filters instead of
+//                             exact sets (less memory, rare false positives;
+//                             see bloom.go)
+//   WORDS_ALLOWED_BLOOM_FP    Bloom false-positive rate (default 0.001)
+//   WORDS_ANSWERS_WEIGHTED=/a.txt:9,/b.txt:1
+//                             classic answers drawn from several lists in
+//                             proportion to their weights (see weighted.go)
+//   WORDS_MAX_REJECTED_PCT=5  warn when more than this share of non-blank
+//                             lines is rejected as malformed (see check)
+//   WORDS_STRICT=true         fail the load instead of warning, and also
+//                             when any answer is missing from the allowed
+//                             list (so the allowed file must list them all)
 //
 // Constraints:
-//   • Words must be 5 alphabetic letters (a–z).
+//   • Words must be MinLength–MaxLength alphabetic letters (a–z); other
+//     non-blank lines are dropped and counted (Report.Rejected).
 //   • Lists are normalized to lowercase.
-//   • Initialization is run once (sync.Once).
+//   • Initialization is run once (sync.Once); Reload re-reads the same
+//     sources later.
+//
+// Concurrency:
+//   • Each load builds a complete, immutable *lists; Init and Reload install
+//     it under listsMu and readers take the read lock to fetch the current
+//     one (current), so a reload never shows a half-built list.
+//   • Slices handed out (AnswersLen) belong to the generation they came from
+//     and stay valid after a reload.
+//   • The themed pools are rebuilt with each generation, so a reload also
+//     re-reads THEME_ANSWERS_FILE and the window. Reload re-reads the answer
+//     tags too (tags.go).
+//   • The daily lists (daily_exports.go) are embedded and not reloadable.
 
 package words
 
@@ -36,10 +67,15 @@ import (
 	"crypto/rand"
 	_ "embed"
 	"errors"
+	"fmt"
 	"math/big"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+
+	"github.com/rs/zerolog/log"
 )
 
 // --- embedded tiny defaults (ensures server runs even if no files configured) ---
@@ -50,77 +86,257 @@ var embeddedAnswers string
 //go:embed default_small_allowed.txt
 var embeddedAllowed string
 
+// Supported word lengths. DefaultLength keeps the classic 5-letter behavior.
+const (
+	DefaultLength = 5
+	MinLength     = 4
+	MaxLength     = 8
+)
+
+// lists is one loaded generation of the word lists. It is never modified
+// once installed.
+type lists struct {
+	answersByLen  map[int][]string            // canonical answers, keyed by length
+	allowedSet    map[int]map[string]struct{} // answers ∪ guesses, keyed by length
+	answersSet    map[int]map[string]struct{} // answers only, keyed by length
+	allowedBloom  map[int]*bloom              // replaces allowedSet with WORDS_ALLOWED_BLOOM=true
+	weightedByLen map[int][]weightedList      // weighted pools (nil unless WORDS_ANSWERS_WEIGHTED)
+	theme         *theme                      // themed pools (nil unless THEME_ANSWERS_FILE; see theme.go)
+	gen           uint64                      // install count; see Generation
+
+	report Report // what the load that built this generation saw
+}
+
+// Report summarizes one load of the word lists (Init, Reload).
+type Report struct {
+	Answers  int `json:"answers"`  // answers loaded, all lengths
+	Allowed  int `json:"allowed"`  // allowed guesses loaded (answers included), all lengths
+	Lines    int `json:"lines"`    // non-blank lines read from the answer/allowed sources
+	Rejected int `json:"rejected"` // of those, lines dropped as malformed (bad length or not a–z)
+	Missing  int `json:"missing"`  // answers not in the allowed list (added or dropped per WORDS_SEED_ALLOWED)
+}
+
 var (
 	initOnce   sync.Once
-	answers    []string           // canonical answers
-	allowedSet map[string]struct{} // answers ∪ guesses
-	answersSet map[string]struct{} // answers only
 	initialErr error
+
+	listsMu sync.RWMutex // guards cur
+	cur     = &lists{}   // installed generation (empty until Init)
 )
 
-// Init loads word lists exactly once.
-// Returns an error if the answers list ends up empty.
-func Init() error {
+// current returns the installed lists under the read lock.
+func current() *lists {
+	listsMu.RLock()
+	defer listsMu.RUnlock()
+	return cur
+}
+
+// install swaps in a new generation.
+func install(l *lists) {
+	listsMu.Lock()
+	l.gen = cur.gen + 1
+	cur = l
+	listsMu.Unlock()
+}
+
+// Generation identifies the installed lists; it changes with every Init and
+// successful Reload, so callers can tell when derived data is stale.
+func Generation() uint64 {
+	return current().gen
+}
+
+// Init loads word lists exactly once and reports what was loaded.
+// Returns an error if the answers list ends up empty or, with WORDS_STRICT,
+// if the lists fail check.
+func Init() (Report, error) {
 	initOnce.Do(func() {
-		var ansList, allowList []string
-
-		answersPath := os.Getenv("WORDS_ANSWERS_FILE")
-		allowedPath := os.Getenv("WORDS_ALLOWED_FILE")
-
-		switch {
-		// Case 1: both lists provided
-		case answersPath != "" && allowedPath != "":
-			var err error
-			ansList, err = readWordFile(answersPath)
-			if err != nil {
-				initialErr = err
-				return
-			}
-			allowList, err = readWordFile(allowedPath)
-			if err != nil {
-				initialErr = err
-				return
-			}
+		l, err := load()
+		if l != nil {
+			install(l)
+		}
+		initialErr = err
+	})
+	return current().report, initialErr
+}
 
-		// Case 2: only allowed file provided → use for both
-		case answersPath == "" && allowedPath != "":
-			var err error
-			allowList, err = readWordFile(allowedPath)
-			if err != nil {
-				initialErr = err
-				return
-			}
-			ansList = allowList
-
-		// Case 3: fallback to embedded defaults
-		default:
-			ansList = normalizeLines(embeddedAnswers)
-			if embeddedAllowed != "" {
-				allowList = normalizeLines(embeddedAllowed)
-			} else {
-				allowList = ansList
-			}
+// Reload re-reads the configured word lists (files, weighted lists, or the
+// embedded defaults, as in Init) and swaps them in. On any error, including
+// an empty answer list or a failed strict check, the current lists stay in
+// place. The answer tags (WORDS_TAGS_FILE) are re-read as well.
+func Reload() (Report, error) {
+	l, err := load()
+	if err != nil {
+		return Report{}, err
+	}
+	install(l)
+	reloadTags()
+	r := l.report
+	log.Info().Int("answers", r.Answers).Int("allowed", r.Allowed).Int("rejected", r.Rejected).
+		Int("missing", r.Missing).Msg("words: reloaded")
+	return r, nil
+}
+
+// load builds a generation from the environment's sources. A read error or
+// a failed strict check returns nil lists; an empty answer list returns the
+// lists and an error.
+func load() (*lists, error) {
+	var ansList, allowList []string
+	var lc lineCount
+
+	answersPath := os.Getenv("WORDS_ANSWERS_FILE")
+	allowedPath := os.Getenv("WORDS_ALLOWED_FILE")
+
+	switch {
+	// Case 1: both lists provided
+	case answersPath != "" && allowedPath != "":
+		var err error
+		ansList, err = readWordFile(answersPath, &lc)
+		if err != nil {
+			return nil, err
+		}
+		allowList, err = readWordFile(allowedPath, &lc)
+		if err != nil {
+			return nil, err
 		}
 
-		answers = ansList
-		answersSet = toSet(ansList)
+	// Case 2: only allowed file provided → use for both
+	case answersPath == "" && allowedPath != "":
+		var err error
+		allowList, err = readWordFile(allowedPath, &lc)
+		if err != nil {
+			return nil, err
+		}
+		ansList = allowList
 
-		// Ensure all answers are also marked as allowed
-		allowedSet = toSet(append([]string{}, ansList...))
-		for _, w := range allowList {
-			allowedSet[w] = struct{}{}
+	// Case 3: fallback to embedded defaults
+	default:
+		ansList = normalizeLines(embeddedAnswers, &lc)
+		if embeddedAllowed != "" {
+			allowList = normalizeLines(embeddedAllowed, &lc)
+		} else {
+			allowList = ansList
 		}
+	}
 
-		if len(answers) == 0 {
-			initialErr = errors.New("words: answers list is empty")
+	// Weighted lists replace the answers (and the allowed list, if no file).
+	var weighted map[int][]weightedList
+	if spec := os.Getenv("WORDS_ANSWERS_WEIGHTED"); spec != "" {
+		merged, pools, err := loadWeighted(spec, &lc)
+		if err != nil {
+			return nil, err
 		}
-	})
-	return initialErr
+		ansList, weighted = merged, pools
+		if allowedPath == "" {
+			allowList = merged
+		}
+	}
+
+	l := &lists{
+		answersByLen: byLength(ansList),
+		allowedSet:   make(map[int]map[string]struct{}),
+	}
+	for n, list := range byLength(allowList) {
+		l.allowedSet[n] = toSet(list)
+	}
+	missing := l.enforceAnswersAllowed(os.Getenv("WORDS_SEED_ALLOWED") != "false")
+
+	l.answersSet = make(map[int]map[string]struct{}, len(l.answersByLen))
+	for n, list := range l.answersByLen {
+		l.answersSet[n] = toSet(list)
+	}
+	if weighted != nil {
+		l.weightedByLen = filterWeighted(weighted, l.answersSet)
+	}
+	l.useAllowedBloom()
+	l.theme = loadTheme(l)
+
+	l.report = Report{Lines: lc.lines, Rejected: lc.rejected, Missing: missing}
+	l.report.Answers, l.report.Allowed = l.counts()
+	if err := check(l.report); err != nil {
+		return nil, err
+	}
+	if len(l.answersByLen[DefaultLength]) == 0 {
+		return l, errors.New("words: answers list is empty")
+	}
+	return l, nil
+}
+
+// check applies the integrity limits to a load: no more than
+// WORDS_MAX_REJECTED_PCT (default 5) percent of lines rejected, and, under
+// WORDS_STRICT=true, no answers missing from the allowed list. Without
+// WORDS_STRICT a breach is only logged (missing answers already are, by
+// enforceAnswersAllowed) and nil is returned.
+func check(r Report) error {
+	strict := os.Getenv("WORDS_STRICT") == "true"
+	maxPct := 5.0
+	if v, err := strconv.ParseFloat(os.Getenv("WORDS_MAX_REJECTED_PCT"), 64); err == nil {
+		maxPct = v
+	}
+	if r.Lines > 0 {
+		if pct := 100 * float64(r.Rejected) / float64(r.Lines); pct > maxPct {
+			if strict {
+				return fmt.Errorf("words: %d of %d lines rejected (%.1f%%, limit %g%%)", r.Rejected, r.Lines, pct, maxPct)
+			}
+			log.Warn().Int("rejected", r.Rejected).Int("lines", r.Lines).Float64("limit_pct", maxPct).
+				Msg("words: many malformed lines rejected")
+		}
+	}
+	if strict && r.Missing > 0 {
+		return fmt.Errorf("words: %d answers missing from the allowed list", r.Missing)
+	}
+	return nil
+}
+
+// enforceAnswersAllowed makes every answer a legal guess in its own length's
+// game. Answers missing from the allowed set are either added to it (seed=true)
+// or removed from the answer pool (seed=false); each affected length is logged.
+// Returns how many answers were missing. Must run before answersSet is built.
+func (l *lists) enforceAnswersAllowed(seed bool) int {
+	total := 0
+	lengths := make([]int, 0, len(l.answersByLen))
+	for n := range l.answersByLen {
+		lengths = append(lengths, n)
+	}
+	sort.Ints(lengths)
+
+	for _, n := range lengths {
+		if l.allowedSet[n] == nil {
+			l.allowedSet[n] = make(map[string]struct{})
+		}
+		kept := l.answersByLen[n][:0]
+		var missing []string
+		for _, w := range l.answersByLen[n] {
+			if _, ok := l.allowedSet[n][w]; !ok {
+				missing = append(missing, w)
+				if !seed {
+					continue
+				}
+				l.allowedSet[n][w] = struct{}{}
+			}
+			kept = append(kept, w)
+		}
+		l.answersByLen[n] = kept
+		total += len(missing)
+		if len(missing) == 0 {
+			continue
+		}
+		// Seeding is the normal case (allowed lists usually hold guesses only);
+		// dropping answers changes the pool, so that one is worth a warning.
+		ev, action := log.Debug(), "added to allowed"
+		if !seed {
+			ev, action = log.Warn(), "dropped from answers"
+		}
+		ev.Int("length", n).Int("count", len(missing)).
+			Strs("sample", missing[:min(len(missing), 5)]).
+			Msgf("words: answers missing from allowed list; %s", action)
+	}
+	return total
 }
 
 // readWordFile loads one word per line from a file,
-// lowercases, trims, and keeps only valid 5-letter alphabetic words.
-func readWordFile(path string) ([]string, error) {
+// lowercases, trims, and keeps only alphabetic words of a supported length.
+// Lines are tallied in lc (nil = not counted).
+func readWordFile(path string, lc *lineCount) ([]string, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
@@ -129,8 +345,7 @@ func readWordFile(path string) ([]string, error) {
 	var out []string
 	sc := bufio.NewScanner(f)
 	for sc.Scan() {
-		w := strings.TrimSpace(strings.ToLower(sc.Text()))
-		if len(w) == 5 && isAlpha(w) {
+		if w, ok := lc.word(sc.Text()); ok {
 			out = append(out, w)
 		}
 	}
@@ -138,18 +353,54 @@ func readWordFile(path string) ([]string, error) {
 }
 
 // normalizeLines processes an embedded multiline string
-// into a slice of valid lowercase 5-letter words.
-func normalizeLines(s string) []string {
+// into a slice of valid lowercase words of a supported length.
+// Lines are tallied in lc (nil = not counted).
+func normalizeLines(s string, lc *lineCount) []string {
 	var out []string
 	for _, line := range strings.Split(s, "\n") {
-		w := strings.TrimSpace(strings.ToLower(line))
-		if len(w) == 5 && isAlpha(w) {
+		if w, ok := lc.word(line); ok {
 			out = append(out, w)
 		}
 	}
 	return out
 }
 
+// lineCount tallies the non-blank source lines a load read and rejected.
+type lineCount struct {
+	lines, rejected int
+}
+
+// word normalizes one source line and reports whether it is a valid word,
+// counting it unless c is nil. Blank lines are skipped without counting.
+func (c *lineCount) word(line string) (string, bool) {
+	w := strings.TrimSpace(strings.ToLower(line))
+	if w == "" {
+		return "", false
+	}
+	ok := validWord(w)
+	if c != nil {
+		c.lines++
+		if !ok {
+			c.rejected++
+		}
+	}
+	return w, ok
+}
+
+// validWord reports whether w is alphabetic with a supported length.
+func validWord(w string) bool {
+	return len(w) >= MinLength && len(w) <= MaxLength && isAlpha(w)
+}
+
+// byLength groups a word list by word length, preserving order.
+func byLength(list []string) map[int][]string {
+	m := make(map[int][]string)
+	for _, w := range list {
+		m[len(w)] = append(m[len(w)], w)
+	}
+	return m
+}
+
 // toSet converts a list of strings into a lookup set.
 func toSet(list []string) map[string]struct{} {
 	m := make(map[string]struct{}, len(list))
@@ -169,29 +420,88 @@ func isAlpha(s string) bool {
 	return true
 }
 
-// RandomAnswer returns a cryptographically random answer from the answers list.
+// RandomAnswer returns a cryptographically random DefaultLength answer.
 // If answers are not loaded yet or empty, falls back to "crane".
 func RandomAnswer() string {
-	if len(answers) == 0 {
-		return "crane"
+	if w := RandomAnswerLen(DefaultLength); w != "" {
+		return w
+	}
+	return "crane"
+}
+
+// RandomAnswerLen returns a cryptographically random answer of length n,
+// or "" if no answers of that length are loaded.
+// During an active theme window the themed pool is used instead (see theme.go);
+// otherwise weighted lists, when configured, drive the pick (see weighted.go).
+func RandomAnswerLen(n int) string {
+	l := current()
+	list := themedClassicAnswers(n)
+	if len(list) == 0 && len(l.weightedByLen[n]) > 0 {
+		return pickWeighted(l.weightedByLen[n], cryptoRandN)
+	}
+	if len(list) == 0 {
+		list = l.answersByLen[n]
+	}
+	if len(list) == 0 {
+		return ""
 	}
-	nBig, _ := rand.Int(rand.Reader, big.NewInt(int64(len(answers))))
-	return answers[nBig.Int64()]
+	nBig, _ := rand.Int(rand.Reader, big.NewInt(int64(len(list))))
+	return list[nBig.Int64()]
 }
 
-// IsAllowed reports whether w is a valid guess (answers ∪ guesses).
+// AnswersLen returns the classic answer list of length n (nil if none are
+// loaded). Callers must not modify it.
+func AnswersLen(n int) []string {
+	return current().answersByLen[n]
+}
+
+// IsAllowed reports whether w is a valid guess (answers ∪ guesses) for its own length.
 func IsAllowed(w string) bool {
-	_, ok := allowedSet[strings.ToLower(w)]
+	return IsAllowedLen(w, len(w))
+}
+
+// IsAllowedLen reports whether w is a valid guess in an n-letter game.
+// In Bloom mode answers are matched exactly and other words probabilistically.
+func IsAllowedLen(w string, n int) bool {
+	if len(w) != n {
+		return false
+	}
+	return current().isAllowed(strings.ToLower(w), n)
+}
+
+// isAllowed reports whether the lowercase w is an allowed n-letter guess in l.
+func (l *lists) isAllowed(w string, n int) bool {
+	if b := l.allowedBloom[n]; b != nil {
+		if _, ok := l.answersSet[n][w]; ok {
+			return true
+		}
+		return b.has(w)
+	}
+	_, ok := l.allowedSet[n][w]
 	return ok
 }
 
 // IsAnswer reports whether w is an answer word.
 func IsAnswer(w string) bool {
-	_, ok := answersSet[strings.ToLower(w)]
+	_, ok := current().answersSet[len(w)][strings.ToLower(w)]
 	return ok
 }
 
-// Stats returns counts of loaded words: (answers, allowed).
+// Stats returns counts of loaded words across all lengths: (answers, allowed).
 func Stats() (answersCount int, allowedCount int) {
-	return len(answers), len(allowedSet)
+	return current().counts()
+}
+
+// counts is Stats for one generation.
+func (l *lists) counts() (answersCount int, allowedCount int) {
+	for _, list := range l.answersByLen {
+		answersCount += len(list)
+	}
+	for _, set := range l.allowedSet {
+		allowedCount += len(set)
+	}
+	for _, b := range l.allowedBloom {
+		allowedCount += b.n
+	}
+	return answersCount, allowedCount
 }
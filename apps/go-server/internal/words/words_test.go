@@ -0,0 +1,117 @@
+package words
+
+import "testing"
+
+func TestAllowedSetsKeyedByLength(t *testing.T) {
+	// Registered first so it runs after the env is restored.
+	t.Cleanup(func() { _, _ = Reload() })
+	dir := t.TempDir()
+	t.Setenv("WORDS_ANSWERS_FILE", writeList(t, dir, "answers.txt", "crane", "planet"))
+	t.Setenv("WORDS_ALLOWED_FILE", writeList(t, dir, "allowed.txt", "crane", "slate", "planet", "silver"))
+	if _, err := Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	for _, tc := range []struct {
+		w    string
+		n    int
+		want bool
+	}{
+		{"slate", 5, true},
+		{"slate", 6, false},
+		{"silver", 6, true},
+		{"silver", 5, false},
+		{"PLANET", 6, true},
+		{"planes", 6, false},
+	} {
+		if got := IsAllowedLen(tc.w, tc.n); got != tc.want {
+			t.Errorf("IsAllowedLen(%q, %d) = %v, want %v", tc.w, tc.n, got, tc.want)
+		}
+	}
+	if !IsAllowed("silver") || !IsAllowed("slate") {
+		t.Error("IsAllowed should check each word against its own length")
+	}
+	if got := AnswersLen(6); len(got) != 1 || got[0] != "planet" {
+		t.Errorf("AnswersLen(6) = %v, want [planet]", got)
+	}
+	if got := AnswersLen(5); len(got) != 1 || got[0] != "crane" {
+		t.Errorf("AnswersLen(5) = %v, want [crane]", got)
+	}
+}
+
+func TestAnswersAllowedInvariantPerLength(t *testing.T) {
+	// Registered first so it runs after the env is restored.
+	t.Cleanup(func() { _, _ = Reload() })
+	dir := t.TempDir()
+	answers := []string{"crane", "bird", "planet", "orchard"}
+	t.Setenv("WORDS_ANSWERS_FILE", writeList(t, dir, "answers.txt", answers...))
+	t.Setenv("WORDS_ALLOWED_FILE", writeList(t, dir, "allowed.txt", "slate", "fish", "silver"))
+
+	r, err := Reload()
+	if err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if r.Missing != len(answers) || r.Answers != len(answers) {
+		t.Fatalf("seeded report = %+v, want %d missing and all kept", r, len(answers))
+	}
+	for _, w := range answers {
+		if !IsAllowedLen(w, len(w)) {
+			t.Errorf("answer %q is not a legal guess in its %d-letter game", w, len(w))
+		}
+	}
+
+	t.Setenv("WORDS_SEED_ALLOWED", "false")
+	t.Setenv("WORDS_ALLOWED_FILE", writeList(t, dir, "allowed.txt", "crane", "fish", "orchard"))
+	if r, err = Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if r.Missing != 2 || r.Answers != 2 {
+		t.Fatalf("unseeded report = %+v, want 2 missing and 2 kept", r)
+	}
+	if len(AnswersLen(4)) != 0 || len(AnswersLen(6)) != 0 || IsAllowed("bird") {
+		t.Fatal("answers missing from the allowed list were kept")
+	}
+	if len(AnswersLen(5)) != 1 || len(AnswersLen(7)) != 1 {
+		t.Fatal("answers in the allowed list were dropped")
+	}
+
+	t.Setenv("WORDS_SEED_ALLOWED", "")
+	t.Setenv("WORDS_STRICT", "true")
+	if _, err := Reload(); err == nil {
+		t.Fatal("strict load accepted answers missing from the allowed list")
+	}
+}
+
+func TestReloadCountsRejectedLines(t *testing.T) {
+	// Registered first so it runs after the env is restored.
+	t.Cleanup(func() { _, _ = Reload() })
+	dir := t.TempDir()
+	t.Setenv("WORDS_ANSWERS_FILE", writeList(t, dir, "answers.txt", "crane", "cr4ne", "", "x", "Slate", "abcdefghijklmnop"))
+	t.Setenv("WORDS_ALLOWED_FILE", writeList(t, dir, "allowed.txt", "crane", "slate", "adie!", "", "trace"))
+
+	r, err := Reload()
+	if err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if r.Lines != 9 || r.Rejected != 4 || r.Answers != 2 || r.Allowed != 3 || r.Missing != 0 {
+		t.Fatalf("report = %+v, want 9 lines, 4 rejected, 2 answers, 3 allowed", r)
+	}
+	if IsAllowed("cr4ne") || !IsAllowed("slate") {
+		t.Fatal("malformed line kept or valid line dropped")
+	}
+
+	// Without WORDS_STRICT that was only a warning. Under it a third of the
+	// lines rejected fails the load, and the previous lists stay installed.
+	t.Setenv("WORDS_STRICT", "true")
+	t.Setenv("WORDS_ANSWERS_FILE", writeList(t, dir, "answers.txt", "trace", "cr4ne"))
+	if _, err := Reload(); err == nil {
+		t.Fatal("strict load accepted 2 of 6 lines rejected")
+	}
+	if !IsAnswer("crane") || IsAnswer("trace") {
+		t.Fatal("failed strict load replaced the lists")
+	}
+	t.Setenv("WORDS_MAX_REJECTED_PCT", "40")
+	if r, err := Reload(); err != nil || r.Rejected != 2 {
+		t.Fatalf("within a 40%% limit: %+v, %v; want 2 rejected and no error", r, err)
+	}
+}
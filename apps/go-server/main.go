@@ -6,18 +6,28 @@
 //   - Configure logging (zerolog).
 //   - Initialize word lists (allowed guesses + answers).
 //   - Open and migrate SQLite/Postgres database.
-//   - Create an in-memory game state store.
-//   - Start HTTP server exposing game + auth routes.
+//   - Create the game state store (in-memory, or SQL with GAME_STORE=sql).
+//     The memory store evicts finished games after GAME_FINISHED_TTL (default
+//     1h) and any game after GAME_MAX_AGE (default 24h); 0 keeps them forever.
+//     With GAME_STORE_RECOVER=true, games still in play are reloaded into the
+//     memory store at startup (see httpserver.RecoverGames).
+//   - Start HTTP server exposing game + auth routes; on SIGINT/SIGTERM drain
+//     in-flight requests (SHUTDOWN_TIMEOUT, default 15s) and close the DB.
 
 package main
 
 import (
+	"context"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
+	"github.com/robalobadob/wordle/apps/go-server/internal/dialect"
 	"github.com/robalobadob/wordle/apps/go-server/internal/httpserver"
 	"github.com/robalobadob/wordle/apps/go-server/internal/store"
 	"github.com/robalobadob/wordle/apps/go-server/internal/words"
@@ -33,28 +43,51 @@ func main() {
 	}
 
 	// Initialize dictionaries of allowed/answer words.
-	if err := words.Init(); err != nil {
+	rep, err := words.Init()
+	if err != nil {
 		log.Fatal().Err(err).Msg("failed to load word lists")
 	}
+	log.Info().Int("answers", rep.Answers).Int("allowed", rep.Allowed).Int("lines", rep.Lines).
+		Int("rejected", rep.Rejected).Int("missing", rep.Missing).Msg("word lists loaded")
 
 	// Open DB connection (defaults to ./data/app.db if DATABASE_URL not set).
 	// DB should already have "users" table from earlier migrations.
-	db, err := openDB(envStr("DATABASE_URL", "./data/app.db"))
+	// The scheme picks the dialect (postgres:// → Postgres; see db.go).
+	dsn := envStr("DATABASE_URL", "./data/app.db")
+	dia := dialect.FromDSN(dsn)
+	db, err := openDB(dsn, dia)
 	if err != nil {
 		log.Fatal().Err(err).Msg("openDB failed")
 	}
 	defer db.Close()
 
 	// Apply schema migrations.
-	if err := migrate(db); err != nil {
+	if err := migrate(db, dia); err != nil {
 		log.Fatal().Err(err).Msg("migrate failed")
 	}
 
-	// Create in-memory store for active game state (per-process only).
-	mem := store.NewMemoryStore()
+	// Create the store for active game state: per-process memory by default,
+	// or the database (durable, shared across instances) with GAME_STORE=sql.
+	var st store.Store
+	switch envStr("GAME_STORE", "memory") {
+	case "sql":
+		st = store.NewSQLStore(db)
+	case "memory":
+		st = store.NewMemoryStoreTTL(envDur("GAME_FINISHED_TTL", time.Hour), envDur("GAME_MAX_AGE", 24*time.Hour))
+	default:
+		log.Fatal().Str("GAME_STORE", os.Getenv("GAME_STORE")).Msg("unknown game store (want memory or sql)")
+	}
 
-	// Construct HTTP server with memory store + database.
-	srv := httpserver.New(mem, db)
+	// Construct HTTP server with the game store + database.
+	srv := httpserver.New(st, db)
+	defer srv.Close()
+	if envStr("GAME_STORE", "memory") == "memory" {
+		if n, err := srv.RecoverGames(context.Background()); err != nil {
+			log.Error().Err(err).Int("recovered", n).Msg("recovering games failed")
+		} else if n > 0 {
+			log.Info().Int("games", n).Msg("recovered active games")
+		}
+	}
 
 	// Server listen address (defaults to :3000).
 	addr := ":" + envStr("PORT", "3000")
@@ -65,9 +98,24 @@ func main() {
 		Str("client_origin", envStr("CLIENT_ORIGIN", "http://localhost:5173")).
 		Msg("go-server listening")
 
-	// Start blocking server loop. Exit fatally if it stops.
-	if err := srv.Start(addr); err != nil {
+	// Serve until SIGINT/SIGTERM, then let in-flight requests finish
+	// (SHUTDOWN_TIMEOUT, default 15s) before the deferred Close and db.Close
+	// stop background jobs and close the database. Exit fatally if the
+	// listener fails on its own.
+	sig, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Start(addr) }()
+	select {
+	case err := <-errc:
 		log.Fatal().Err(err).Msg("server exited")
+	case <-sig.Done():
+		log.Info().Msg("shutting down")
+		ctx, cancel := context.WithTimeout(context.Background(), envDur("SHUTDOWN_TIMEOUT", 15*time.Second))
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Warn().Err(err).Msg("shutdown: in-flight requests cut off")
+		}
 	}
 }
 
@@ -79,5 +127,13 @@ func envStr(k, def string) string {
 	return def
 }
 
+// envDur parses env var k as a Go duration, or returns def if unset/invalid.
+func envDur(k string, def time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(k)); err == nil {
+		return d
+	}
+	return def
+}
+
 // getEnv is an alias for envStr (kept for compatibility).
 func getEnv(k, def string) string { return envStr(k, def) }
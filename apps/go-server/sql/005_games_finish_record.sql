@@ -0,0 +1,15 @@
+-- apps/go-server/sql/005_games_finish_record.sql
+--
+-- Migration #5: Add finish-record columns to `games`.
+--
+-- Context:
+--   With PERSIST_GAME_RESULTS=true the server writes a complete finish record
+--   when a classic game ends (status, finished_at, final guess count, answer,
+--   win flag, duration) in the same transaction as the stats update.
+--
+-- Schema changes:
+--   • won         – 1 if the game was won, 0 if lost (NULL while playing / not recorded)
+--   • duration_ms – milliseconds from started_at to finish (NULL while playing / not recorded)
+
+ALTER TABLE games ADD COLUMN won INTEGER;
+ALTER TABLE games ADD COLUMN duration_ms INTEGER;
@@ -0,0 +1,13 @@
+-- apps/go-server/sql/006_users_daily_difficulty.sql
+--
+-- Migration #6: Per-user daily difficulty preference.
+--
+-- Schema changes:
+--   • users.daily_difficulty – 'easy' | 'normal' | 'hard' (default 'normal')
+--       - easy:   normal + each in-progress guess reveals one unsolved letter
+--       - normal: guesses must be in the allowed list
+--       - hard:   normal + revealed hints must be reused (hard mode)
+--
+-- Applied to the next daily the user starts (see /daily/preferences).
+
+ALTER TABLE users ADD COLUMN daily_difficulty TEXT NOT NULL DEFAULT 'normal';
@@ -0,0 +1,23 @@
+-- apps/go-server/sql/007_game_guesses.sql
+--
+-- Migration #7: Per-guess history for classic games.
+--
+-- Context:
+--   Each accepted guess is appended here with a UTC RFC3339 timestamp so
+--   clients can replay a game and compute per-guess think time
+--   (see GET /games/{id}/history; timestamps exposed with HISTORY_TIMESTAMPS=true).
+--
+-- Schema notes:
+--   • game_id    – owning game (games.id); rows are removed with the game
+--   • seq        – 1-based guess number within the game
+--   • guess      – the normalized (lowercase) guessed word
+--   • created_at – RFC3339 timestamp (UTC) when the guess was accepted
+
+CREATE TABLE IF NOT EXISTS game_guesses (
+  game_id    TEXT NOT NULL,
+  seq        INTEGER NOT NULL,
+  guess      TEXT NOT NULL,
+  created_at TEXT NOT NULL,
+  PRIMARY KEY (game_id, seq),
+  FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+);
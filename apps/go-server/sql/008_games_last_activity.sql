@@ -0,0 +1,20 @@
+-- apps/go-server/sql/008_games_last_activity.sql
+--
+-- Migration #8: Track the last activity time of classic games.
+--
+-- Context:
+--   Games started via /game/new but never finished used to stay 'playing'
+--   forever. A background sweep (GAME_ABANDON_AFTER) now reclassifies games
+--   with no activity for the configured period as 'abandoned'.
+--
+-- Schema changes:
+--   • last_activity – RFC3339 timestamp of the last guess (NULL until the first
+--                     guess; the sweep falls back to started_at)
+--   • status        – may now also be 'abandoned'
+--
+-- Indexes:
+--   • idx_games_status_activity → supports the sweep's status + time filter
+
+ALTER TABLE games ADD COLUMN last_activity TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_games_status_activity ON games(status, last_activity);
@@ -0,0 +1,12 @@
+-- apps/go-server/sql/009_games_difficulty.sql
+--
+-- Migration #9: Store a difficulty score with finished classic games.
+--
+-- Context:
+--   With GAME_DIFFICULTY_SCORES=true (game_difficulty flag) the server scores
+--   each finished game's answer (internal/analysis) for analytics.
+--
+-- Schema changes:
+--   • difficulty – 0–100, higher = harder (NULL when not scored)
+
+ALTER TABLE games ADD COLUMN difficulty REAL;
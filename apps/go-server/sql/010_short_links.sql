@@ -0,0 +1,27 @@
+-- apps/go-server/sql/010_short_links.sql
+--
+-- Migration #10: Short links for shareable challenges.
+--
+-- Context:
+--   POST /links stores a game config (mode + answer) under a short random slug
+--   so players can share a puzzle URL; POST /game/new {"link": slug} starts it.
+--   Links expire after SHORT_LINK_TTL and are purged by a background job.
+--
+-- Schema notes:
+--   • slug       – short URL-safe identifier (primary key)
+--   • mode       – game mode ("normal" | "hard" | "jotto")
+--   • answer     – the challenge word (never returned by GET /links/{slug})
+--   • created_by – creator's user ID or anon cookie ID
+--   • created_at – RFC3339 timestamp (UTC)
+--   • expires_at – RFC3339 timestamp (UTC) after which the link no longer resolves
+
+CREATE TABLE IF NOT EXISTS short_links (
+  slug       TEXT PRIMARY KEY,
+  mode       TEXT NOT NULL,
+  answer     TEXT NOT NULL,
+  created_by TEXT NOT NULL,
+  created_at TEXT NOT NULL,
+  expires_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_short_links_expires_at ON short_links(expires_at);
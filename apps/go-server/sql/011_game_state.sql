@@ -0,0 +1,33 @@
+-- apps/go-server/sql/011_game_state.sql
+--
+-- Migration #11: Durable state for active classic games.
+--
+-- Context:
+--   With GAME_STORE=sql the server keeps each game's full engine state here
+--   (store.NewSQLStore) instead of in process memory, so games survive a
+--   restart and can be shared by several instances on the same database.
+--   The `games` table stays the owner/stats record; this one mirrors game.Game.
+--
+-- Schema notes:
+--   • id         – game ID (same as games.id)
+--   • mode       – "normal" | "hard" | "jotto"
+--   • answer     – the solution word (lowercase)
+--   • rows/cols  – board size
+--   • guesses    – JSON array of guessed words, in order
+--   • counts     – JSON array of jotto counts (parallel to guesses; [] otherwise)
+--   • finished   – 0/1
+--   • won        – 0/1
+--   • updated_at – RFC3339 timestamp (UTC) of the last save
+
+CREATE TABLE IF NOT EXISTS game_state (
+  id         TEXT PRIMARY KEY,
+  mode       TEXT NOT NULL,
+  answer     TEXT NOT NULL,
+  rows       INTEGER NOT NULL,
+  cols       INTEGER NOT NULL,
+  guesses    TEXT NOT NULL DEFAULT '[]',
+  counts     TEXT NOT NULL DEFAULT '[]',
+  finished   INTEGER NOT NULL DEFAULT 0,
+  won        INTEGER NOT NULL DEFAULT 0,
+  updated_at TEXT NOT NULL
+);
@@ -0,0 +1,14 @@
+-- apps/go-server/sql/012_game_state_times.sql
+--
+-- Migration #12: Creation and finish times for stored game state.
+--
+-- Context:
+--   game.Game now records CreatedAt/FinishedAt (used by the memory store's
+--   eviction sweep); the SQL store keeps them so a round trip is lossless.
+--
+-- Schema changes:
+--   • created_at  – RFC3339Nano timestamp (UTC); '' for rows saved before this migration
+--   • finished_at – RFC3339Nano timestamp (UTC); '' while the game is in play
+
+ALTER TABLE game_state ADD COLUMN created_at TEXT NOT NULL DEFAULT '';
+ALTER TABLE game_state ADD COLUMN finished_at TEXT NOT NULL DEFAULT '';
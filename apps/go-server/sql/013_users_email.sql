@@ -0,0 +1,18 @@
+-- apps/go-server/sql/013_users_email.sql
+--
+-- Migration #13: Optional email address on accounts.
+--
+-- Context:
+--   LOGIN_IDENTIFIER=email|either lets players sign up and log in with an
+--   email address (groundwork for password reset and notifications).
+--   Username login keeps working; accounts without an email leave it NULL.
+--
+-- Schema changes:
+--   • email – normalized (trimmed, lowercase) address; NULL when not given
+--
+-- Indexes:
+--   • idx_users_email → unique, so one address maps to one account
+
+ALTER TABLE users ADD COLUMN email TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users(email);
@@ -0,0 +1,20 @@
+-- apps/go-server/sql/014_users_guess_distribution.sql
+--
+-- Migration #14: Per-user guess distribution for classic wins.
+--
+-- Context:
+--   /stats/me returns a 1–6 "distribution" histogram (wins by number of
+--   guesses). bumpStats increments the matching bucket in the same
+--   transaction as games_played/wins/streak; losses touch no bucket.
+--
+-- Schema changes:
+--   • win_guess_1 … win_guess_6 – wins solved in exactly N guesses
+--
+-- Existing accounts start at zero; wins before this migration aren't backfilled.
+
+ALTER TABLE users ADD COLUMN win_guess_1 INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN win_guess_2 INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN win_guess_3 INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN win_guess_4 INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN win_guess_5 INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN win_guess_6 INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,19 @@
+-- apps/go-server/sql/015_users_verification.sql
+--
+-- Migration #15: Optional account verification.
+--
+-- Context:
+--   With REQUIRE_ACCOUNT_VERIFICATION=true (account_verification flag) new
+--   accounts start unverified and receive a one-time token; gated routes
+--   answer 403 "unverified" until POST /auth/verify redeems it.
+--
+-- Schema changes:
+--   • verified            – 0/1; existing accounts are treated as verified
+--   • verify_token_hash   – hex SHA-256 of the pending token (NULL once used)
+--   • verify_expires_at   – RFC3339 timestamp (UTC) after which the token is void
+
+ALTER TABLE users ADD COLUMN verified INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN verify_token_hash TEXT;
+ALTER TABLE users ADD COLUMN verify_expires_at TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_users_verify_token_hash ON users(verify_token_hash);
@@ -0,0 +1,21 @@
+-- apps/go-server/sql/016_streak_freezes.sql
+--
+-- Migration #16: Daily "streak freeze" tokens.
+--
+-- Context:
+--   With DAILY_STREAK_FREEZE_ENABLED=true (streak_freeze flag) players earn a
+--   freeze every DAILY_FREEZE_EVERY consecutive daily wins; one is spent
+--   automatically to bridge a single missed day (see daily.Streak).
+--
+-- Schema changes:
+--   • users.streak_freezes – unspent freezes
+--   • daily_streak_freezes – dates bridged by a spent freeze, so a day is only
+--                            ever charged once
+
+ALTER TABLE users ADD COLUMN streak_freezes INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS daily_streak_freezes (
+  user_id TEXT NOT NULL,
+  date    TEXT NOT NULL,
+  PRIMARY KEY (user_id, date)
+);
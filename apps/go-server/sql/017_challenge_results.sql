@@ -0,0 +1,40 @@
+-- apps/go-server/sql/017_challenge_results.sql
+--
+-- Migration #17: Per-challenge leaderboards for classic games.
+--
+-- Context:
+--   Games started from a short link (POST /game/new {"link": slug}) share a
+--   fixed answer. With CHALLENGE_LEADERBOARD_ENABLED=true a finished game can
+--   be submitted (POST /game/{id}/submit) to the leaderboard keyed by that
+--   slug (GET /challenges/{code}/leaderboard).
+--
+-- Schema changes:
+--   • games.link – slug the game was started from (NULL for ordinary games)
+--   • challenge_results – one opted-in result per player per challenge
+--       - slug         – the challenge code (short link slug)
+--       - player_id    – user ID, or anon cookie ID for guests
+--       - user_id      – set for registered players (username lookup)
+--       - game_id      – the submitted game
+--       - won          – 1 = solved, 0 = out of guesses
+--       - guesses      – guesses used
+--       - duration_ms  – started_at → finished_at of the game
+--       - submitted_at – RFC3339 timestamp (UTC)
+--
+-- Indexes:
+--   • idx_challenge_results_rank → ranked board per slug.
+
+ALTER TABLE games ADD COLUMN link TEXT;
+
+CREATE TABLE IF NOT EXISTS challenge_results (
+  slug         TEXT NOT NULL,
+  player_id    TEXT NOT NULL,
+  user_id      TEXT,
+  game_id      TEXT NOT NULL,
+  won          INTEGER NOT NULL,
+  guesses      INTEGER NOT NULL,
+  duration_ms  INTEGER NOT NULL,
+  submitted_at TEXT NOT NULL,
+  PRIMARY KEY (slug, player_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_challenge_results_rank ON challenge_results(slug, won, guesses, duration_ms);
@@ -0,0 +1,17 @@
+-- apps/go-server/sql/018_custom_allowed.sql
+--
+-- Migration #18: Per-game custom allowed-guess lists.
+--
+-- Context:
+--   With CUSTOM_ALLOWED_ENABLED=true a challenge link or a custom game can
+--   carry its own guess list (e.g. only 5-letter animals) that replaces the
+--   global allowed list for that game. The link stores the list so every
+--   game started from it gets the same one, and game_state keeps it so a
+--   SQL-stored game enforces it after a restart.
+--
+-- Schema changes:
+--   • short_links.allowed – JSON array of lowercase words; '[]' = the global list
+--   • game_state.allowed  – JSON array of lowercase words (sorted); '[]' = the global list
+
+ALTER TABLE short_links ADD COLUMN allowed TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE game_state ADD COLUMN allowed TEXT NOT NULL DEFAULT '[]';
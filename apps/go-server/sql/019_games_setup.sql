@@ -0,0 +1,16 @@
+-- apps/go-server/sql/019_games_setup.sql
+--
+-- Migration #19: Starting state of classic games, for startup recovery.
+--
+-- Context:
+--   The memory game store loses every active game on restart. With
+--   GAME_STORE_RECOVER=true, POST /game/new also records the new game's
+--   canonical JSON (game.Marshal: mode, answer, board size, custom allowed
+--   list) here, and on startup games still 'playing' are rebuilt from it by
+--   replaying their game_guesses rows into the memory store.
+--
+-- Schema changes:
+--   • games.setup – canonical JSON of the game as created (includes the
+--                   answer); NULL for games started with recovery off
+
+ALTER TABLE games ADD COLUMN setup TEXT;
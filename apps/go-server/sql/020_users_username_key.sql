@@ -0,0 +1,30 @@
+-- apps/go-server/sql/020_users_username_key.sql
+--
+-- Migration #20: Canonical username key for case-insensitive uniqueness.
+--
+-- Context:
+--   Username uniqueness and login lookups compared lower(username), and
+--   SQLite's lower() only folds ASCII. The server now computes a canonical
+--   key in Go (Unicode simple case folding, see usernameKey) and stores it
+--   alongside the username, which keeps the casing the player typed for
+--   display.
+--
+-- Schema changes:
+--   • users.username_key – case-folded username; every new or imported
+--                          account gets one
+--
+-- Backfill:
+--   Existing usernames are ASCII-only (signup has always enforced that), so
+--   lower() gives the same key as Go. If two legacy rows differ only in case,
+--   only the oldest gets the key; the others stay NULL and are still found
+--   by the lower(username) fallback at login.
+--
+-- Indexes:
+--   • idx_users_username_key → unique, so one key maps to one account
+
+ALTER TABLE users ADD COLUMN username_key TEXT;
+
+UPDATE users SET username_key = lower(username)
+ WHERE rowid = (SELECT MIN(u2.rowid) FROM users u2 WHERE lower(u2.username) = lower(users.username));
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username_key ON users(username_key);
@@ -0,0 +1,14 @@
+-- apps/go-server/sql/021_users_is_admin.sql
+--
+-- Migration #21: Per-account admin role.
+--
+-- Context:
+--   Admin endpoints were only reachable with the shared ADMIN_TOKEN. Account
+--   lookups for operators and moderators (GET /admin/users/{id}/stats) are
+--   instead gated on the signed-in user, so users can now be marked as
+--   admins. There is no endpoint to grant the role; set it in the database.
+--
+-- Schema changes:
+--   • users.is_admin – 0/1; every existing and new account starts at 0
+
+ALTER TABLE users ADD COLUMN is_admin INTEGER NOT NULL DEFAULT 0;
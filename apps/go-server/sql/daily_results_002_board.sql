@@ -0,0 +1,18 @@
+-- apps/go-server/sql/daily_results_002_board.sql
+--
+-- Migration: Persist the winning board for daily results.
+--
+-- Context:
+--   Once a daily is won the in-memory session may be gone, so the board
+--   (the ordered list of guessed words) is stored with the result. This lets
+--   /daily/share rebuild the emoji grid later without revealing the answer.
+--
+-- Schema changes:
+--   • board – JSON array of guessed words, e.g. ["crane","slate","plant"]
+--             (NULL for rows recorded before this migration)
+--
+-- Naming:
+--   • Prefixed with "daily_results_" so it sorts after daily_results.sql and
+--     the table is guaranteed to exist when this runs.
+
+ALTER TABLE daily_results ADD COLUMN board TEXT;
@@ -0,0 +1,29 @@
+-- apps/go-server/sql/daily_results_003_archive.sql
+--
+-- Migration: Create `daily_results_archive` for the retention job.
+--
+-- Context:
+--   When DAILY_RETENTION_DAYS is set, the server periodically moves
+--   daily_results rows older than the window into this table (or deletes them
+--   when DAILY_RETENTION_MODE=delete). Recent leaderboards stay in the hot table.
+--
+-- Schema notes:
+--   • Same columns as daily_results, plus archived_at.
+--   • No UNIQUE constraint: archival is append-only.
+--
+-- Indexes:
+--   • idx_daily_results_archive_date → lookups of archived days.
+
+CREATE TABLE IF NOT EXISTS daily_results_archive (
+  id          INTEGER PRIMARY KEY,
+  user_id     TEXT NOT NULL,
+  date        TEXT NOT NULL,
+  word_index  INTEGER NOT NULL,
+  guesses     INTEGER NOT NULL,
+  elapsed_ms  INTEGER NOT NULL,
+  created_at  TIMESTAMP,
+  board       TEXT,
+  archived_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_results_archive_date ON daily_results_archive(date);
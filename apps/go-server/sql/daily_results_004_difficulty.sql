@@ -0,0 +1,13 @@
+-- apps/go-server/sql/daily_results_004_difficulty.sql
+--
+-- Migration: Record the difficulty each daily result was played at.
+--
+-- Schema changes:
+--   • difficulty – 'easy' | 'normal' | 'hard' (default 'normal' for existing rows)
+--
+-- Indexes:
+--   • idx_daily_results_date_difficulty → leaderboards segmented by difficulty.
+
+ALTER TABLE daily_results ADD COLUMN difficulty TEXT NOT NULL DEFAULT 'normal';
+
+CREATE INDEX IF NOT EXISTS idx_daily_results_date_difficulty ON daily_results(date, difficulty, elapsed_ms);
@@ -0,0 +1,21 @@
+-- apps/go-server/sql/daily_results_005_won.sql
+--
+-- Migration: Persist daily losses alongside wins.
+--
+-- Context:
+--   Only wins used to be written, so participation was undercounted. Losses
+--   (out of guesses) are now stored too; the leaderboard ranks wins only.
+--
+-- Schema changes:
+--   • daily_results.won          – 1 = solved, 0 = out of guesses (existing rows are wins)
+--   • daily_results_archive.won  – carried over by the retention job
+--   • daily_results_archive.difficulty – carried over by the retention job
+--
+-- Indexes:
+--   • idx_daily_results_date_won → ranked boards (wins) per date.
+
+ALTER TABLE daily_results ADD COLUMN won INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE daily_results_archive ADD COLUMN won INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE daily_results_archive ADD COLUMN difficulty TEXT NOT NULL DEFAULT 'normal';
+
+CREATE INDEX IF NOT EXISTS idx_daily_results_date_won ON daily_results(date, won, elapsed_ms);
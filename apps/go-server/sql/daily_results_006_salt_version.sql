@@ -0,0 +1,32 @@
+-- apps/go-server/sql/daily_results_006_salt_version.sql
+--
+-- Migration: Salt versioning for the daily word mapping.
+--
+-- Context:
+--   The daily answer index is HMAC(date, DAILY_SALT). Changing the salt used to
+--   remap every date, past ones included. Each date's index is now pinned the
+--   first time it is served, together with the salt version that produced it,
+--   so rotating DAILY_SALT (and bumping DAILY_SALT_VERSION) only affects dates
+--   that have not been served yet.
+--
+-- Schema changes:
+--   • daily_words                  – date → word_index pin (one row per date)
+--   • daily_results.salt_version   – salt version the result was played under
+--   • daily_results_archive.salt_version
+--
+-- Backfill:
+--   • Dates that already have results are pinned to their recorded index under
+--     version 1 (the only version before this migration).
+
+CREATE TABLE IF NOT EXISTS daily_words (
+  date         TEXT PRIMARY KEY,            -- "YYYY-MM-DD"
+  word_index   INTEGER NOT NULL,
+  salt_version INTEGER NOT NULL,
+  created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE daily_results ADD COLUMN salt_version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE daily_results_archive ADD COLUMN salt_version INTEGER NOT NULL DEFAULT 1;
+
+INSERT OR IGNORE INTO daily_words (date, word_index, salt_version)
+SELECT date, MIN(word_index), 1 FROM daily_results GROUP BY date;
@@ -0,0 +1,21 @@
+-- apps/go-server/sql/daily_results_007_fingerprint.sql
+--
+-- Migration: Soft device fingerprint on guest daily results.
+--
+-- Context:
+--   Guests can replay the daily from fresh incognito windows, since each one
+--   gets a new anon cookie. With DAILY_FINGERPRINT=advisory|strict, guest
+--   results record a salted hash of the caller's IP and/or User-Agent, and
+--   /daily/new flags (advisory) or refuses (strict) a second guest attempt from
+--   the same fingerprint on the same day.
+--
+-- Schema changes:
+--   • daily_results.fingerprint – hex SHA-256; NULL for registered players
+--                                 and for results recorded with the check off
+--
+-- Indexes:
+--   • idx_daily_results_date_fingerprint → per-day fingerprint lookups.
+
+ALTER TABLE daily_results ADD COLUMN fingerprint TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_daily_results_date_fingerprint ON daily_results(date, fingerprint);
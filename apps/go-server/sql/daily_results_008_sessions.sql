@@ -0,0 +1,48 @@
+-- apps/go-server/sql/daily_results_008_sessions.sql
+--
+-- Migration: Durable in-progress daily sessions.
+--
+-- Context:
+--   /daily sessions used to live only in process memory, so a restart lost
+--   every game in progress. Each session is now written here on start and
+--   after every guess, and /daily/new and /daily/guess reload it when the
+--   in-memory cache misses. The finished result still goes to daily_results.
+--
+-- Schema changes:
+--   • daily_sessions – one row per player per date
+--       - user_id       – user ID, or anon cookie ID for guests
+--       - date          – "YYYY-MM-DD" (UTC)
+--       - game_id       – session game ID handed to the client
+--       - word_index    – index of the day's answer (the answer is not stored)
+--       - salt_version  – salt version word_index was pinned under
+--       - guesses       – JSON array of guessed words, in order
+--       - started_at    – RFC3339Nano timestamp (UTC)
+--       - last_seen     – RFC3339Nano timestamp (UTC) of the start or last guess
+--       - active_ms     – idle-capped play time so far
+--       - finished, won – 0/1
+--       - difficulty    – easy | normal | hard
+--       - fingerprint   – guest device fingerprint ('' = none)
+--       - practice_code – short link made once finished ('' = none)
+--
+-- Indexes:
+--   • idx_daily_sessions_date → pruning past days.
+
+CREATE TABLE IF NOT EXISTS daily_sessions (
+  user_id       TEXT NOT NULL,
+  date          TEXT NOT NULL,
+  game_id       TEXT NOT NULL,
+  word_index    INTEGER NOT NULL,
+  salt_version  INTEGER NOT NULL DEFAULT 1,
+  guesses       TEXT NOT NULL DEFAULT '[]',
+  started_at    TEXT NOT NULL,
+  last_seen     TEXT NOT NULL,
+  active_ms     INTEGER NOT NULL DEFAULT 0,
+  finished      INTEGER NOT NULL DEFAULT 0,
+  won           INTEGER NOT NULL DEFAULT 0,
+  difficulty    TEXT NOT NULL DEFAULT 'normal',
+  fingerprint   TEXT NOT NULL DEFAULT '',
+  practice_code TEXT NOT NULL DEFAULT '',
+  PRIMARY KEY (user_id, date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_sessions_date ON daily_sessions(date);
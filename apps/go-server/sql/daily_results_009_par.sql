@@ -0,0 +1,14 @@
+-- apps/go-server/sql/daily_results_009_par.sql
+--
+-- Migration: Solver par per daily date.
+--
+-- Context:
+--   With the daily_par flag on, the guess count a fixed solver needs for the
+--   day's answer (analysis.SolverPar, openers from DAILY_PAR_OPENERS) is
+--   computed when the date is first served and stored next to its pin.
+--   /daily/recap only returns it once the day is over.
+--
+-- Schema changes:
+--   • daily_words.par – solver guess count (NULL = not computed yet)
+
+ALTER TABLE daily_words ADD COLUMN par INTEGER;
@@ -0,0 +1,9 @@
+-- apps/go-server/sql/postgres/003_anonymous_games.sql
+--
+-- Migration #3 (Postgres): Extend `games` for anonymous players.
+--
+-- Postgres form of sql/003_anonymous_games.sql; see that file for context.
+
+ALTER TABLE games ADD COLUMN anonymous_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_games_anon ON games(anonymous_id);
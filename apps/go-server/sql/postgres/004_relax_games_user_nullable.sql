@@ -0,0 +1,11 @@
+-- apps/go-server/sql/postgres/004_relax_games_user_nullable.sql
+--
+-- Migration #4 (Postgres): Relax `games.user_id` for guest ownership.
+--
+-- Postgres form of sql/004_relax_games_user_nullable.sql; see that file for context.
+--
+-- Differences:
+--   • Altered in place; SQLite has to rebuild the table to drop NOT NULL.
+
+ALTER TABLE games ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE games ADD CONSTRAINT games_owner_check CHECK (user_id IS NOT NULL OR anonymous_id IS NOT NULL);
@@ -0,0 +1,8 @@
+-- apps/go-server/sql/postgres/005_games_finish_record.sql
+--
+-- Migration #5 (Postgres): Add finish-record columns to `games`.
+--
+-- Postgres form of sql/005_games_finish_record.sql; see that file for context.
+
+ALTER TABLE games ADD COLUMN won INTEGER;
+ALTER TABLE games ADD COLUMN duration_ms INTEGER;
@@ -0,0 +1,7 @@
+-- apps/go-server/sql/postgres/006_users_daily_difficulty.sql
+--
+-- Migration #6 (Postgres): Per-user daily difficulty preference.
+--
+-- Postgres form of sql/006_users_daily_difficulty.sql; see that file for context.
+
+ALTER TABLE users ADD COLUMN daily_difficulty TEXT NOT NULL DEFAULT 'normal';
@@ -0,0 +1,14 @@
+-- apps/go-server/sql/postgres/007_game_guesses.sql
+--
+-- Migration #7 (Postgres): Per-guess history for classic games.
+--
+-- Postgres form of sql/007_game_guesses.sql; see that file for context.
+
+CREATE TABLE IF NOT EXISTS game_guesses (
+  game_id    TEXT NOT NULL,
+  seq        INTEGER NOT NULL,
+  guess      TEXT NOT NULL,
+  created_at TEXT NOT NULL,
+  PRIMARY KEY (game_id, seq),
+  FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE
+);
@@ -0,0 +1,9 @@
+-- apps/go-server/sql/postgres/008_games_last_activity.sql
+--
+-- Migration #8 (Postgres): Track the last activity time of classic games.
+--
+-- Postgres form of sql/008_games_last_activity.sql; see that file for context.
+
+ALTER TABLE games ADD COLUMN last_activity TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_games_status_activity ON games(status, last_activity);
@@ -0,0 +1,10 @@
+-- apps/go-server/sql/postgres/009_games_difficulty.sql
+--
+-- Migration #9 (Postgres): Store a difficulty score with finished classic games.
+--
+-- Postgres form of sql/009_games_difficulty.sql; see that file for context.
+--
+-- Differences:
+--   • difficulty is DOUBLE PRECISION (SQLite REAL is 8 bytes; Postgres REAL is 4).
+
+ALTER TABLE games ADD COLUMN difficulty DOUBLE PRECISION;
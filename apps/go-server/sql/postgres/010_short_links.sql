@@ -0,0 +1,16 @@
+-- apps/go-server/sql/postgres/010_short_links.sql
+--
+-- Migration #10 (Postgres): Short links for shareable challenges.
+--
+-- Postgres form of sql/010_short_links.sql; see that file for context.
+
+CREATE TABLE IF NOT EXISTS short_links (
+  slug       TEXT PRIMARY KEY,
+  mode       TEXT NOT NULL,
+  answer     TEXT NOT NULL,
+  created_by TEXT NOT NULL,
+  created_at TEXT NOT NULL,
+  expires_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_short_links_expires_at ON short_links(expires_at);
@@ -0,0 +1,18 @@
+-- apps/go-server/sql/postgres/011_game_state.sql
+--
+-- Migration #11 (Postgres): Durable state for active classic games.
+--
+-- Postgres form of sql/011_game_state.sql; see that file for context.
+
+CREATE TABLE IF NOT EXISTS game_state (
+  id         TEXT PRIMARY KEY,
+  mode       TEXT NOT NULL,
+  answer     TEXT NOT NULL,
+  rows       INTEGER NOT NULL,
+  cols       INTEGER NOT NULL,
+  guesses    TEXT NOT NULL DEFAULT '[]',
+  counts     TEXT NOT NULL DEFAULT '[]',
+  finished   INTEGER NOT NULL DEFAULT 0,
+  won        INTEGER NOT NULL DEFAULT 0,
+  updated_at TEXT NOT NULL
+);
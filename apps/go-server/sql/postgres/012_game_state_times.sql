@@ -0,0 +1,8 @@
+-- apps/go-server/sql/postgres/012_game_state_times.sql
+--
+-- Migration #12 (Postgres): Creation and finish times for stored game state.
+--
+-- Postgres form of sql/012_game_state_times.sql; see that file for context.
+
+ALTER TABLE game_state ADD COLUMN created_at TEXT NOT NULL DEFAULT '';
+ALTER TABLE game_state ADD COLUMN finished_at TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+-- apps/go-server/sql/postgres/013_users_email.sql
+--
+-- Migration #13 (Postgres): Optional email address on accounts.
+--
+-- Postgres form of sql/013_users_email.sql; see that file for context.
+
+ALTER TABLE users ADD COLUMN email TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users(email);
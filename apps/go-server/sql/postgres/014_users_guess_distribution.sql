@@ -0,0 +1,12 @@
+-- apps/go-server/sql/postgres/014_users_guess_distribution.sql
+--
+-- Migration #14 (Postgres): Per-user guess distribution for classic wins.
+--
+-- Postgres form of sql/014_users_guess_distribution.sql; see that file for context.
+
+ALTER TABLE users ADD COLUMN win_guess_1 INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN win_guess_2 INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN win_guess_3 INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN win_guess_4 INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN win_guess_5 INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN win_guess_6 INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,11 @@
+-- apps/go-server/sql/postgres/015_users_verification.sql
+--
+-- Migration #15 (Postgres): Optional account verification.
+--
+-- Postgres form of sql/015_users_verification.sql; see that file for context.
+
+ALTER TABLE users ADD COLUMN verified INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN verify_token_hash TEXT;
+ALTER TABLE users ADD COLUMN verify_expires_at TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_users_verify_token_hash ON users(verify_token_hash);
@@ -0,0 +1,13 @@
+-- apps/go-server/sql/postgres/016_streak_freezes.sql
+--
+-- Migration #16 (Postgres): Daily "streak freeze" tokens.
+--
+-- Postgres form of sql/016_streak_freezes.sql; see that file for context.
+
+ALTER TABLE users ADD COLUMN streak_freezes INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS daily_streak_freezes (
+  user_id TEXT NOT NULL,
+  date    TEXT NOT NULL,
+  PRIMARY KEY (user_id, date)
+);
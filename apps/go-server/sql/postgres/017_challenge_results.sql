@@ -0,0 +1,21 @@
+-- apps/go-server/sql/postgres/017_challenge_results.sql
+--
+-- Migration #17 (Postgres): Per-challenge leaderboards for classic games.
+--
+-- Postgres form of sql/017_challenge_results.sql; see that file for context.
+
+ALTER TABLE games ADD COLUMN link TEXT;
+
+CREATE TABLE IF NOT EXISTS challenge_results (
+  slug         TEXT NOT NULL,
+  player_id    TEXT NOT NULL,
+  user_id      TEXT,
+  game_id      TEXT NOT NULL,
+  won          INTEGER NOT NULL,
+  guesses      INTEGER NOT NULL,
+  duration_ms  INTEGER NOT NULL,
+  submitted_at TEXT NOT NULL,
+  PRIMARY KEY (slug, player_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_challenge_results_rank ON challenge_results(slug, won, guesses, duration_ms);
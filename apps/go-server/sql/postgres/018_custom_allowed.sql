@@ -0,0 +1,8 @@
+-- apps/go-server/sql/postgres/018_custom_allowed.sql
+--
+-- Migration #18 (Postgres): Per-game custom allowed-guess lists.
+--
+-- Postgres form of sql/018_custom_allowed.sql; see that file for context.
+
+ALTER TABLE short_links ADD COLUMN allowed TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE game_state ADD COLUMN allowed TEXT NOT NULL DEFAULT '[]';
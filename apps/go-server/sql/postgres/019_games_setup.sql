@@ -0,0 +1,7 @@
+-- apps/go-server/sql/postgres/019_games_setup.sql
+--
+-- Migration #19 (Postgres): Starting state of classic games, for startup recovery.
+--
+-- Postgres form of sql/019_games_setup.sql; see that file for context.
+
+ALTER TABLE games ADD COLUMN setup TEXT;
@@ -0,0 +1,18 @@
+-- apps/go-server/sql/postgres/020_users_username_key.sql
+--
+-- Migration #20 (Postgres): Canonical username key for case-insensitive uniqueness.
+--
+-- Postgres form of sql/020_users_username_key.sql; see that file for context.
+--
+-- Differences:
+--   • The oldest account per key is picked by created_at (SQLite uses rowid).
+
+ALTER TABLE users ADD COLUMN username_key TEXT;
+
+UPDATE users SET username_key = lower(users.username)
+  FROM (SELECT DISTINCT ON (lower(username)) id
+          FROM users
+         ORDER BY lower(username), created_at, id) AS first
+ WHERE users.id = first.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username_key ON users(username_key);
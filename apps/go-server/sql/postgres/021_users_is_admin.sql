@@ -0,0 +1,7 @@
+-- apps/go-server/sql/postgres/021_users_is_admin.sql
+--
+-- Migration #21 (Postgres): Per-account admin role.
+--
+-- Postgres form of sql/021_users_is_admin.sql; see that file for context.
+
+ALTER TABLE users ADD COLUMN is_admin INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,23 @@
+-- apps/go-server/sql/postgres/daily_results.sql
+--
+-- Migration (Postgres): Create `daily_results` table
+--
+-- Postgres form of sql/daily_results.sql; see that file for context.
+--
+-- Differences:
+--   • id is an identity column instead of AUTOINCREMENT.
+--   • created_at is TIMESTAMPTZ, so it reads back as UTC like SQLite's CURRENT_TIMESTAMP.
+
+CREATE TABLE IF NOT EXISTS daily_results (
+  id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+  user_id TEXT NOT NULL,
+  date TEXT NOT NULL,              -- YYYY-MM-DD (UTC or chosen tz)
+  word_index INTEGER NOT NULL,     -- index into answers list
+  guesses INTEGER NOT NULL,
+  elapsed_ms INTEGER NOT NULL,
+  created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE(user_id, date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_results_date        ON daily_results(date);
+CREATE INDEX IF NOT EXISTS idx_daily_results_date_time   ON daily_results(date, elapsed_ms);
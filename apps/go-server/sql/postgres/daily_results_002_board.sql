@@ -0,0 +1,7 @@
+-- apps/go-server/sql/postgres/daily_results_002_board.sql
+--
+-- Migration (Postgres): Persist the winning board for daily results.
+--
+-- Postgres form of sql/daily_results_002_board.sql; see that file for context.
+
+ALTER TABLE daily_results ADD COLUMN board TEXT;
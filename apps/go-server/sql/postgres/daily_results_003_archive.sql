@@ -0,0 +1,19 @@
+-- apps/go-server/sql/postgres/daily_results_003_archive.sql
+--
+-- Migration (Postgres): Create `daily_results_archive` for the retention job.
+--
+-- Postgres form of sql/daily_results_003_archive.sql; see that file for context.
+
+CREATE TABLE IF NOT EXISTS daily_results_archive (
+  id          BIGINT PRIMARY KEY,
+  user_id     TEXT NOT NULL,
+  date        TEXT NOT NULL,
+  word_index  INTEGER NOT NULL,
+  guesses     INTEGER NOT NULL,
+  elapsed_ms  INTEGER NOT NULL,
+  created_at  TIMESTAMPTZ,
+  board       TEXT,
+  archived_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_results_archive_date ON daily_results_archive(date);
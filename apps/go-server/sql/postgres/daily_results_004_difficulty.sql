@@ -0,0 +1,9 @@
+-- apps/go-server/sql/postgres/daily_results_004_difficulty.sql
+--
+-- Migration (Postgres): Record the difficulty each daily result was played at.
+--
+-- Postgres form of sql/daily_results_004_difficulty.sql; see that file for context.
+
+ALTER TABLE daily_results ADD COLUMN difficulty TEXT NOT NULL DEFAULT 'normal';
+
+CREATE INDEX IF NOT EXISTS idx_daily_results_date_difficulty ON daily_results(date, difficulty, elapsed_ms);
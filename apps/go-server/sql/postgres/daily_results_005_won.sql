@@ -0,0 +1,11 @@
+-- apps/go-server/sql/postgres/daily_results_005_won.sql
+--
+-- Migration (Postgres): Persist daily losses alongside wins.
+--
+-- Postgres form of sql/daily_results_005_won.sql; see that file for context.
+
+ALTER TABLE daily_results ADD COLUMN won INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE daily_results_archive ADD COLUMN won INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE daily_results_archive ADD COLUMN difficulty TEXT NOT NULL DEFAULT 'normal';
+
+CREATE INDEX IF NOT EXISTS idx_daily_results_date_won ON daily_results(date, won, elapsed_ms);
@@ -0,0 +1,22 @@
+-- apps/go-server/sql/postgres/daily_results_006_salt_version.sql
+--
+-- Migration (Postgres): Salt versioning for the daily word mapping.
+--
+-- Postgres form of sql/daily_results_006_salt_version.sql; see that file for context.
+--
+-- Differences:
+--   • ON CONFLICT DO NOTHING instead of INSERT OR IGNORE.
+
+CREATE TABLE IF NOT EXISTS daily_words (
+  date         TEXT PRIMARY KEY,            -- "YYYY-MM-DD"
+  word_index   INTEGER NOT NULL,
+  salt_version INTEGER NOT NULL,
+  created_at   TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE daily_results ADD COLUMN salt_version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE daily_results_archive ADD COLUMN salt_version INTEGER NOT NULL DEFAULT 1;
+
+INSERT INTO daily_words (date, word_index, salt_version)
+SELECT date, MIN(word_index), 1 FROM daily_results GROUP BY date
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,9 @@
+-- apps/go-server/sql/postgres/daily_results_007_fingerprint.sql
+--
+-- Migration (Postgres): Soft device fingerprint on guest daily results.
+--
+-- Postgres form of sql/daily_results_007_fingerprint.sql; see that file for context.
+
+ALTER TABLE daily_results ADD COLUMN fingerprint TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_daily_results_date_fingerprint ON daily_results(date, fingerprint);
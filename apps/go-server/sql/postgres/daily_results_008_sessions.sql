@@ -0,0 +1,33 @@
+-- apps/go-server/sql/postgres/daily_results_008_sessions.sql
+--
+-- Migration (Postgres): Durable in-progress daily sessions.
+--
+-- Postgres form of sql/daily_results_008_sessions.sql; see that file for context.
+--
+-- Differences:
+--   • Adds json_array_length(text), used by the session upsert.
+
+CREATE TABLE IF NOT EXISTS daily_sessions (
+  user_id       TEXT NOT NULL,
+  date          TEXT NOT NULL,
+  game_id       TEXT NOT NULL,
+  word_index    INTEGER NOT NULL,
+  salt_version  INTEGER NOT NULL DEFAULT 1,
+  guesses       TEXT NOT NULL DEFAULT '[]',
+  started_at    TEXT NOT NULL,
+  last_seen     TEXT NOT NULL,
+  active_ms     INTEGER NOT NULL DEFAULT 0,
+  finished      INTEGER NOT NULL DEFAULT 0,
+  won           INTEGER NOT NULL DEFAULT 0,
+  difficulty    TEXT NOT NULL DEFAULT 'normal',
+  fingerprint   TEXT NOT NULL DEFAULT '',
+  practice_code TEXT NOT NULL DEFAULT '',
+  PRIMARY KEY (user_id, date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_sessions_date ON daily_sessions(date);
+
+-- daily.Store.SaveSession compares json_array_length(guesses) on TEXT, as
+-- SQLite allows; Postgres only defines it for json.
+CREATE OR REPLACE FUNCTION json_array_length(text) RETURNS INTEGER
+  LANGUAGE SQL IMMUTABLE AS $$ SELECT json_array_length($1::json) $$;
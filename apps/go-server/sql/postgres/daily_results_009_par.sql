@@ -0,0 +1,7 @@
+-- apps/go-server/sql/postgres/daily_results_009_par.sql
+--
+-- Migration (Postgres): Solver par per daily date.
+--
+-- Postgres form of sql/daily_results_009_par.sql; see that file for context.
+
+ALTER TABLE daily_words ADD COLUMN par INTEGER;